@@ -0,0 +1,147 @@
+package nfe
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+)
+
+// AccessKeyLength is the number of digits in an NF-e access key (chave de
+// acesso), including the check digit.
+const AccessKeyLength = 44
+
+// emissionTypeNormal is the emission type (tpEmis) used for documents issued
+// under normal conditions, as opposed to contingency.
+const emissionTypeNormal = "1"
+
+// AccessKeyRegexp is used to check the format of an access key.
+var AccessKeyRegexp = regexp.MustCompile(`^\d{44}$`)
+
+// stateCodes maps the states (UF) to their numeric codes as defined by the
+// IBGE, used as the first two digits of the access key.
+var stateCodes = map[cbc.Code]string{
+	"RO": "11", "AC": "12", "AM": "13", "RR": "14", "PA": "15", "AP": "16", "TO": "17",
+	"MA": "21", "PI": "22", "CE": "23", "RN": "24", "PB": "25", "PE": "26", "AL": "27",
+	"SE": "28", "BA": "29", "MG": "31", "ES": "32", "RJ": "33", "SP": "35", "PR": "41",
+	"SC": "42", "RS": "43", "MS": "50", "MT": "51", "GO": "52", "DF": "53",
+}
+
+// AccessKey determines the 44-digit access key of an NF-e or NFC-e from the
+// invoice's details. The key is composed of:
+//
+//   - state code of the emitter (2 digits),
+//   - year and month of issue (4 digits, YYMM),
+//   - CNPJ of the emitter (14 digits),
+//   - document model (2 digits),
+//   - series (3 digits),
+//   - document number (9 digits),
+//   - emission type (1 digit),
+//   - numeric code (8 digits), derived from the invoice's UUID, and
+//   - check digit (1 digit), calculated using modulus 11.
+//
+// The invoice is expected to have been normalized and validated with this
+// addon beforehand.
+func AccessKey(inv *bill.Invoice) (string, error) {
+	if inv.Supplier == nil || inv.Supplier.TaxID == nil {
+		return "", errors.New("supplier tax ID required")
+	}
+	if len(inv.Supplier.Addresses) == 0 || inv.Supplier.Addresses[0] == nil {
+		return "", errors.New("supplier address required")
+	}
+	uf, ok := stateCodes[inv.Supplier.Addresses[0].State]
+	if !ok {
+		return "", fmt.Errorf("supplier state '%s' not recognized", inv.Supplier.Addresses[0].State)
+	}
+	if inv.UUID.IsZero() {
+		return "", errors.New("uuid required")
+	}
+	model := ModelNFe
+	if inv.Tax != nil && inv.Tax.Ext.Has(ExtKeyModel) {
+		model = inv.Tax.Ext[ExtKeyModel]
+	}
+	series, err := strconv.Atoi(inv.Series.String())
+	if err != nil || series < 0 || series > 999 {
+		return "", fmt.Errorf("series '%s' must be numeric with up to 3 digits", inv.Series)
+	}
+	num, err := strconv.Atoi(inv.Code.String())
+	if err != nil || num < 1 || num > 999999999 {
+		return "", fmt.Errorf("code '%s' must be numeric with up to 9 digits", inv.Code)
+	}
+
+	var b strings.Builder
+	b.WriteString(uf)
+	fmt.Fprintf(&b, "%02d%02d", inv.IssueDate.Year%100, int(inv.IssueDate.Month))
+	fmt.Fprintf(&b, "%014s", inv.Supplier.TaxID.Code)
+	b.WriteString(model.String())
+	fmt.Fprintf(&b, "%03d%09d", series, num)
+	b.WriteString(emissionTypeNormal)
+	fmt.Fprintf(&b, "%08d", numericCode(inv, num))
+
+	key := b.String()
+	dv, err := AccessKeyCheckDigit(key)
+	if err != nil {
+		return "", err
+	}
+	return key + strconv.Itoa(dv), nil
+}
+
+// AccessKeyCheckDigit calculates the modulus 11 check digit for the first 43
+// digits of an access key. Weights from 2 to 9 are applied to each digit
+// starting from the right, and a remainder of 0 or 1 results in 0.
+func AccessKeyCheckDigit(key string) (int, error) {
+	if len(key) != AccessKeyLength-1 {
+		return 0, fmt.Errorf("expected %d digits", AccessKeyLength-1)
+	}
+	sum := 0
+	w := 2
+	for i := len(key) - 1; i >= 0; i-- {
+		d := key[i]
+		if d < '0' || d > '9' {
+			return 0, errors.New("must contain only digits")
+		}
+		sum += int(d-'0') * w
+		w++
+		if w > 9 {
+			w = 2
+		}
+	}
+	r := sum % 11
+	if r < 2 {
+		return 0, nil
+	}
+	return 11 - r, nil
+}
+
+// ValidAccessKey checks that the provided key has the expected format and
+// check digit.
+func ValidAccessKey(key string) bool {
+	if !AccessKeyRegexp.MatchString(key) {
+		return false
+	}
+	dv, err := AccessKeyCheckDigit(key[:AccessKeyLength-1])
+	if err != nil {
+		return false
+	}
+	return int(key[AccessKeyLength-1]-'0') == dv
+}
+
+// numericCode provides the random numeric code (cNF) of the access key,
+// derived from the invoice's UUID so that it is stable for the same document.
+// The code may not be equal to the document number.
+func numericCode(inv *bill.Invoice, num int) int {
+	b := inv.UUID.Bytes()
+	var n uint64
+	for _, v := range b[len(b)-8:] {
+		n = n<<8 | uint64(v)
+	}
+	code := int(n % 100000000)
+	if code == num {
+		code = (code + 1) % 100000000
+	}
+	return code
+}
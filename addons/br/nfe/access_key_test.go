@@ -0,0 +1,114 @@
+package nfe_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/br/nfe"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessKey(t *testing.T) {
+	t.Run("valid invoice", func(t *testing.T) {
+		inv := testInvoice(t)
+		key, err := nfe.AccessKey(inv)
+		require.NoError(t, err)
+		assert.Equal(t, "35241155263640000186550010000010241286696880", key)
+		assert.Len(t, key, nfe.AccessKeyLength)
+		assert.True(t, nfe.ValidAccessKey(key))
+	})
+
+	t.Run("NFC-e model", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Tax.Ext[nfe.ExtKeyModel] = nfe.ModelNFCe
+		key, err := nfe.AccessKey(inv)
+		require.NoError(t, err)
+		assert.Equal(t, "65", key[20:22])
+		assert.True(t, nfe.ValidAccessKey(key))
+	})
+
+	t.Run("numeric code differs from document number", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Code = "28669688"
+		key, err := nfe.AccessKey(inv)
+		require.NoError(t, err)
+		assert.Equal(t, "028669688", key[25:34])
+		assert.Equal(t, "28669689", key[35:43])
+	})
+
+	t.Run("missing supplier tax ID", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.TaxID = nil
+		_, err := nfe.AccessKey(inv)
+		assert.EqualError(t, err, "supplier tax ID required")
+	})
+
+	t.Run("missing supplier address", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.Addresses = nil
+		_, err := nfe.AccessKey(inv)
+		assert.EqualError(t, err, "supplier address required")
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.Addresses = []*org.Address{{State: "XX"}}
+		_, err := nfe.AccessKey(inv)
+		assert.EqualError(t, err, "supplier state 'XX' not recognized")
+	})
+
+	t.Run("missing uuid", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.UUID = uuid.Empty
+		_, err := nfe.AccessKey(inv)
+		assert.EqualError(t, err, "uuid required")
+	})
+
+	t.Run("invalid series", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Series = "A1"
+		_, err := nfe.AccessKey(inv)
+		assert.EqualError(t, err, "series 'A1' must be numeric with up to 3 digits")
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Code = "1234567890"
+		_, err := nfe.AccessKey(inv)
+		assert.EqualError(t, err, "code '1234567890' must be numeric with up to 9 digits")
+	})
+}
+
+func TestAccessKeyCheckDigit(t *testing.T) {
+	tests := []struct {
+		key string
+		dv  int
+		err string
+	}{
+		{key: "5206043300991100250655012000000780026730161", dv: 5},
+		{key: "3524115526364000018655001000001024128669688", dv: 0},
+		{key: "0000000000000000000000000000000000000000000", dv: 0},
+		{key: "123", err: "expected 43 digits"},
+		{key: "520604330099110025065501200000078002673016A", err: "must contain only digits"},
+	}
+	for _, ts := range tests {
+		t.Run(ts.key, func(t *testing.T) {
+			dv, err := nfe.AccessKeyCheckDigit(ts.key)
+			if ts.err != "" {
+				assert.EqualError(t, err, ts.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ts.dv, dv)
+		})
+	}
+}
+
+func TestValidAccessKey(t *testing.T) {
+	assert.True(t, nfe.ValidAccessKey("52060433009911002506550120000007800267301615"))
+	assert.False(t, nfe.ValidAccessKey("52060433009911002506550120000007800267301614"))
+	assert.False(t, nfe.ValidAccessKey("5206043300991100250655012000000780026730161"))
+	assert.False(t, nfe.ValidAccessKey(""))
+}
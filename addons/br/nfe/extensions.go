@@ -0,0 +1,362 @@
+package nfe
+
+import (
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pkg/here"
+)
+
+// Brazilian extension keys required to issue NF-e and NFC-e documents.
+const (
+	ExtKeyModel     cbc.Key = "br-nfe-model"
+	ExtKeyPurpose   cbc.Key = "br-nfe-purpose"
+	ExtKeyRegime    cbc.Key = "br-nfe-regime"
+	ExtKeyNCM       cbc.Key = "br-nfe-ncm"
+	ExtKeyCFOP      cbc.Key = "br-nfe-cfop"
+	ExtKeyICMSCST   cbc.Key = "br-nfe-icms-cst"
+	ExtKeyICMSCSOSN cbc.Key = "br-nfe-icms-csosn"
+)
+
+// Document models
+const (
+	ModelNFe  cbc.Code = "55"
+	ModelNFCe cbc.Code = "65"
+)
+
+// Tax regime codes (CRT) for the emitter
+const (
+	RegimeSimples       cbc.Code = "1"
+	RegimeSimplesExcess cbc.Code = "2"
+	RegimeNormal        cbc.Code = "3"
+	RegimeMEI           cbc.Code = "4"
+)
+
+var extensions = []*cbc.Definition{
+	{
+		Key: ExtKeyModel,
+		Name: i18n.String{
+			i18n.EN: "Document Model",
+			i18n.PT: "Modelo do Documento Fiscal",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Model of the fiscal document, set automatically to NFC-e for invoices with
+				the "simplified" tag, or NF-e otherwise.
+			`),
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: ModelNFe,
+				Name: i18n.String{
+					i18n.EN: "NF-e",
+					i18n.PT: "NF-e",
+				},
+			},
+			{
+				Code: ModelNFCe,
+				Name: i18n.String{
+					i18n.EN: "NFC-e",
+					i18n.PT: "NFC-e",
+				},
+			},
+		},
+	},
+	{
+		Key: ExtKeyPurpose,
+		Name: i18n.String{
+			i18n.EN: "Purpose",
+			i18n.PT: "Finalidade de Emissão",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Purpose of the document (finNFe), set automatically according to the
+				invoice type.
+			`),
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: "1",
+				Name: i18n.String{
+					i18n.EN: "Normal",
+					i18n.PT: "NF-e normal",
+				},
+			},
+			{
+				Code: "2",
+				Name: i18n.String{
+					i18n.EN: "Complementary",
+					i18n.PT: "NF-e complementar",
+				},
+			},
+			{
+				Code: "3",
+				Name: i18n.String{
+					i18n.EN: "Adjustment",
+					i18n.PT: "NF-e de ajuste",
+				},
+			},
+			{
+				Code: "4",
+				Name: i18n.String{
+					i18n.EN: "Return of goods",
+					i18n.PT: "Devolução de mercadoria",
+				},
+			},
+		},
+	},
+	{
+		Key: ExtKeyRegime,
+		Name: i18n.String{
+			i18n.EN: "Tax Regime",
+			i18n.PT: "Código de Regime Tributário",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Tax regime code (CRT) of the emitter, which determines whether ICMS taxes
+				are classified using CST or CSOSN codes.
+			`),
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: RegimeSimples,
+				Name: i18n.String{
+					i18n.EN: "Simples Nacional",
+					i18n.PT: "Simples Nacional",
+				},
+			},
+			{
+				Code: RegimeSimplesExcess,
+				Name: i18n.String{
+					i18n.EN: "Simples Nacional, gross revenue sublimit exceeded",
+					i18n.PT: "Simples Nacional, excesso de sublimite de receita bruta",
+				},
+			},
+			{
+				Code: RegimeNormal,
+				Name: i18n.String{
+					i18n.EN: "Normal Regime",
+					i18n.PT: "Regime Normal",
+				},
+			},
+			{
+				Code: RegimeMEI,
+				Name: i18n.String{
+					i18n.EN: "Simples Nacional, Individual Micro-entrepreneur (MEI)",
+					i18n.PT: "Simples Nacional, Microempreendedor Individual (MEI)",
+				},
+			},
+		},
+	},
+	{
+		Key: ExtKeyNCM,
+		Name: i18n.String{
+			i18n.EN: "NCM Code",
+			i18n.PT: "Código NCM",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Mercosur Common Nomenclature (NCM) code used to classify goods, which
+				consists of 8 digits.
+
+				List of codes from the Receita Federal:
+
+				* https://portalunico.siscomex.gov.br/classif/#/sumario
+			`),
+		},
+		Pattern: `^\d{8}$`,
+	},
+	{
+		Key: ExtKeyCFOP,
+		Name: i18n.String{
+			i18n.EN: "CFOP Code",
+			i18n.PT: "Código Fiscal de Operações e Prestações",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Fiscal Code of Operations and Services (CFOP) that describes the nature of the
+				operation. The first digit indicates whether it is an entry (1, 2 or 3) or an
+				exit (5, 6 or 7), within the same state, between states, or abroad respectively.
+
+				May be set in the invoice's tax extensions to apply to all lines, or in the
+				item's extensions to override the value for a specific line.
+			`),
+		},
+		Pattern: `^[1235-7]\d{3}$`,
+	},
+	{
+		Key: ExtKeyICMSCST,
+		Name: i18n.String{
+			i18n.EN: "ICMS Tax Situation Code",
+			i18n.PT: "Código de Situação Tributária do ICMS",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Tax Situation Code (CST) for ICMS used by emitters in the normal regime.
+			`),
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: "00",
+				Name: i18n.String{
+					i18n.EN: "Fully taxed",
+					i18n.PT: "Tributada integralmente",
+				},
+			},
+			{
+				Code: "10",
+				Name: i18n.String{
+					i18n.EN: "Taxed with ICMS collected by tax substitution",
+					i18n.PT: "Tributada e com cobrança do ICMS por substituição tributária",
+				},
+			},
+			{
+				Code: "20",
+				Name: i18n.String{
+					i18n.EN: "With reduction of the tax base",
+					i18n.PT: "Com redução de base de cálculo",
+				},
+			},
+			{
+				Code: "30",
+				Name: i18n.String{
+					i18n.EN: "Exempt or not taxed, with ICMS collected by tax substitution",
+					i18n.PT: "Isenta ou não tributada e com cobrança do ICMS por substituição tributária",
+				},
+			},
+			{
+				Code: "40",
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.PT: "Isenta",
+				},
+			},
+			{
+				Code: "41",
+				Name: i18n.String{
+					i18n.EN: "Not taxed",
+					i18n.PT: "Não tributada",
+				},
+			},
+			{
+				Code: "50",
+				Name: i18n.String{
+					i18n.EN: "Suspended",
+					i18n.PT: "Suspensão",
+				},
+			},
+			{
+				Code: "51",
+				Name: i18n.String{
+					i18n.EN: "Deferred",
+					i18n.PT: "Diferimento",
+				},
+			},
+			{
+				Code: "60",
+				Name: i18n.String{
+					i18n.EN: "ICMS previously collected by tax substitution",
+					i18n.PT: "ICMS cobrado anteriormente por substituição tributária",
+				},
+			},
+			{
+				Code: "70",
+				Name: i18n.String{
+					i18n.EN: "With reduction of the tax base and ICMS collected by tax substitution",
+					i18n.PT: "Com redução de base de cálculo e cobrança do ICMS por substituição tributária",
+				},
+			},
+			{
+				Code: "90",
+				Name: i18n.String{
+					i18n.EN: "Others",
+					i18n.PT: "Outras",
+				},
+			},
+		},
+	},
+	{
+		Key: ExtKeyICMSCSOSN,
+		Name: i18n.String{
+			i18n.EN: "ICMS Simples Nacional Operation Code",
+			i18n.PT: "Código de Situação da Operação no Simples Nacional",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Operation Situation Code (CSOSN) for ICMS used by emitters in the Simples
+				Nacional regime.
+			`),
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: "101",
+				Name: i18n.String{
+					i18n.EN: "Taxed with permission for credit",
+					i18n.PT: "Tributada com permissão de crédito",
+				},
+			},
+			{
+				Code: "102",
+				Name: i18n.String{
+					i18n.EN: "Taxed without permission for credit",
+					i18n.PT: "Tributada sem permissão de crédito",
+				},
+			},
+			{
+				Code: "103",
+				Name: i18n.String{
+					i18n.EN: "Exempt from ICMS for gross revenue range",
+					i18n.PT: "Isenção do ICMS para faixa de receita bruta",
+				},
+			},
+			{
+				Code: "201",
+				Name: i18n.String{
+					i18n.EN: "Taxed with permission for credit and ICMS collected by tax substitution",
+					i18n.PT: "Tributada com permissão de crédito e com cobrança do ICMS por substituição tributária",
+				},
+			},
+			{
+				Code: "202",
+				Name: i18n.String{
+					i18n.EN: "Taxed without permission for credit and ICMS collected by tax substitution",
+					i18n.PT: "Tributada sem permissão de crédito e com cobrança do ICMS por substituição tributária",
+				},
+			},
+			{
+				Code: "203",
+				Name: i18n.String{
+					i18n.EN: "Exempt for gross revenue range and ICMS collected by tax substitution",
+					i18n.PT: "Isenção do ICMS para faixa de receita bruta e com cobrança do ICMS por substituição tributária",
+				},
+			},
+			{
+				Code: "300",
+				Name: i18n.String{
+					i18n.EN: "Immune",
+					i18n.PT: "Imune",
+				},
+			},
+			{
+				Code: "400",
+				Name: i18n.String{
+					i18n.EN: "Not taxed by Simples Nacional",
+					i18n.PT: "Não tributada pelo Simples Nacional",
+				},
+			},
+			{
+				Code: "500",
+				Name: i18n.String{
+					i18n.EN: "ICMS previously collected by tax substitution or by anticipation",
+					i18n.PT: "ICMS cobrado anteriormente por substituição tributária ou por antecipação",
+				},
+			},
+			{
+				Code: "900",
+				Name: i18n.String{
+					i18n.EN: "Others",
+					i18n.PT: "Outros",
+				},
+			},
+		},
+	},
+}
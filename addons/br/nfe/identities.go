@@ -0,0 +1,21 @@
+package nfe
+
+import (
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+)
+
+// Brazilian identity keys required to issue NF-e documents.
+const (
+	IdentityKeyStateReg cbc.Key = "br-nfe-state-reg"
+)
+
+var identities = []*cbc.Definition{
+	{
+		Key: IdentityKeyStateReg,
+		Name: i18n.String{
+			i18n.EN: "Company State Registration",
+			i18n.PT: "Inscrição Estadual da Empresa",
+		},
+	},
+}
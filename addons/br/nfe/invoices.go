@@ -0,0 +1,237 @@
+package nfe
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/br"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+var (
+	// SeriesRegexp is the regular expression used to validate the invoice series
+	SeriesRegexp = regexp.MustCompile(`^\d{1,3}$`)
+	// CodeRegexp is the regular expression used to validate the invoice code
+	CodeRegexp = regexp.MustCompile(`^[1-9]\d{0,8}$`)
+)
+
+func validateInvoice(inv *bill.Invoice) error {
+	if inv == nil {
+		return nil
+	}
+	simplified := inv.HasTags(tax.TagSimplified)
+
+	return validation.ValidateStruct(inv,
+		validation.Field(&inv.Type,
+			validation.In(
+				bill.InvoiceTypeStandard,
+				bill.InvoiceTypeCreditNote,
+			),
+		),
+		validation.Field(&inv.Series,
+			validation.Required,
+			validation.Match(SeriesRegexp),
+		),
+		validation.Field(&inv.Code, validation.Match(CodeRegexp)),
+		validation.Field(&inv.Supplier,
+			validation.By(validateSupplier),
+			validation.Skip,
+		),
+		validation.Field(&inv.Customer,
+			validation.When(
+				!simplified,
+				validation.Required,
+			),
+			validation.By(validateCustomer),
+			validation.Skip,
+		),
+		validation.Field(&inv.Preceding,
+			validation.When(
+				inv.Type.In(bill.InvoiceTypeCreditNote),
+				validation.Required,
+			),
+			validation.Skip,
+		),
+		validation.Field(&inv.Tax,
+			validation.Required,
+			validation.By(validateInvoiceTax),
+			validation.Skip,
+		),
+		validation.Field(&inv.Lines,
+			validation.Each(
+				validation.By(validateInvoiceLine(inv)),
+				validation.Skip,
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateInvoiceTax(value any) error {
+	obj, _ := value.(*bill.Tax)
+	if obj == nil {
+		return nil
+	}
+	return validation.ValidateStruct(obj,
+		validation.Field(&obj.Ext,
+			tax.ExtensionsRequire(
+				ExtKeyModel,
+				ExtKeyPurpose,
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateSupplier(value any) error {
+	obj, _ := value.(*org.Party)
+	if obj == nil {
+		return nil
+	}
+
+	return validation.ValidateStruct(obj,
+		validation.Field(&obj.TaxID,
+			validation.Required,
+			tax.RequireIdentityCode,
+			validation.Skip,
+		),
+		validation.Field(&obj.Identities,
+			org.RequireIdentityKey(IdentityKeyStateReg),
+			validation.Skip,
+		),
+		validation.Field(&obj.Name, validation.Required),
+		validation.Field(&obj.Addresses,
+			validation.Required,
+			validation.Each(
+				validation.Required,
+				validation.By(validateAddress),
+			),
+			validation.Skip,
+		),
+		validation.Field(&obj.Ext,
+			tax.ExtensionsRequire(ExtKeyRegime),
+			validation.Skip,
+		),
+	)
+}
+
+func validateCustomer(value any) error {
+	obj, _ := value.(*org.Party)
+	if obj == nil {
+		return nil
+	}
+	domestic := obj.TaxID != nil && obj.TaxID.Country.In(l10n.BR.Tax())
+
+	return validation.ValidateStruct(obj,
+		validation.Field(&obj.TaxID,
+			validation.Required,
+			validation.When(
+				domestic,
+				tax.RequireIdentityCode,
+			),
+			validation.Skip,
+		),
+		validation.Field(&obj.Name, validation.Required),
+		validation.Field(&obj.Addresses,
+			validation.Required,
+			validation.When(
+				domestic,
+				validation.Each(
+					validation.Required,
+					validation.By(validateAddress),
+				),
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateAddress(value any) error {
+	obj, _ := value.(*org.Address)
+	if obj == nil {
+		return nil
+	}
+
+	return validation.ValidateStruct(obj,
+		validation.Field(&obj.Street, validation.Required),
+		validation.Field(&obj.Number, validation.Required),
+		validation.Field(&obj.Locality, validation.Required),
+		validation.Field(&obj.State, validation.Required),
+		validation.Field(&obj.Code, validation.Required),
+	)
+}
+
+// validateInvoiceLine checks the line details that depend on the rest of the
+// invoice: the CFOP, which may be defined for the whole invoice, and the ICMS
+// classification, which depends on the supplier's tax regime.
+func validateInvoiceLine(inv *bill.Invoice) func(value any) error {
+	return func(value any) error {
+		line, _ := value.(*bill.Line)
+		if line == nil {
+			return nil
+		}
+		return validation.ValidateStruct(line,
+			validation.Field(&line.Item,
+				validation.By(validateLineCFOP(inv)),
+				validation.Skip,
+			),
+			validation.Field(&line.Taxes,
+				validation.By(validateLineICMS(inv)),
+				validation.Skip,
+			),
+		)
+	}
+}
+
+func validateLineCFOP(inv *bill.Invoice) func(value any) error {
+	return func(value any) error {
+		item, _ := value.(*org.Item)
+		if item == nil {
+			return nil
+		}
+		if item.Ext.Has(ExtKeyCFOP) || (inv.Tax != nil && inv.Tax.Ext.Has(ExtKeyCFOP)) {
+			return nil
+		}
+		return errors.New("CFOP required in item or invoice tax extensions")
+	}
+}
+
+func validateLineICMS(inv *bill.Invoice) func(value any) error {
+	return func(value any) error {
+		ts, _ := value.(tax.Set)
+		tc := ts.Get(br.TaxCategoryICMS)
+		if tc == nil {
+			return nil
+		}
+		key, other := ExtKeyICMSCST, ExtKeyICMSCSOSN
+		if simplesNacional(inv.Supplier) {
+			key, other = ExtKeyICMSCSOSN, ExtKeyICMSCST
+		}
+		err := validation.ValidateStruct(tc,
+			validation.Field(&tc.Ext,
+				tax.ExtensionsRequire(key),
+				tax.ExtensionsExclude(other),
+				validation.Skip,
+			),
+		)
+		if err != nil {
+			return validation.Errors{
+				br.TaxCategoryICMS.String(): err,
+			}
+		}
+		return nil
+	}
+}
+
+// simplesNacional returns true when the supplier's tax regime requires
+// ICMS to be classified using CSOSN codes instead of CST.
+func simplesNacional(sup *org.Party) bool {
+	if sup == nil {
+		return false
+	}
+	return sup.Ext.Get(ExtKeyRegime).In(RegimeSimples, RegimeMEI)
+}
@@ -0,0 +1,294 @@
+package nfe_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/br/nfe"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/br"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/gobl/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInvoice(t *testing.T) *bill.Invoice {
+	t.Helper()
+	inv := &bill.Invoice{
+		Regime:    tax.WithRegime("BR"),
+		Addons:    tax.WithAddons(nfe.V4),
+		Identify:  uuid.Identify{UUID: uuid.MustParse("0193305c-a7be-730d-b552-2093f0df2df8")},
+		Series:    "1",
+		Code:      "1024",
+		IssueDate: cal.MakeDate(2024, 11, 18),
+		Currency:  "BRL",
+		Supplier: &org.Party{
+			Name: "Distribuidora Paulista de Alimentos Ltda.",
+			TaxID: &tax.Identity{
+				Country: "BR",
+				Code:    "55263640000186",
+			},
+			Identities: []*org.Identity{
+				{
+					Key:  nfe.IdentityKeyStateReg,
+					Code: "110042490114",
+				},
+			},
+			Addresses: []*org.Address{
+				{
+					Number:   "1500",
+					Street:   "Avenida Paulista",
+					Locality: "Bela Vista",
+					State:    "SP",
+					Code:     "01310-200",
+					Country:  "BR",
+				},
+			},
+			Ext: tax.Extensions{
+				nfe.ExtKeyRegime: nfe.RegimeNormal,
+			},
+		},
+		Customer: &org.Party{
+			Name: "Mercado Bom Preço Ltda.",
+			TaxID: &tax.Identity{
+				Country: "BR",
+				Code:    "46602178000103",
+			},
+			Addresses: []*org.Address{
+				{
+					Number:   "320",
+					Street:   "Rua da Consolação",
+					Locality: "Consolação",
+					State:    "SP",
+					Code:     "01302-000",
+					Country:  "BR",
+				},
+			},
+		},
+		Tax: &bill.Tax{
+			Ext: tax.Extensions{
+				nfe.ExtKeyCFOP: "5102",
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(50, 0),
+				Item: &org.Item{
+					Name:  "Arroz Branco Tipo 1 5kg",
+					Price: num.MakeAmount(2490, 2),
+					Ext: tax.Extensions{
+						nfe.ExtKeyNCM: "10063021",
+					},
+				},
+				Taxes: tax.Set{
+					{
+						Category: br.TaxCategoryICMS,
+						Percent:  num.NewPercentage(18, 2),
+						Ext: tax.Extensions{
+							nfe.ExtKeyICMSCST: "00",
+						},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, inv.Calculate())
+	return inv
+}
+
+func TestInvoiceScenarios(t *testing.T) {
+	t.Run("standard NF-e", func(t *testing.T) {
+		inv := testInvoice(t)
+		assert.Equal(t, nfe.ModelNFe, inv.Tax.Ext[nfe.ExtKeyModel])
+		assert.Equal(t, cbc.Code("1"), inv.Tax.Ext[nfe.ExtKeyPurpose])
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("simplified NFC-e", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.SetTags(tax.TagSimplified)
+		inv.Customer = nil
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, nfe.ModelNFCe, inv.Tax.Ext[nfe.ExtKeyModel])
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("credit note", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Type = bill.InvoiceTypeCreditNote
+		inv.Preceding = []*org.DocumentRef{
+			{
+				Series:    "1",
+				Code:      "1000",
+				IssueDate: cal.NewDate(2024, 11, 1),
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, cbc.Code("4"), inv.Tax.Ext[nfe.ExtKeyPurpose])
+		assert.NoError(t, inv.Validate())
+	})
+}
+
+func TestInvoiceValidation(t *testing.T) {
+	t.Run("unsupported type", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Type = bill.InvoiceTypeProforma
+		assert.ErrorContains(t, inv.Validate(), "type: must be a valid value")
+	})
+
+	t.Run("credit note without preceding", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Type = bill.InvoiceTypeCreditNote
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "preceding: cannot be blank")
+	})
+
+	t.Run("missing series", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Series = ""
+		assert.ErrorContains(t, inv.Validate(), "series: cannot be blank")
+	})
+
+	t.Run("invalid series", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Series = "1234"
+		assert.ErrorContains(t, inv.Validate(), "series: must be in a valid format")
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Code = "0001"
+		assert.ErrorContains(t, inv.Validate(), "code: must be in a valid format")
+	})
+
+	t.Run("missing model", func(t *testing.T) {
+		inv := testInvoice(t)
+		delete(inv.Tax.Ext, nfe.ExtKeyModel)
+		assert.ErrorContains(t, inv.Validate(), "tax: (ext: (br-nfe-model: required.).)")
+	})
+}
+
+func TestSupplierValidation(t *testing.T) {
+	t.Run("missing tax ID", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.TaxID = nil
+		assert.ErrorContains(t, inv.Validate(), "supplier: (tax_id: cannot be blank.)")
+	})
+
+	t.Run("missing state registration", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.Identities = nil
+		assert.ErrorContains(t, inv.Validate(), "identities: missing key br-nfe-state-reg")
+	})
+
+	t.Run("missing regime", func(t *testing.T) {
+		inv := testInvoice(t)
+		delete(inv.Supplier.Ext, nfe.ExtKeyRegime)
+		assert.ErrorContains(t, inv.Validate(), "supplier: (ext: (br-nfe-regime: required.).)")
+	})
+
+	t.Run("incomplete address", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.Addresses[0].Number = ""
+		assert.ErrorContains(t, inv.Validate(), "supplier: (addresses: (0: (num: cannot be blank.).).)")
+	})
+}
+
+func TestCustomerValidation(t *testing.T) {
+	t.Run("missing customer", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer = nil
+		assert.ErrorContains(t, inv.Validate(), "customer: cannot be blank")
+	})
+
+	t.Run("missing tax ID code", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer.TaxID.Code = ""
+		assert.ErrorContains(t, inv.Validate(), "customer: (tax_id: (code: cannot be blank.).)")
+	})
+
+	t.Run("foreign customer", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer.TaxID = &tax.Identity{Country: "AR"}
+		inv.Customer.Addresses = []*org.Address{
+			{
+				Locality: "Buenos Aires",
+				Country:  l10n.AR.ISO(),
+			},
+		}
+		inv.Tax.Ext[nfe.ExtKeyCFOP] = "7102"
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("incomplete address", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer.Addresses[0].State = ""
+		assert.ErrorContains(t, inv.Validate(), "customer: (addresses: (0: (state: cannot be blank.).).)")
+	})
+}
+
+func TestLineValidation(t *testing.T) {
+	t.Run("missing ICMS", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Lines[0].Taxes = tax.Set{
+			{
+				Category: br.TaxCategoryIPI,
+				Percent:  num.NewPercentage(5, 2),
+			},
+		}
+		assert.ErrorContains(t, inv.Validate(), "taxes: missing category ICMS")
+	})
+
+	t.Run("missing NCM", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Lines[0].Item.Ext = nil
+		assert.ErrorContains(t, inv.Validate(), "item: (ext: (br-nfe-ncm: required.).)")
+	})
+
+	t.Run("CFOP in item", func(t *testing.T) {
+		inv := testInvoice(t)
+		delete(inv.Tax.Ext, nfe.ExtKeyCFOP)
+		inv.Lines[0].Item.Ext[nfe.ExtKeyCFOP] = "5405"
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("missing CFOP", func(t *testing.T) {
+		inv := testInvoice(t)
+		delete(inv.Tax.Ext, nfe.ExtKeyCFOP)
+		assert.ErrorContains(t, inv.Validate(), "lines: (0: (item: CFOP required in item or invoice tax extensions.).)")
+	})
+
+	t.Run("invalid CFOP", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Tax.Ext[nfe.ExtKeyCFOP] = "4102"
+		assert.ErrorContains(t, inv.Validate(), "br-nfe-cfop: does not match pattern")
+	})
+
+	t.Run("missing CST", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Lines[0].Taxes[0].Ext = nil
+		assert.ErrorContains(t, inv.Validate(), "lines: (0: (taxes: (ICMS: (ext: (br-nfe-icms-cst: required.).).).)")
+	})
+
+	t.Run("CSOSN in normal regime", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Lines[0].Taxes[0].Ext[nfe.ExtKeyICMSCSOSN] = "102"
+		assert.ErrorContains(t, inv.Validate(), "br-nfe-icms-csosn: must be blank")
+	})
+
+	t.Run("CSOSN in simples nacional", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.Ext[nfe.ExtKeyRegime] = nfe.RegimeSimples
+		assert.ErrorContains(t, inv.Validate(), "br-nfe-icms-csosn: required")
+
+		inv.Lines[0].Taxes[0].Ext = tax.Extensions{
+			nfe.ExtKeyICMSCSOSN: "102",
+		}
+		assert.NoError(t, inv.Validate())
+	})
+}
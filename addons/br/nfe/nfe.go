@@ -0,0 +1,59 @@
+// Package nfe handles extensions and validation rules to issue NF-e and
+// NFC-e documents in Brazil.
+package nfe
+
+import (
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/pkg/here"
+	"github.com/invopop/gobl/tax"
+)
+
+const (
+	// V4 identifies the NF-e addon version
+	V4 cbc.Key = "br-nfe-v4"
+)
+
+// Stamps provided by the SEFAZ once the document has been authorized.
+const (
+	StampAccessKey cbc.Key = "br-nfe-access-key"
+	StampProtocol  cbc.Key = "br-nfe-protocol"
+)
+
+func init() {
+	tax.RegisterAddonDef(newAddon())
+}
+
+func newAddon() *tax.AddonDef {
+	return &tax.AddonDef{
+		Key: V4,
+		Name: i18n.String{
+			i18n.EN: "Brazil NF-e 4.00",
+		},
+		Description: i18n.String{
+			i18n.EN: here.Doc(`
+				Extensions and validation rules to issue electronic invoices for goods in
+				Brazil, either as an NF-e (model 55) or, for invoices with the "simplified"
+				tag, an NFC-e (model 65), according to layout version 4.00.
+			`),
+		},
+		Extensions: extensions,
+		Identities: identities,
+		Scenarios:  scenarios,
+		Validator:  validate,
+	}
+}
+
+func validate(doc any) error {
+	switch obj := doc.(type) {
+	case *bill.Invoice:
+		return validateInvoice(obj)
+	case *bill.Line:
+		return validateLine(obj)
+	case *org.Item:
+		return validateItem(obj)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package nfe
+
+import (
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+)
+
+var scenarios = []*tax.ScenarioSet{
+	invoiceScenarios,
+}
+
+var invoiceScenarios = &tax.ScenarioSet{
+	Schema: bill.ShortSchemaInvoice,
+	List: []*tax.Scenario{
+		// ** Document Model **
+		{
+			Ext: tax.Extensions{
+				ExtKeyModel: ModelNFe,
+			},
+		},
+		{
+			Tags: []cbc.Key{tax.TagSimplified},
+			Ext: tax.Extensions{
+				ExtKeyModel: ModelNFCe,
+			},
+		},
+		// ** Purpose **
+		{
+			Types: []cbc.Key{bill.InvoiceTypeStandard},
+			Ext: tax.Extensions{
+				ExtKeyPurpose: "1",
+			},
+		},
+		{
+			Types: []cbc.Key{bill.InvoiceTypeCreditNote},
+			Ext: tax.Extensions{
+				ExtKeyPurpose: "4",
+			},
+		},
+	},
+}
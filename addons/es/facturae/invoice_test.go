@@ -6,6 +6,7 @@ import (
 	"github.com/invopop/gobl/addons/es/facturae"
 	"github.com/invopop/gobl/bill"
 	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/org"
 	"github.com/invopop/gobl/tax"
@@ -82,7 +83,7 @@ func testInvoiceStandard(t *testing.T) *bill.Invoice {
 		Currency: "EUR",
 		Tax: &bill.Tax{
 			// Addons:        []cbc.Key{facturae.KeyV3},
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			Name: "Test Supplier",
@@ -1,6 +1,7 @@
 package tbai
 
 import (
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/i18n"
 	"github.com/invopop/gobl/pkg/here"
@@ -114,6 +115,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Exempt: pursuant to Article 20 of the Foral VAT Law",
 					i18n.ES: "Exenta: por el artículo 20 de la Norma Foral del IVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-132",
+				},
 			},
 			{
 				Code: "E2",
@@ -121,6 +125,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Exempt: pursuant to Article 21 of the Foral VAT Law",
 					i18n.ES: "Exenta: por el artículo 21 de la Norma Foral del IVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-G",
+				},
 			},
 			{
 				Code: "E3",
@@ -128,6 +135,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Exempt: pursuant to Article 22 of the Foral VAT Law",
 					i18n.ES: "Exenta: por el artículo 22 de la Norma Foral del IVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-148",
+				},
 			},
 			{
 				Code: "E4",
@@ -142,6 +152,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Exempt: pursuant to Article 25 of the Foral VAT law",
 					i18n.ES: "Exenta: por el artículo 25 de la Norma Foral del IVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-IC",
+				},
 			},
 			{
 				Code: "E6",
@@ -156,6 +169,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Not subject: pursuant to Article 7 of the VAT Law - other cases of non-subject",
 					i18n.ES: "No sujeto: por el artículo 7 de la Ley del IVA - otros supuestos de no sujeción",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-O",
+				},
 			},
 			{
 				Code: "RL",
@@ -163,6 +179,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Not subject: pursuant to localization rules",
 					i18n.ES: "No sujeto: por reglas de localización",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-O",
+				},
 			},
 			{
 				Code: "VT",
@@ -170,6 +189,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Not subject: sales made on behalf of third parties (amount not computable for VAT or IRPF purposes)",
 					i18n.ES: "No sujeto: ventas realizadas por cuenta de terceros (importe no computable a efectos de IVA ni de IRPF)",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-79-C",
+				},
 			},
 			{
 				Code: "IE",
@@ -195,6 +217,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Subject and not exempt: with reverse charge",
 					i18n.ES: "Sujeto y no exenta: con inversión del sujeto pasivo",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 		},
 	},
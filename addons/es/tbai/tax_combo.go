@@ -0,0 +1,10 @@
+package tbai
+
+import (
+	"github.com/invopop/gobl/catalogues/cef"
+	"github.com/invopop/gobl/tax"
+)
+
+func normalizeTaxCombo(tc *tax.Combo) {
+	tc.Ext = cef.NormalizeVATEX(tc.Ext, ExtKeyExemption)
+}
@@ -0,0 +1,51 @@
+package tbai_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/es/tbai"
+	"github.com/invopop/gobl/catalogues/cef"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxComboNormalization(t *testing.T) {
+	ad := tax.AddonForKey(tbai.V1)
+
+	t.Run("exemption with VATEX", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				tbai.ExtKeyExemption: "E5",
+			},
+		}
+		ad.Normalizer(c)
+		assert.Equal(t, "VATEX-EU-IC", c.Ext[cef.ExtKeyVATEX].String())
+	})
+
+	t.Run("VATEX with exemption", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-IC",
+			},
+		}
+		ad.Normalizer(c)
+		assert.Equal(t, "E5", c.Ext[tbai.ExtKeyExemption].String())
+	})
+
+	t.Run("ambiguous VATEX", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-O", // OT or RL
+			},
+		}
+		ad.Normalizer(c)
+		assert.False(t, c.Ext.Has(tbai.ExtKeyExemption))
+		assert.Equal(t, "VATEX-EU-O", c.Ext[cef.ExtKeyVATEX].String())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/invopop/gobl/addons/eu/en16931"
 	"github.com/invopop/gobl/bill"
 	"github.com/invopop/gobl/catalogues/untdid"
+	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/org"
 	"github.com/invopop/gobl/tax"
@@ -34,7 +35,7 @@ func TestInvoiceValidation(t *testing.T) {
 	t.Run("missing tax document type", func(t *testing.T) {
 		inv := testInvoiceStandard(t)
 		inv.Type = bill.InvoiceTypeOther
-		inv.Tax = &bill.Tax{PricesInclude: "VAT"}
+		inv.Tax = &bill.Tax{PricesInclude: []cbc.Code{"VAT"}}
 		require.NoError(t, inv.Calculate())
 		err := ad.Validator(inv)
 		assert.ErrorContains(t, err, "tax: (ext: (untdid-document-type: required.).)")
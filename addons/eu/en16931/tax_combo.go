@@ -1,6 +1,7 @@
 package en16931
 
 import (
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/catalogues/untdid"
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/regimes/es"
@@ -24,6 +25,11 @@ var acceptedTaxCategories = []cbc.Code{
 }
 
 func normalizeTaxCombo(tc *tax.Combo) {
+	normalizeTaxComboCategory(tc)
+	tc.Ext = cef.NormalizeVATEX(tc.Ext, untdid.ExtKeyTaxCategory)
+}
+
+func normalizeTaxComboCategory(tc *tax.Combo) {
 	switch tc.Category {
 	case tax.CategoryVAT:
 		if tc.Rate.IsEmpty() {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/invopop/gobl/addons/eu/en16931"
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/catalogues/untdid"
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/num"
@@ -62,6 +63,38 @@ func TestTaxComboNormalization(t *testing.T) {
 		ad.Normalizer(c)
 		assert.Empty(t, c.Ext)
 	})
+
+	t.Run("category with VATEX", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt.With(tax.TagExport).With(tax.TagEEA),
+		}
+		ad.Normalizer(c)
+		assert.Equal(t, "K", c.Ext[untdid.ExtKeyTaxCategory].String())
+		assert.Equal(t, "VATEX-EU-IC", c.Ext[cef.ExtKeyVATEX].String())
+	})
+
+	t.Run("VATEX with category", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-IC",
+			},
+		}
+		ad.Normalizer(c)
+		assert.Equal(t, "K", c.Ext[untdid.ExtKeyTaxCategory].String())
+	})
+
+	t.Run("VATEX without category", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-132", // any exemption, not mapped
+			},
+		}
+		ad.Normalizer(c)
+		assert.False(t, c.Ext.Has(untdid.ExtKeyTaxCategory))
+	})
 }
 
 func TestTaxComboValidation(t *testing.T) {
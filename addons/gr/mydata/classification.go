@@ -0,0 +1,299 @@
+package mydata
+
+import (
+	"fmt"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Item keys used to determine the income classification category of
+// each line.
+const (
+	ItemKeyGoods       cbc.Key = "goods"
+	ItemKeyProducts    cbc.Key = "products"
+	ItemKeyServices    cbc.Key = "services"
+	ItemKeyFixedAssets cbc.Key = "fixed-assets"
+)
+
+// markets used to determine the income classification type
+type market int
+
+const (
+	marketWholesale market = iota
+	marketRetail
+	marketEU
+	marketThirdCountry
+)
+
+// exemptionArt39a is the VAT exemption code for article 39a of the VAT code,
+// which has its own income classification types.
+const exemptionArt39a cbc.Code = "16"
+
+// euTaxCountries contains the tax country codes of the EU member states other
+// than Greece.
+var euTaxCountries = []l10n.TaxCountryCode{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "HR", "HU",
+	"IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK",
+}
+
+// incomeCatItemKeys maps item keys to income classification categories.
+var incomeCatItemKeys = map[cbc.Key]cbc.Code{
+	ItemKeyGoods:       "category1_1",
+	ItemKeyProducts:    "category1_2",
+	ItemKeyServices:    "category1_3",
+	ItemKeyFixedAssets: "category1_4",
+}
+
+// incomeCatInvoiceTypes maps invoice types to the income classification
+// category used when the item does not have a key.
+var incomeCatInvoiceTypes = map[cbc.Code]cbc.Code{
+	"1.1":  "category1_1",
+	"1.2":  "category1_1",
+	"1.3":  "category1_1",
+	"1.4":  "category1_7",
+	"2.1":  "category1_3",
+	"2.2":  "category1_3",
+	"2.3":  "category1_3",
+	"11.1": "category1_1",
+	"11.2": "category1_3",
+	"11.5": "category1_7",
+}
+
+// incomeTypes defines the income classification type to use for each category
+// and market. Categories not listed here cannot be classified automatically.
+var incomeTypes = map[cbc.Code]map[market]cbc.Code{
+	"category1_1": salesIncomeTypes,
+	"category1_2": salesIncomeTypes,
+	"category1_3": salesIncomeTypes,
+	"category1_4": {
+		marketWholesale:    "E3_880_001",
+		marketRetail:       "E3_880_002",
+		marketEU:           "E3_880_003",
+		marketThirdCountry: "E3_880_004",
+	},
+	"category1_7": {
+		marketWholesale:    "E3_881_001",
+		marketRetail:       "E3_881_002",
+		marketEU:           "E3_881_003",
+		marketThirdCountry: "E3_881_004",
+	},
+}
+
+// salesIncomeCats are the categories classified with the sales income types.
+var salesIncomeCats = []cbc.Code{"category1_1", "category1_2", "category1_3"}
+
+var salesIncomeTypes = map[market]cbc.Code{
+	marketWholesale:    "E3_561_001",
+	marketRetail:       "E3_561_003",
+	marketEU:           "E3_561_005",
+	marketThirdCountry: "E3_561_006",
+}
+
+// incomeTypesArt39a replaces the sales types for operations exempt under
+// article 39a of the VAT code.
+var incomeTypesArt39a = map[market]cbc.Code{
+	marketWholesale: "E3_561_002",
+	marketRetail:    "E3_561_004",
+}
+
+// invoiceTypeMarkets defines the markets implied by the invoice type.
+var invoiceTypeMarkets = map[cbc.Code]market{
+	"1.2":  marketEU,
+	"2.2":  marketEU,
+	"1.3":  marketThirdCountry,
+	"2.3":  marketThirdCountry,
+	"11.1": marketRetail,
+	"11.2": marketRetail,
+	"11.3": marketRetail,
+	"11.4": marketRetail,
+	"11.5": marketRetail,
+}
+
+// normalizeInvoice classifies the income of each line by setting the income
+// category and type extensions in the VAT combo. Codes already present in the
+// item or combo's extensions will be respected.
+func normalizeInvoice(inv *bill.Invoice) {
+	it := invoiceType(inv)
+	mk := invoiceMarket(inv, it)
+	for _, l := range inv.Lines {
+		if l == nil || l.Item == nil {
+			continue
+		}
+		if l.Item.Ext.Has(ExtKeyIncomeCat) || l.Item.Ext.Has(ExtKeyIncomeType) {
+			continue
+		}
+		tc := l.Taxes.Get(tax.CategoryVAT)
+		if tc == nil {
+			continue
+		}
+		cat := tc.Ext.Get(ExtKeyIncomeCat)
+		if cat == cbc.CodeEmpty {
+			cat = incomeCatItemKeys[l.Item.Key]
+		}
+		if cat == cbc.CodeEmpty {
+			cat = incomeCatInvoiceTypes[it]
+		}
+		if cat == cbc.CodeEmpty {
+			continue
+		}
+		typ := tc.Ext.Get(ExtKeyIncomeType)
+		if typ == cbc.CodeEmpty {
+			typ = incomeType(cat, mk, tc)
+		}
+		if typ == cbc.CodeEmpty {
+			continue
+		}
+		tc.Ext = tc.Ext.Merge(tax.Extensions{
+			ExtKeyIncomeCat:  cat,
+			ExtKeyIncomeType: typ,
+		})
+	}
+}
+
+func incomeType(cat cbc.Code, mk market, tc *tax.Combo) cbc.Code {
+	types, ok := incomeTypes[cat]
+	if !ok {
+		return cbc.CodeEmpty
+	}
+	if tc.Ext.Get(ExtKeyExemption) == exemptionArt39a && cat.In(salesIncomeCats...) {
+		if typ, ok := incomeTypesArt39a[mk]; ok {
+			return typ
+		}
+	}
+	return types[mk]
+}
+
+// invoiceType provides the invoice type code from the invoice's tax extensions,
+// or from the scenarios if they have not been applied yet.
+func invoiceType(inv *bill.Invoice) cbc.Code {
+	if inv.Tax != nil && inv.Tax.Ext.Has(ExtKeyInvoiceType) {
+		return inv.Tax.Ext.Get(ExtKeyInvoiceType)
+	}
+	if ss := inv.ScenarioSummary(); ss != nil {
+		return ss.Ext.Get(ExtKeyInvoiceType)
+	}
+	return cbc.CodeEmpty
+}
+
+// invoiceMarket determines the market of the sale from the invoice type or,
+// when not implied, from the customer's tax ID.
+func invoiceMarket(inv *bill.Invoice, it cbc.Code) market {
+	if mk, ok := invoiceTypeMarkets[it]; ok {
+		return mk
+	}
+	c := inv.Customer
+	if c == nil || c.TaxID == nil || c.TaxID.Code == cbc.CodeEmpty {
+		return marketRetail
+	}
+	switch {
+	case c.TaxID.Country == l10n.EL.Tax():
+		return marketWholesale
+	case c.TaxID.Country.In(euTaxCountries...):
+		return marketEU
+	}
+	return marketThirdCountry
+}
+
+// validateInvoiceLineClassification ensures that the income classifications
+// provided in the line's item or tax combos are possible for the invoice.
+func validateInvoiceLineClassification(inv *bill.Invoice) validation.RuleFunc {
+	var it cbc.Code
+	if inv.Tax != nil {
+		it = inv.Tax.Ext.Get(ExtKeyInvoiceType)
+	}
+	return func(value any) error {
+		l, ok := value.(*bill.Line)
+		if !ok || l == nil {
+			return nil
+		}
+		return validation.ValidateStruct(l,
+			validation.Field(&l.Item,
+				validation.By(func(value any) error {
+					if l.Item == nil {
+						return nil
+					}
+					return validation.ValidateStruct(l.Item,
+						validation.Field(&l.Item.Ext,
+							validation.By(validateIncomeClassification(it)),
+							validation.Skip,
+						),
+					)
+				}),
+				validation.Skip,
+			),
+			validation.Field(&l.Taxes,
+				validation.Each(
+					validation.By(func(value any) error {
+						tc, ok := value.(*tax.Combo)
+						if !ok || tc == nil {
+							return nil
+						}
+						return validation.ValidateStruct(tc,
+							validation.Field(&tc.Ext,
+								validation.By(validateIncomeClassification(it)),
+								validation.Skip,
+							),
+						)
+					}),
+					validation.Skip,
+				),
+				validation.Skip,
+			),
+		)
+	}
+}
+
+// validateIncomeClassification rejects income category and type combinations
+// known to be impossible: types reserved to another category, and types whose
+// market contradicts the one implied by the invoice type. Types not defined
+// in the classification tables are left for AADE to check.
+func validateIncomeClassification(it cbc.Code) validation.RuleFunc {
+	return func(value any) error {
+		ext, _ := value.(tax.Extensions)
+		cat := ext.Get(ExtKeyIncomeCat)
+		typ := ext.Get(ExtKeyIncomeType)
+		if cat == cbc.CodeEmpty || typ == cbc.CodeEmpty {
+			return nil
+		}
+		for c, types := range incomeTypes {
+			if c.In(salesIncomeCats...) || c == cat {
+				continue
+			}
+			if _, found := marketForType(types, typ); found {
+				return fmt.Errorf("income type '%s' not valid for category '%s'", typ, cat)
+			}
+		}
+		mk, found := marketForIncomeType(typ)
+		if !found {
+			return nil
+		}
+		if im, ok := invoiceTypeMarkets[it]; ok && im != mk {
+			return fmt.Errorf("income type '%s' not valid for invoice type '%s'", typ, it)
+		}
+		return nil
+	}
+}
+
+// marketForIncomeType looks up the market of any income type defined in the
+// classification tables.
+func marketForIncomeType(typ cbc.Code) (market, bool) {
+	for _, types := range incomeTypes {
+		if mk, found := marketForType(types, typ); found {
+			return mk, true
+		}
+	}
+	return marketForType(incomeTypesArt39a, typ)
+}
+
+func marketForType(types map[market]cbc.Code, typ cbc.Code) (market, bool) {
+	for m, t := range types {
+		if t == typ {
+			return m, true
+		}
+	}
+	return 0, false
+}
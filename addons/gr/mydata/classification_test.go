@@ -0,0 +1,130 @@
+package mydata_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/gr/mydata"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncomeClassification(t *testing.T) {
+	assertClassification := func(t *testing.T, ext tax.Extensions, cat, typ cbc.Code) {
+		t.Helper()
+		assert.Equal(t, cat, ext[mydata.ExtKeyIncomeCat])
+		assert.Equal(t, typ, ext[mydata.ExtKeyIncomeType])
+	}
+
+	t.Run("domestic services", func(t *testing.T) {
+		inv := validInvoice()
+		require.NoError(t, inv.Calculate())
+		require.NoError(t, inv.Validate())
+		assertClassification(t, inv.Lines[0].Taxes[0].Ext, "category1_3", "E3_561_001")
+	})
+
+	t.Run("item key", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Lines[0].Item.Key = mydata.ItemKeyFixedAssets
+		require.NoError(t, inv.Calculate())
+		require.NoError(t, inv.Validate())
+		assertClassification(t, inv.Lines[0].Taxes[0].Ext, "category1_4", "E3_880_001")
+	})
+
+	t.Run("goods to EU customer", func(t *testing.T) {
+		inv := validInvoice()
+		inv.SetTags(mydata.TagGoods)
+		inv.Customer.TaxID.Country = "DE"
+		inv.Customer.TaxID.Code = "111111125"
+		require.NoError(t, inv.Calculate())
+		require.NoError(t, inv.Validate())
+		assertClassification(t, inv.Lines[0].Taxes[0].Ext, "category1_1", "E3_561_005")
+	})
+
+	t.Run("export of services", func(t *testing.T) {
+		inv := validInvoice()
+		inv.SetTags(mydata.TagServices, mydata.TagExport)
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, cbc.Code("2.3"), inv.Tax.Ext[mydata.ExtKeyInvoiceType])
+		assertClassification(t, inv.Lines[0].Taxes[0].Ext, "category1_3", "E3_561_006")
+	})
+
+	t.Run("retail receipt", func(t *testing.T) {
+		inv := validInvoice()
+		inv.SetTags(mydata.TagGoods, tax.TagSimplified)
+		require.NoError(t, inv.Calculate())
+		assertClassification(t, inv.Lines[0].Taxes[0].Ext, "category1_1", "E3_561_003")
+	})
+
+	t.Run("article 39a exemption", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Lines[0].Taxes[0].Rate = tax.RateExempt
+		inv.Lines[0].Taxes[0].Ext = tax.Extensions{
+			mydata.ExtKeyExemption: "16",
+		}
+		require.NoError(t, inv.Calculate())
+		require.NoError(t, inv.Validate())
+		assertClassification(t, inv.Lines[0].Taxes[0].Ext, "category1_3", "E3_561_002")
+	})
+
+	t.Run("category override", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Lines[0].Taxes[0].Ext = tax.Extensions{
+			mydata.ExtKeyIncomeCat: "category1_7",
+		}
+		require.NoError(t, inv.Calculate())
+		require.NoError(t, inv.Validate())
+		assertClassification(t, inv.Lines[0].Taxes[0].Ext, "category1_7", "E3_881_001")
+	})
+
+	t.Run("item override", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Lines[0].Item.Ext = tax.Extensions{
+			mydata.ExtKeyIncomeCat:  "category1_5",
+			mydata.ExtKeyIncomeType: "E3_562",
+		}
+		require.NoError(t, inv.Calculate())
+		require.NoError(t, inv.Validate())
+		assert.False(t, inv.Lines[0].Taxes[0].Ext.Has(mydata.ExtKeyIncomeCat))
+	})
+
+	t.Run("unclassified credit note", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Type = "credit-note"
+		require.NoError(t, inv.Calculate())
+		assert.False(t, inv.Lines[0].Taxes[0].Ext.Has(mydata.ExtKeyIncomeCat))
+	})
+}
+
+func TestIncomeClassificationValidation(t *testing.T) {
+	t.Run("type reserved to another category", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Lines[0].Taxes[0].Ext = tax.Extensions{
+			mydata.ExtKeyIncomeCat:  "category1_1",
+			mydata.ExtKeyIncomeType: "E3_880_001",
+		}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "lines: (0: (taxes: (0: (ext: income type 'E3_880_001' not valid for category 'category1_1'.).).).)")
+	})
+
+	t.Run("type not in classification tables", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Lines[0].Taxes[0].Ext = tax.Extensions{
+			mydata.ExtKeyIncomeCat:  "category1_1",
+			mydata.ExtKeyIncomeType: "E3_561_007",
+		}
+		require.NoError(t, inv.Calculate())
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("type contradicts invoice type", func(t *testing.T) {
+		inv := validInvoice()
+		inv.SetTags(mydata.TagServices, mydata.TagExport)
+		inv.Lines[0].Taxes[0].Ext = tax.Extensions{
+			mydata.ExtKeyIncomeType: "E3_561_001",
+		}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "lines: (0: (taxes: (0: (ext: income type 'E3_561_001' not valid for invoice type '2.3'.).).).)")
+	})
+}
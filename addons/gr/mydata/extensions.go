@@ -1,6 +1,7 @@
 package mydata
 
 import (
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/i18n"
 )
@@ -477,6 +478,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Without VAT - article 3 of the VAT code",
 					i18n.EL: "Χωρίς ΦΠΑ - άρθρο 3 του Κώδικα ΦΠΑ",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-O",
+				},
 			},
 			{
 				Code: "2",
@@ -519,6 +523,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Without VAT - article 22 of the VAT code",
 					i18n.EL: "Χωρίς ΦΠΑ - άρθρο 22 του Κώδικα ΦΠΑ",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-132",
+				},
 			},
 			{
 				Code: "8",
@@ -526,6 +533,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Without VAT - article 24 of the VAT code",
 					i18n.EL: "Χωρίς ΦΠΑ - άρθρο 24 του Κώδικα ΦΠΑ",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-G",
+				},
 			},
 			{
 				Code: "9",
@@ -554,6 +564,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Without VAT - article 27 - Seagoing Vessels of the VAT code",
 					i18n.EL: "Χωρίς ΦΠΑ - άρθρο 27 - Πλοία Ανοικτής Θαλάσσης του Κώδικα ΦΠΑ",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-148",
+				},
 			},
 			{
 				Code: "13",
@@ -568,6 +581,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Without VAT - article 28 of the VAT code",
 					i18n.EL: "Χωρίς ΦΠΑ - άρθρο 28 του Κώδικα ΦΠΑ",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-IC",
+				},
 			},
 			{
 				Code: "15",
@@ -582,6 +598,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Without VAT - article 39a of the VAT code",
 					i18n.EL: "Χωρίς ΦΠΑ - άρθρο 39α του Κώδικα ΦΠΑ",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "17",
@@ -610,6 +629,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT included - article 43 of the VAT code",
 					i18n.EL: "ΦΠΑ εμπεριεχόμενος - άρθρο 43 του Κώδικα ΦΠΑ",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-D",
+				},
 			},
 			{
 				Code: "21",
@@ -36,6 +36,7 @@ func validateInvoice(inv *bill.Invoice) error {
 		validation.Field(&inv.Lines,
 			validation.Each(
 				validation.By(validateInvoiceLine),
+				validation.By(validateInvoiceLineClassification(inv)),
 				validation.Skip,
 			),
 			validation.Skip,
@@ -37,6 +37,8 @@ func newAddon() *tax.AddonDef {
 
 func normalize(doc any) {
 	switch obj := doc.(type) {
+	case *bill.Invoice:
+		normalizeInvoice(obj)
 	case *pay.Instructions:
 		normalizePayInstructions(obj)
 	case *pay.Advance:
@@ -1,6 +1,7 @@
 package mydata
 
 import (
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/regimes/gr"
 	"github.com/invopop/gobl/tax"
@@ -18,6 +19,8 @@ var taxComboMapVAT = map[cbc.Key]cbc.Code{
 }
 
 func normalizeTaxCombo(tc *tax.Combo) {
+	tc.Ext = cef.NormalizeVATEX(tc.Ext, ExtKeyExemption)
+
 	// copy the SAF-T tax rate code to the line
 	switch tc.Category {
 	case tax.CategoryVAT:
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/invopop/gobl/addons/gr/mydata"
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/tax"
 	"github.com/stretchr/testify/assert"
@@ -47,3 +48,44 @@ func TestValidateTaxCombo(t *testing.T) {
 		assert.NoError(t, ad.Validator(tc))
 	})
 }
+
+func TestTaxComboVATEX(t *testing.T) {
+	ad := tax.AddonForKey(mydata.V1)
+
+	t.Run("exemption with VATEX", func(t *testing.T) {
+		tc := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				mydata.ExtKeyExemption: "14",
+			},
+		}
+		ad.Normalizer(tc)
+		assert.Equal(t, "VATEX-EU-IC", tc.Ext[cef.ExtKeyVATEX].String())
+	})
+
+	t.Run("VATEX with exemption", func(t *testing.T) {
+		tc := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-IC",
+			},
+		}
+		ad.Normalizer(tc)
+		assert.Equal(t, "14", tc.Ext[mydata.ExtKeyExemption].String())
+	})
+
+	t.Run("unmapped VATEX", func(t *testing.T) {
+		tc := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-79-C",
+			},
+		}
+		ad.Normalizer(tc)
+		assert.False(t, tc.Ext.Has(mydata.ExtKeyExemption))
+		assert.Equal(t, "VATEX-EU-79-C", tc.Ext[cef.ExtKeyVATEX].String())
+	})
+}
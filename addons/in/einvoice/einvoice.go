@@ -0,0 +1,62 @@
+// Package einvoice provides the addon for the Indian GST e-invoicing system,
+// where B2B invoices must be registered with an Invoice Registration Portal
+// (IRP) in order to obtain an Invoice Reference Number (IRN).
+package einvoice
+
+import (
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pkg/here"
+	"github.com/invopop/gobl/tax"
+)
+
+const (
+	// V1 for the Indian e-Invoice schema v1.x
+	V1 cbc.Key = "in-einvoice-v1"
+)
+
+// Official stamps or codes provided by the IRP
+const (
+	// StampIRN contains the 64 character Invoice Reference Number.
+	StampIRN cbc.Key = "irn"
+	// StampAckNo contains the acknowledgement number issued by the IRP.
+	StampAckNo cbc.Key = "irn-ack-no"
+	// StampAckDate contains the date and time of the acknowledgement.
+	StampAckDate cbc.Key = "irn-ack-date"
+	// StampSignedQR contains the signed QR code data (JWT) issued by the IRP.
+	StampSignedQR cbc.Key = "irn-signed-qr"
+)
+
+func init() {
+	tax.RegisterAddonDef(newAddon())
+}
+
+func newAddon() *tax.AddonDef {
+	return &tax.AddonDef{
+		Key: V1,
+		Name: i18n.String{
+			i18n.EN: "India e-Invoice v1.x",
+		},
+		Description: i18n.String{
+			i18n.EN: here.Doc(`
+				Support for the Indian GST e-invoicing system, where B2B invoices above the
+				turnover threshold must be reported to an Invoice Registration Portal (IRP).
+
+				The IRP will respond with an Invoice Reference Number (IRN), an acknowledgement
+				number and date, and a signed QR code, which should be added to the envelope
+				header as stamps. The IRN may also be calculated locally from the supplier's
+				GSTIN, financial year, document type, and document number.
+			`),
+		},
+		Validator: validate,
+	}
+}
+
+func validate(doc any) error {
+	switch obj := doc.(type) {
+	case *bill.Invoice:
+		return validateInvoice(obj)
+	}
+	return nil
+}
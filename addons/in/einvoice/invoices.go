@@ -0,0 +1,122 @@
+package einvoice
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+func validateInvoice(inv *bill.Invoice) error {
+	return validation.ValidateStruct(inv,
+		validation.Field(&inv.Type,
+			validation.In(
+				bill.InvoiceTypeStandard,
+				bill.InvoiceTypeCreditNote,
+				bill.InvoiceTypeDebitNote,
+			),
+			validation.Skip,
+		),
+		validation.Field(&inv.Code,
+			validation.Required,
+			validation.By(validateDocumentNumber(inv)),
+			validation.Skip,
+		),
+		validation.Field(&inv.Supplier,
+			validation.By(validateSupplier),
+			validation.Skip,
+		),
+		validation.Field(&inv.Customer,
+			validation.Required,
+			validation.By(validateCustomer),
+			validation.Skip,
+		),
+		validation.Field(&inv.Preceding,
+			validation.When(
+				inv.Type.In(bill.InvoiceTypeCreditNote, bill.InvoiceTypeDebitNote),
+				validation.Required,
+			),
+			validation.Each(validation.By(validatePreceding)),
+			validation.Skip,
+		),
+	)
+}
+
+func validateDocumentNumber(inv *bill.Invoice) validation.RuleFunc {
+	return func(_ any) error {
+		num := DocumentNumber(inv)
+		if len(num) > DocumentNumberMaxLength {
+			return fmt.Errorf("document number '%s' exceeds %d characters", num, DocumentNumberMaxLength)
+		}
+		if !documentNumberRegexp.MatchString(num) {
+			return fmt.Errorf("document number '%s' must start with a letter or non-zero digit and only contain letters, digits, '/' or '-'", num)
+		}
+		return nil
+	}
+}
+
+func validateSupplier(value any) error {
+	p, ok := value.(*org.Party)
+	if !ok || p == nil {
+		return nil
+	}
+	return validation.ValidateStruct(p,
+		validation.Field(&p.TaxID,
+			validation.Required,
+			tax.RequireIdentityCode,
+			validation.By(validateIndianTaxID),
+			validation.Skip,
+		),
+		validation.Field(&p.Addresses,
+			validation.Required,
+			validation.Skip,
+		),
+	)
+}
+
+func validateCustomer(value any) error {
+	p, ok := value.(*org.Party)
+	if !ok || p == nil {
+		return nil
+	}
+	return validation.ValidateStruct(p,
+		validation.Field(&p.TaxID,
+			validation.Required,
+			validation.When(
+				p.TaxID != nil && p.TaxID.Country.In(l10n.IN.Tax()),
+				tax.RequireIdentityCode,
+			),
+			validation.Skip,
+		),
+		validation.Field(&p.Addresses,
+			validation.Required,
+			validation.Skip,
+		),
+	)
+}
+
+func validateIndianTaxID(value any) error {
+	tID, ok := value.(*tax.Identity)
+	if !ok || tID == nil {
+		return nil
+	}
+	if tID.Country != l10n.IN.Tax() {
+		return errors.New("must be an Indian GSTIN")
+	}
+	return nil
+}
+
+func validatePreceding(value any) error {
+	dr, ok := value.(*org.DocumentRef)
+	if !ok || dr == nil {
+		return nil
+	}
+	return validation.ValidateStruct(dr,
+		validation.Field(&dr.Code, validation.Required),
+		validation.Field(&dr.IssueDate, validation.Required),
+	)
+}
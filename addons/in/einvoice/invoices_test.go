@@ -0,0 +1,141 @@
+package einvoice_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/in/einvoice"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validInvoice() *bill.Invoice {
+	return &bill.Invoice{
+		Regime:    tax.WithRegime("IN"),
+		Addons:    tax.WithAddons(einvoice.V1),
+		Type:      bill.InvoiceTypeStandard,
+		Series:    "INV",
+		Code:      "0001",
+		IssueDate: cal.MakeDate(2024, 6, 15),
+		Currency:  "INR",
+		Supplier: &org.Party{
+			Name: "Test Supplier",
+			TaxID: &tax.Identity{
+				Country: "IN",
+				Code:    "27AAPFU0939F1ZV",
+			},
+			Addresses: []*org.Address{
+				{
+					Locality: "Mumbai",
+					Code:     "400018",
+					State:    "27",
+					Country:  "IN",
+				},
+			},
+		},
+		Customer: &org.Party{
+			Name: "Test Customer",
+			TaxID: &tax.Identity{
+				Country: "IN",
+				Code:    "29AAGCB7383J1Z4",
+			},
+			Addresses: []*org.Address{
+				{
+					Locality: "Bengaluru",
+					Code:     "560001",
+					State:    "29",
+					Country:  "IN",
+				},
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Development services",
+					Price: num.MakeAmount(10000, 2),
+					Identities: []*org.Identity{
+						{
+							Type: "HSN",
+							Code: "998314",
+						},
+					},
+				},
+				Taxes: tax.Set{
+					{
+						Category: tax.CategoryGST,
+						Percent:  num.NewPercentage(18, 2),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInvoiceValidation(t *testing.T) {
+	inv := validInvoice()
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+}
+
+func TestInvoiceDocumentNumberValidation(t *testing.T) {
+	inv := validInvoice()
+	inv.Series = "INV-2024-MUMBAI"
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "code: document number 'INV-2024-MUMBAI/0001' exceeds 16 characters")
+
+	inv = validInvoice()
+	inv.Series = ""
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "code: document number '0001' must start with a letter or non-zero digit")
+
+	inv = validInvoice()
+	inv.Code = "1.2"
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "only contain letters, digits, '/' or '-'")
+}
+
+func TestInvoicePartyValidation(t *testing.T) {
+	inv := validInvoice()
+	inv.Customer = nil
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "customer: cannot be blank")
+
+	inv = validInvoice()
+	inv.Supplier.Addresses = nil
+	inv.Customer.TaxID = nil
+	require.NoError(t, inv.Calculate())
+	err := inv.Validate()
+	assert.ErrorContains(t, err, "supplier: (addresses: cannot be blank.)")
+	assert.ErrorContains(t, err, "customer: (tax_id: cannot be blank.)")
+
+	inv = validInvoice()
+	inv.Customer.TaxID = &tax.Identity{Country: "US"}
+	inv.Customer.Addresses[0].Country = "US"
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+}
+
+func TestInvoiceTypeValidation(t *testing.T) {
+	inv := validInvoice()
+	inv.Type = bill.InvoiceTypeProforma
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "type: must be a valid value")
+
+	inv = validInvoice()
+	inv.Type = bill.InvoiceTypeCreditNote
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "preceding: cannot be blank")
+
+	inv.Preceding = []*org.DocumentRef{{Code: "INV/0000"}}
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "preceding: (0: (issue_date: cannot be blank.).)")
+
+	inv.Preceding[0].IssueDate = cal.NewDate(2024, 6, 1)
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+}
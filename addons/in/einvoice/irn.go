@@ -0,0 +1,84 @@
+package einvoice
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+)
+
+// Document types used by the IRP.
+const (
+	DocTypeInvoice    cbc.Code = "INV"
+	DocTypeCreditNote cbc.Code = "CRN"
+	DocTypeDebitNote  cbc.Code = "DBN"
+)
+
+// DocumentNumberMaxLength is the maximum length of a document number
+// accepted by the IRP.
+const DocumentNumberMaxLength = 16
+
+// documentNumberRegexp must start with a letter or a digit other than zero,
+// followed by letters, digits, slashes, or hyphens.
+var documentNumberRegexp = regexp.MustCompile(`^[A-Za-z1-9][A-Za-z0-9/-]*$`)
+
+var docTypes = map[cbc.Key]cbc.Code{
+	bill.InvoiceTypeStandard:   DocTypeInvoice,
+	bill.InvoiceTypeCreditNote: DocTypeCreditNote,
+	bill.InvoiceTypeDebitNote:  DocTypeDebitNote,
+}
+
+// DocumentType provides the IRP document type code for the invoice, or
+// an empty code if the invoice type is not supported.
+func DocumentType(inv *bill.Invoice) cbc.Code {
+	return docTypes[inv.Type]
+}
+
+// DocumentNumber provides the document number reported to the IRP, which
+// combines the series and code separated by a slash.
+func DocumentNumber(inv *bill.Invoice) string {
+	if inv.Series == cbc.CodeEmpty {
+		return inv.Code.String()
+	}
+	return inv.Series.String() + "/" + inv.Code.String()
+}
+
+// FinancialYear provides the Indian financial year, which runs from April to
+// March, for the given date in the "YYYY-YY" format. For example, both
+// 2024-04-01 and 2025-03-31 belong to "2024-25".
+func FinancialYear(date cal.Date) string {
+	y := date.Year
+	if date.Month < 4 {
+		y--
+	}
+	return fmt.Sprintf("%d-%02d", y, (y+1)%100)
+}
+
+// IRN calculates the Invoice Reference Number for the invoice, which is the
+// hex encoded SHA-256 hash of the supplier's GSTIN, financial year, document
+// type, and document number. The IRN issued by the IRP should always be
+// preferred, but this may be used to check or prepare documents in advance.
+func IRN(inv *bill.Invoice) (string, error) {
+	if inv.Supplier == nil || inv.Supplier.TaxID == nil || inv.Supplier.TaxID.Code == cbc.CodeEmpty {
+		return "", errors.New("supplier GSTIN required")
+	}
+	if inv.IssueDate.IsZero() {
+		return "", errors.New("issue date required")
+	}
+	dt := DocumentType(inv)
+	if dt == cbc.CodeEmpty {
+		return "", fmt.Errorf("unsupported invoice type: %s", inv.Type)
+	}
+	num := DocumentNumber(inv)
+	if num == "" {
+		return "", errors.New("document number required")
+	}
+	data := inv.Supplier.TaxID.Code.String() + FinancialYear(inv.IssueDate) + dt.String() + num
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,56 @@
+package einvoice_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/in/einvoice"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinancialYear(t *testing.T) {
+	assert.Equal(t, "2024-25", einvoice.FinancialYear(cal.MakeDate(2024, 4, 1)))
+	assert.Equal(t, "2024-25", einvoice.FinancialYear(cal.MakeDate(2025, 3, 31)))
+	assert.Equal(t, "2099-00", einvoice.FinancialYear(cal.MakeDate(2099, 12, 1)))
+}
+
+func TestDocumentType(t *testing.T) {
+	inv := validInvoice()
+	assert.Equal(t, einvoice.DocTypeInvoice, einvoice.DocumentType(inv))
+	inv.Type = bill.InvoiceTypeCreditNote
+	assert.Equal(t, einvoice.DocTypeCreditNote, einvoice.DocumentType(inv))
+	inv.Type = bill.InvoiceTypeProforma
+	assert.Equal(t, cbc.CodeEmpty, einvoice.DocumentType(inv))
+}
+
+func TestDocumentNumber(t *testing.T) {
+	inv := validInvoice()
+	assert.Equal(t, "INV/0001", einvoice.DocumentNumber(inv))
+	inv.Series = ""
+	assert.Equal(t, "0001", einvoice.DocumentNumber(inv))
+}
+
+func TestIRN(t *testing.T) {
+	inv := validInvoice()
+	irn, err := einvoice.IRN(inv)
+	require.NoError(t, err)
+	assert.Equal(t, "7a74184c497061d8cd96480fd385ea23cb953b49563106f14c92a58a15c49e11", irn)
+	assert.Len(t, irn, 64)
+
+	inv.Type = bill.InvoiceTypeProforma
+	_, err = einvoice.IRN(inv)
+	assert.ErrorContains(t, err, "unsupported invoice type: proforma")
+
+	inv = validInvoice()
+	inv.Supplier.TaxID = nil
+	_, err = einvoice.IRN(inv)
+	assert.ErrorContains(t, err, "supplier GSTIN required")
+
+	inv = validInvoice()
+	inv.IssueDate = cal.Date{}
+	_, err = einvoice.IRN(inv)
+	assert.ErrorContains(t, err, "issue date required")
+}
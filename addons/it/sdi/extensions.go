@@ -1,6 +1,7 @@
 package sdi
 
 import (
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/i18n"
 	"github.com/invopop/gobl/pkg/here"
@@ -16,6 +17,7 @@ const (
 	ExtKeyRetained     cbc.Key = "it-sdi-retained"
 	ExtKeyPaymentMeans cbc.Key = "it-sdi-payment-means"
 	ExtKeyVATLiability cbc.Key = "it-sdi-vat-liability"
+	ExtKeyFundType     cbc.Key = "it-sdi-fund-type"
 )
 
 var extensions = []*cbc.Definition{
@@ -316,6 +318,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Excluded pursuant to Art. 15, DPR 633/72",
 					i18n.IT: "Escluse ex. art. 15 del D.P.R. 633/1972",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-79-C",
+				},
 			},
 			{
 				Code: "N2.1",
@@ -323,6 +328,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Not subject pursuant to Art. 7, DPR 633/72",
 					i18n.IT: "Non soggette ex. art. 7 del D.P.R. 633/72",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-O",
+				},
 			},
 			{
 				Code: "N2.2",
@@ -330,6 +338,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Not subject - other",
 					i18n.IT: "Non soggette - altri casi",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-O",
+				},
 			},
 			{
 				Code: "N3.1",
@@ -337,6 +348,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Not taxable - exports",
 					i18n.IT: "Non imponibili - esportazioni",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-G",
+				},
 			},
 			{
 				Code: "N3.2",
@@ -344,6 +358,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Not taxable - intra-community supplies",
 					i18n.IT: "Non imponibili - cessioni intracomunitarie",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-IC",
+				},
 			},
 			{
 				Code: "N3.3",
@@ -379,6 +396,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Exempt",
 					i18n.IT: "Esenti",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-132",
+				},
 			},
 			{
 				Code: "N5",
@@ -393,6 +413,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - Transfer of scrap and of other recyclable materials",
 					i18n.IT: "Inversione contabile - cessione di rottami e altri materiali di recupero",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N6.2",
@@ -400,6 +423,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - Transfer of gold and pure silver pursuant to law 7/2000 as well as used jewelery to OPO",
 					i18n.IT: "Inversione contabile - cessione di oro e argento ai sensi della legge 7/2000 nonché di oreficeria usata ad OPO",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N6.3",
@@ -407,6 +433,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - Construction subcontracting",
 					i18n.IT: "Inversione contabile - subappalto nel settore edile",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N6.4",
@@ -414,6 +443,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - Transfer of buildings",
 					i18n.IT: "Inversione contabile - cessione di fabbricati",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N6.5",
@@ -421,6 +453,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - Transfer of mobile phones",
 					i18n.IT: "Inversione contabile - cessione di telefoni cellulari",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N6.6",
@@ -428,6 +463,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - Transfer of electronic products",
 					i18n.IT: "Inversione contabile - cessione di prodotti elettronici",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N6.7",
@@ -435,6 +473,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - provisions in the construction and related sectors",
 					i18n.IT: "Inversione contabile - prestazioni comparto edile e settori connessi",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N6.8",
@@ -442,6 +483,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - transactions in the energy sector",
 					i18n.IT: "Inversione contabile - operazioni settore energetico",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N6.9",
@@ -449,6 +493,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Reverse charge - other cases",
 					i18n.IT: "Inversione contabile - altri casi",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "N7",
@@ -895,4 +942,169 @@ var extensions = []*cbc.Definition{
 			},
 		},
 	},
+	{
+		// Related to the "TipoCassa" field used for social security fund
+		// contributions (cassa previdenziale) applied as charges.
+		Key: ExtKeyFundType,
+		Name: i18n.String{
+			i18n.EN: "Fund Type",
+			i18n.IT: "Tipo Cassa",
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: "TC01",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare fund for lawyers and solicitors",
+					i18n.IT: "Cassa Nazionale Previdenza e Assistenza Avvocati e Procuratori Legali",
+				},
+			},
+			{
+				Code: "TC02",
+				Name: i18n.String{
+					i18n.EN: "Pension fund for accountants",
+					i18n.IT: "Cassa Previdenza Dottori Commercialisti",
+				},
+			},
+			{
+				Code: "TC03",
+				Name: i18n.String{
+					i18n.EN: "Pension and welfare fund for surveyors",
+					i18n.IT: "Cassa Previdenza e Assistenza Geometri",
+				},
+			},
+			{
+				Code: "TC04",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare fund for self-employed engineers and architects",
+					i18n.IT: "Cassa Nazionale Previdenza e Assistenza Ingegneri e Architetti Liberi Professionisti",
+				},
+			},
+			{
+				Code: "TC05",
+				Name: i18n.String{
+					i18n.EN: "National fund for notaries",
+					i18n.IT: "Cassa Nazionale del Notariato",
+				},
+			},
+			{
+				Code: "TC06",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare fund for bookkeepers and commercial experts",
+					i18n.IT: "Cassa Nazionale Previdenza e Assistenza Ragionieri e Periti Commerciali",
+				},
+			},
+			{
+				Code: "TC07",
+				Name: i18n.String{
+					i18n.EN: "National welfare board for sales agents and representatives (ENASARCO)",
+					i18n.IT: "Ente Nazionale Assistenza Agenti e Rappresentanti di Commercio (ENASARCO)",
+				},
+			},
+			{
+				Code: "TC08",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare board for employment consultants (ENPACL)",
+					i18n.IT: "Ente Nazionale Previdenza e Assistenza Consulenti del Lavoro (ENPACL)",
+				},
+			},
+			{
+				Code: "TC09",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare board for doctors (ENPAM)",
+					i18n.IT: "Ente Nazionale Previdenza e Assistenza Medici (ENPAM)",
+				},
+			},
+			{
+				Code: "TC10",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare board for pharmacists (ENPAF)",
+					i18n.IT: "Ente Nazionale Previdenza e Assistenza Farmacisti (ENPAF)",
+				},
+			},
+			{
+				Code: "TC11",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare board for veterinary physicians (ENPAV)",
+					i18n.IT: "Ente Nazionale Previdenza e Assistenza Veterinari (ENPAV)",
+				},
+			},
+			{
+				Code: "TC12",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare board for agricultural employees (ENPAIA)",
+					i18n.IT: "Ente Nazionale Previdenza e Assistenza Impiegati dell'Agricoltura (ENPAIA)",
+				},
+			},
+			{
+				Code: "TC13",
+				Name: i18n.String{
+					i18n.EN: "Pension fund for employees of shipping companies and maritime agencies",
+					i18n.IT: "Fondo Previdenza Impiegati Imprese di Spedizione e Agenzie Marittime",
+				},
+			},
+			{
+				Code: "TC14",
+				Name: i18n.String{
+					i18n.EN: "National pension institute for Italian journalists (INPGI)",
+					i18n.IT: "Istituto Nazionale Previdenza Giornalisti Italiani (INPGI)",
+				},
+			},
+			{
+				Code: "TC15",
+				Name: i18n.String{
+					i18n.EN: "National welfare board for orphans of Italian doctors (ONAOSI)",
+					i18n.IT: "Opera Nazionale Assistenza Orfani Sanitari Italiani (ONAOSI)",
+				},
+			},
+			{
+				Code: "TC16",
+				Name: i18n.String{
+					i18n.EN: "Autonomous supplementary welfare fund for Italian journalists (CASAGIT)",
+					i18n.IT: "Cassa Autonoma Assistenza Integrativa Giornalisti Italiani (CASAGIT)",
+				},
+			},
+			{
+				Code: "TC17",
+				Name: i18n.String{
+					i18n.EN: "Pension board for industrial experts and graduate industrial experts (EPPI)",
+					i18n.IT: "Ente Previdenza Periti Industriali e Periti Industriali Laureati (EPPI)",
+				},
+			},
+			{
+				Code: "TC18",
+				Name: i18n.String{
+					i18n.EN: "National multi-category pension and welfare board (EPAP)",
+					i18n.IT: "Ente Previdenza e Assistenza Pluricategoriale (EPAP)",
+				},
+			},
+			{
+				Code: "TC19",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare board for biologists (ENPAB)",
+					i18n.IT: "Ente Nazionale Previdenza e Assistenza Biologi (ENPAB)",
+				},
+			},
+			{
+				Code: "TC20",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare board for the nursing profession (ENPAPI)",
+					i18n.IT: "Ente Nazionale Previdenza e Assistenza Professione Infermieristica (ENPAPI)",
+				},
+			},
+			{
+				Code: "TC21",
+				Name: i18n.String{
+					i18n.EN: "National pension and welfare board for psychologists (ENPAP)",
+					i18n.IT: "Ente Nazionale Previdenza e Assistenza Psicologi (ENPAP)",
+				},
+			},
+			{
+				Code: "TC22",
+				Name: i18n.String{
+					i18n.EN: "National Social Security Institute (INPS)",
+					i18n.IT: "Istituto Nazionale della Previdenza Sociale (INPS)",
+				},
+			},
+		},
+	},
 }
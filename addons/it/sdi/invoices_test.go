@@ -6,6 +6,7 @@ import (
 	"github.com/invopop/gobl/addons/it/sdi"
 	"github.com/invopop/gobl/bill"
 	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/org"
 	"github.com/invopop/gobl/pay"
@@ -23,7 +24,7 @@ func testInvoiceStandard(t *testing.T) *bill.Invoice {
 		Code:     "123TEST",
 		Currency: "EUR",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Type: bill.InvoiceTypeStandard,
 		Supplier: &org.Party{
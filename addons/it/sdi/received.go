@@ -0,0 +1,232 @@
+package sdi
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/head"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/regimes/it"
+	"github.com/invopop/gobl/tax"
+)
+
+// Received contains the SDI specific data extracted from a FatturaPA document
+// issued by a supplier and delivered to us through the SDI, that needs to be
+// mapped back into a GOBL invoice. The rest of the document is expected to be
+// converted directly, with the supplier as the issuing party.
+type Received struct {
+	// Notification sent by the SDI alongside the document (NotificaMetadati).
+	Notification *Notification
+	// RegimeFiscale of the supplier, RF01 to RF19.
+	RegimeFiscale cbc.Code
+	// Ritenute lists the withholdings defined in DatiRitenuta.
+	Ritenute []*Ritenuta
+	// Casse lists the social security fund contributions defined in
+	// DatiCassaPrevidenziale.
+	Casse []*CassaPrevidenziale
+}
+
+// Notification contains the metadata provided by the SDI when delivering
+// a file to the recipient.
+type Notification struct {
+	// IdentificativoSdI is the unique ID assigned to the file by the SDI.
+	IdentificativoSdI string
+	// NomeFile is the name of the file transmitted.
+	NomeFile string
+	// DataOraRicezione is the date and time the file was received by the SDI.
+	DataOraRicezione string
+}
+
+// Ritenuta represents a withholding (DatiRitenuta) applied to the lines of the
+// document flagged as subject to withholding.
+type Ritenuta struct {
+	// TipoRitenuta, RT01 to RT05.
+	TipoRitenuta cbc.Code
+	// AliquotaRitenuta is the percentage withheld.
+	AliquotaRitenuta num.Percentage
+	// CausalePagamento is the reason for the payment, A to ZO.
+	CausalePagamento cbc.Code
+}
+
+// CassaPrevidenziale represents a social security fund contribution
+// (DatiCassaPrevidenziale) charged by the supplier.
+type CassaPrevidenziale struct {
+	// TipoCassa, TC01 to TC22.
+	TipoCassa cbc.Code
+	// AlCassa is the contribution percentage.
+	AlCassa num.Percentage
+	// ImponibileCassa is the base the contribution was calculated on, when
+	// different from the sum of lines.
+	ImponibileCassa *num.Amount
+	// AliquotaIVA is the VAT percentage applied to the contribution.
+	AliquotaIVA num.Percentage
+	// Ritenuta is true when the contribution is subject to withholding.
+	Ritenuta bool
+	// Natura is the VAT exemption code when AliquotaIVA is zero.
+	Natura cbc.Code
+}
+
+// Stamps provides the list of stamps to add to the envelope's header in order
+// to preserve the details of the SDI notification.
+func (n *Notification) Stamps() []*head.Stamp {
+	if n == nil {
+		return nil
+	}
+	var st []*head.Stamp
+	if n.IdentificativoSdI != "" {
+		st = append(st, &head.Stamp{Provider: StampIdentifier, Value: n.IdentificativoSdI})
+	}
+	if n.NomeFile != "" {
+		st = append(st, &head.Stamp{Provider: StampFileName, Value: n.NomeFile})
+	}
+	if n.DataOraRicezione != "" {
+		st = append(st, &head.Stamp{Provider: StampReceived, Value: n.DataOraRicezione})
+	}
+	return st
+}
+
+// Apply updates the invoice issued by the supplier with the SDI data: the addon
+// is enabled, the supplier's fiscal regime set, and the social security fund
+// contributions added as charges.
+func (r *Received) Apply(inv *bill.Invoice) error {
+	if !slices.Contains(inv.GetAddons(), V1) {
+		inv.SetAddons(append(inv.GetAddons(), V1)...)
+	}
+	if r.RegimeFiscale != "" && inv.Supplier != nil {
+		if err := checkExtCode(ExtKeyFiscalRegime, r.RegimeFiscale); err != nil {
+			return err
+		}
+		inv.Supplier.Ext = inv.Supplier.Ext.Merge(tax.Extensions{
+			ExtKeyFiscalRegime: r.RegimeFiscale,
+		})
+	}
+	for _, c := range r.Casse {
+		ch, err := r.fundCharge(c)
+		if err != nil {
+			return err
+		}
+		inv.Charges = append(inv.Charges, ch)
+	}
+	return nil
+}
+
+// LineTaxes provides the tax combos to use for a line with the given VAT
+// percentage or exemption code, including the retained taxes if the line is
+// flagged as subject to withholding.
+func (r *Received) LineTaxes(aliquota num.Percentage, natura cbc.Code, ritenuta bool) (tax.Set, error) {
+	vat, err := VATCombo(aliquota, natura)
+	if err != nil {
+		return nil, err
+	}
+	taxes := tax.Set{vat}
+	if ritenuta {
+		rt, err := r.retainedCombos()
+		if err != nil {
+			return nil, err
+		}
+		taxes = append(taxes, rt...)
+	}
+	return taxes, nil
+}
+
+// retainedCombos provides a combo for each of the withholdings. A tax set may
+// only contain a single combo per category, so repeated withholdings of the
+// same type are merged when identical, or rejected otherwise.
+func (r *Received) retainedCombos() (tax.Set, error) {
+	var taxes tax.Set
+	for _, rt := range r.Ritenute {
+		c, err := rt.Combo()
+		if err != nil {
+			return nil, err
+		}
+		if ec := taxes.Get(c.Category); ec != nil {
+			if !ec.Percent.Equals(*c.Percent) || !ec.Ext.Equals(c.Ext) {
+				return nil, fmt.Errorf("tipo ritenuta '%s' repeated with different details", rt.TipoRitenuta)
+			}
+			continue
+		}
+		taxes = append(taxes, c)
+	}
+	return taxes, nil
+}
+
+func (r *Received) fundCharge(c *CassaPrevidenziale) (*bill.Charge, error) {
+	if err := checkExtCode(ExtKeyFundType, c.TipoCassa); err != nil {
+		return nil, err
+	}
+	taxes, err := r.LineTaxes(c.AliquotaIVA, c.Natura, c.Ritenuta)
+	if err != nil {
+		return nil, err
+	}
+	pc := c.AlCassa
+	return &bill.Charge{
+		Base:    c.ImponibileCassa,
+		Percent: &pc,
+		Taxes:   taxes,
+		Ext: tax.Extensions{
+			ExtKeyFundType: c.TipoCassa,
+		},
+	}, nil
+}
+
+// Combo provides the retained tax combo for the withholding, using the tax
+// category mapped to the TipoRitenuta code by the Italian regime.
+func (rt *Ritenuta) Combo() (*tax.Combo, error) {
+	cat := RetainedCategory(rt.TipoRitenuta)
+	if cat == cbc.CodeEmpty {
+		return nil, fmt.Errorf("tipo ritenuta '%s' not supported", rt.TipoRitenuta)
+	}
+	if err := checkExtCode(ExtKeyRetained, rt.CausalePagamento); err != nil {
+		return nil, err
+	}
+	pc := rt.AliquotaRitenuta
+	return &tax.Combo{
+		Category: cat,
+		Percent:  &pc,
+		Ext: tax.Extensions{
+			ExtKeyRetained: rt.CausalePagamento,
+		},
+	}, nil
+}
+
+// RetainedCategory provides the Italian tax category code that maps to the
+// FatturaPA TipoRitenuta code, or an empty code if not supported.
+func RetainedCategory(tipo cbc.Code) cbc.Code {
+	for _, cat := range it.New().Categories {
+		if cat.Retained && cat.Map[it.KeyFatturaPATipoRitenuta] == tipo {
+			return cat.Code
+		}
+	}
+	return cbc.CodeEmpty
+}
+
+// VATCombo provides the VAT tax combo for the percentage or, when defined,
+// the Natura exemption code used in the FatturaPA document.
+func VATCombo(aliquota num.Percentage, natura cbc.Code) (*tax.Combo, error) {
+	if natura != cbc.CodeEmpty {
+		if err := checkExtCode(ExtKeyExempt, natura); err != nil {
+			return nil, err
+		}
+		return &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				ExtKeyExempt: natura,
+			},
+		}, nil
+	}
+	return &tax.Combo{
+		Category: tax.CategoryVAT,
+		Percent:  &aliquota,
+	}, nil
+}
+
+func checkExtCode(key cbc.Key, code cbc.Code) error {
+	kd := tax.ExtensionForKey(key)
+	if kd == nil || !kd.HasCode(code) {
+		return fmt.Errorf("%s: code '%s' invalid", key, code)
+	}
+	return nil
+}
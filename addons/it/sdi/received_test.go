@@ -0,0 +1,243 @@
+package sdi_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/it/sdi"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/it"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReceivedInvoice(t *testing.T) *bill.Invoice {
+	t.Helper()
+	return &bill.Invoice{
+		Regime:    tax.WithRegime("IT"),
+		Code:      "FT-42",
+		Currency:  "EUR",
+		Type:      bill.InvoiceTypeStandard,
+		IssueDate: cal.MakeDate(2024, 5, 2),
+		Supplier: &org.Party{
+			Name: "Studio Legale Rossi",
+			TaxID: &tax.Identity{
+				Country: "IT",
+				Code:    "12345678903",
+			},
+			Addresses: []*org.Address{
+				{
+					Street:   "Via del Corso",
+					Number:   "10",
+					Code:     "00186",
+					Locality: "Roma",
+					Region:   "RM",
+					Country:  "IT",
+				},
+			},
+		},
+		Customer: &org.Party{
+			Name: "Test Customer",
+			TaxID: &tax.Identity{
+				Country: "IT",
+				Code:    "13029381004",
+			},
+			Addresses: []*org.Address{
+				{
+					Street:   "Piazza di Test",
+					Number:   "1",
+					Code:     "38342",
+					Locality: "Venezia",
+					Country:  "IT",
+				},
+			},
+		},
+	}
+}
+
+func testReceived() *sdi.Received {
+	return &sdi.Received{
+		Notification: &sdi.Notification{
+			IdentificativoSdI: "4321987",
+			NomeFile:          "IT12345678903_00042.xml",
+			DataOraRicezione:  "2024-05-02T10:15:30.000+02:00",
+		},
+		RegimeFiscale: "RF19",
+		Ritenute: []*sdi.Ritenuta{
+			{
+				TipoRitenuta:     "RT01",
+				AliquotaRitenuta: num.MakePercentage(200, 3),
+				CausalePagamento: "A",
+			},
+		},
+		Casse: []*sdi.CassaPrevidenziale{
+			{
+				TipoCassa:   "TC01",
+				AlCassa:     num.MakePercentage(40, 3),
+				AliquotaIVA: num.MakePercentage(220, 3),
+				Ritenuta:    false,
+			},
+		},
+	}
+}
+
+func TestReceivedApply(t *testing.T) {
+	inv := testReceivedInvoice(t)
+	r := testReceived()
+
+	taxes, err := r.LineTaxes(num.MakePercentage(220, 3), cbc.CodeEmpty, true)
+	require.NoError(t, err)
+	inv.Lines = []*bill.Line{
+		{
+			Quantity: num.MakeAmount(1, 0),
+			Item: &org.Item{
+				Name:  "Consulenza legale",
+				Price: num.MakeAmount(100000, 2),
+			},
+			Taxes: taxes,
+		},
+	}
+	require.NoError(t, r.Apply(inv))
+	require.NoError(t, inv.Calculate())
+	require.NoError(t, inv.Validate())
+
+	assert.Contains(t, inv.GetAddons(), sdi.V1)
+	assert.Equal(t, cbc.Code("RF19"), inv.Supplier.Ext[sdi.ExtKeyFiscalRegime])
+
+	require.Len(t, inv.Charges, 1)
+	ch := inv.Charges[0]
+	assert.Equal(t, cbc.Code("TC01"), ch.Ext[sdi.ExtKeyFundType])
+	assert.Equal(t, "40.00", ch.Amount.String())
+	assert.Len(t, ch.Taxes, 1)
+
+	l := inv.Lines[0]
+	require.Len(t, l.Taxes, 2)
+	assert.Equal(t, it.TaxCategoryIRPEF, l.Taxes[1].Category)
+	assert.Equal(t, cbc.Code("A"), l.Taxes[1].Ext[sdi.ExtKeyRetained])
+
+	// 1000 + 40 fund, 22% VAT, less 20% IRPEF on 1000
+	assert.Equal(t, "1040.00", inv.Totals.Total.String())
+	assert.Equal(t, "1068.80", inv.Totals.Payable.String())
+}
+
+func TestReceivedApplyErrors(t *testing.T) {
+	t.Run("invalid fiscal regime", func(t *testing.T) {
+		inv := testReceivedInvoice(t)
+		r := testReceived()
+		r.RegimeFiscale = "RF99"
+		assert.ErrorContains(t, r.Apply(inv), "it-sdi-fiscal-regime: code 'RF99' invalid")
+	})
+	t.Run("invalid fund type", func(t *testing.T) {
+		inv := testReceivedInvoice(t)
+		r := testReceived()
+		r.Casse[0].TipoCassa = "TC99"
+		assert.ErrorContains(t, r.Apply(inv), "it-sdi-fund-type: code 'TC99' invalid")
+	})
+	t.Run("fund subject to unsupported withholding", func(t *testing.T) {
+		inv := testReceivedInvoice(t)
+		r := testReceived()
+		r.Casse[0].Ritenuta = true
+		r.Ritenute[0].TipoRitenuta = "RT06"
+		assert.ErrorContains(t, r.Apply(inv), "tipo ritenuta 'RT06' not supported")
+	})
+}
+
+func TestReceivedLineTaxesRitenute(t *testing.T) {
+	t.Run("different types", func(t *testing.T) {
+		r := testReceived()
+		r.Ritenute = append(r.Ritenute, &sdi.Ritenuta{
+			TipoRitenuta:     "RT03",
+			AliquotaRitenuta: num.MakePercentage(40, 3),
+			CausalePagamento: "A",
+		})
+		taxes, err := r.LineTaxes(num.MakePercentage(220, 3), cbc.CodeEmpty, true)
+		require.NoError(t, err)
+		require.Len(t, taxes, 3)
+		assert.Equal(t, it.TaxCategoryIRPEF, taxes[1].Category)
+		assert.Equal(t, it.TaxCategoryINPS, taxes[2].Category)
+	})
+	t.Run("two identical IRPEF", func(t *testing.T) {
+		inv := testReceivedInvoice(t)
+		r := testReceived()
+		r.Ritenute = append(r.Ritenute, &sdi.Ritenuta{
+			TipoRitenuta:     "RT01",
+			AliquotaRitenuta: num.MakePercentage(200, 3),
+			CausalePagamento: "A",
+		})
+		taxes, err := r.LineTaxes(num.MakePercentage(220, 3), cbc.CodeEmpty, true)
+		require.NoError(t, err)
+		require.Len(t, taxes, 2)
+		assert.Equal(t, it.TaxCategoryIRPEF, taxes[1].Category)
+
+		inv.Lines = []*bill.Line{
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Consulenza legale",
+					Price: num.MakeAmount(100000, 2),
+				},
+				Taxes: taxes,
+			},
+		}
+		require.NoError(t, r.Apply(inv))
+		require.NoError(t, inv.Calculate())
+		assert.NoError(t, inv.Validate())
+	})
+	t.Run("two different IRPEF", func(t *testing.T) {
+		r := testReceived()
+		r.Ritenute = append(r.Ritenute, &sdi.Ritenuta{
+			TipoRitenuta:     "RT01",
+			AliquotaRitenuta: num.MakePercentage(230, 3),
+			CausalePagamento: "A",
+		})
+		_, err := r.LineTaxes(num.MakePercentage(220, 3), cbc.CodeEmpty, true)
+		assert.ErrorContains(t, err, "tipo ritenuta 'RT01' repeated with different details")
+	})
+}
+
+func TestRetainedCategory(t *testing.T) {
+	tests := map[cbc.Code]cbc.Code{
+		"RT01": it.TaxCategoryIRPEF,
+		"RT02": it.TaxCategoryIRES,
+		"RT03": it.TaxCategoryINPS,
+		"RT04": it.TaxCategoryENASARCO,
+		"RT05": it.TaxCategoryENPAM,
+		"RT06": cbc.CodeEmpty,
+	}
+	for tipo, cat := range tests {
+		assert.Equal(t, cat, sdi.RetainedCategory(tipo), string(tipo))
+	}
+}
+
+func TestVATCombo(t *testing.T) {
+	c, err := sdi.VATCombo(num.MakePercentage(100, 3), cbc.CodeEmpty)
+	require.NoError(t, err)
+	assert.Equal(t, tax.CategoryVAT, c.Category)
+	assert.Equal(t, "10.0%", c.Percent.String())
+
+	c, err = sdi.VATCombo(num.MakePercentage(0, 2), "N2.2")
+	require.NoError(t, err)
+	assert.Equal(t, tax.RateExempt, c.Rate)
+	assert.Nil(t, c.Percent)
+	assert.Equal(t, cbc.Code("N2.2"), c.Ext[sdi.ExtKeyExempt])
+
+	_, err = sdi.VATCombo(num.MakePercentage(0, 2), "N2")
+	assert.ErrorContains(t, err, "it-sdi-exempt: code 'N2' invalid")
+}
+
+func TestNotificationStamps(t *testing.T) {
+	n := testReceived().Notification
+	st := n.Stamps()
+	require.Len(t, st, 3)
+	assert.Equal(t, sdi.StampIdentifier, st[0].Provider)
+	assert.Equal(t, "4321987", st[0].Value)
+	assert.Equal(t, sdi.StampFileName, st[1].Provider)
+	assert.Equal(t, sdi.StampReceived, st[2].Provider)
+
+	n = nil
+	assert.Nil(t, n.Stamps())
+}
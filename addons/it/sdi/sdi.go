@@ -15,6 +15,18 @@ const (
 	V1 cbc.Key = "it-sdi-v1"
 )
 
+// Stamps provided by the SDI when delivering a document to the recipient
+const (
+	// StampIdentifier contains the unique ID assigned to the file by the SDI
+	// (IdentificativoSdI).
+	StampIdentifier cbc.Key = "sdi-id"
+	// StampFileName contains the name of the file transmitted (NomeFile).
+	StampFileName cbc.Key = "sdi-file-name"
+	// StampReceived contains the date and time the SDI received the file
+	// from the transmitter (DataOraRicezione).
+	StampReceived cbc.Key = "sdi-received"
+)
+
 func init() {
 	tax.RegisterAddonDef(newAddon())
 }
@@ -44,6 +56,8 @@ func normalize(doc any) {
 		normalizePayInstructions(obj)
 	case *pay.Advance:
 		normalizePayAdvance(obj)
+	case *tax.Combo:
+		normalizeTaxCombo(obj)
 	}
 }
 
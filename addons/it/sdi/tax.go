@@ -1,11 +1,18 @@
 package sdi
 
 import (
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/regimes/it"
 	"github.com/invopop/gobl/tax"
 	"github.com/invopop/validation"
 )
 
+func normalizeTaxCombo(c *tax.Combo) {
+	if c.Category == tax.CategoryVAT {
+		c.Ext = cef.NormalizeVATEX(c.Ext, ExtKeyExempt)
+	}
+}
+
 func validateTaxCombo(val any) error {
 	c, ok := val.(*tax.Combo)
 	if !ok {
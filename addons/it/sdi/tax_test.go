@@ -0,0 +1,51 @@
+package sdi_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/it/sdi"
+	"github.com/invopop/gobl/catalogues/cef"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxComboNormalization(t *testing.T) {
+	ad := tax.AddonForKey(sdi.V1)
+
+	t.Run("exempt with VATEX", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				sdi.ExtKeyExempt: "N3.2",
+			},
+		}
+		ad.Normalizer(c)
+		assert.Equal(t, "VATEX-EU-IC", c.Ext[cef.ExtKeyVATEX].String())
+	})
+
+	t.Run("VATEX with exempt", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-IC",
+			},
+		}
+		ad.Normalizer(c)
+		assert.Equal(t, "N3.2", c.Ext[sdi.ExtKeyExempt].String())
+	})
+
+	t.Run("ambiguous VATEX", func(t *testing.T) {
+		c := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-AE", // N6.1 to N6.9
+			},
+		}
+		ad.Normalizer(c)
+		assert.False(t, c.Ext.Has(sdi.ExtKeyExempt))
+		assert.Equal(t, "VATEX-EU-AE", c.Ext[cef.ExtKeyVATEX].String())
+	})
+}
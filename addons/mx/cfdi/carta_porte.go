@@ -0,0 +1,503 @@
+package cfdi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/mx"
+	"github.com/invopop/validation"
+)
+
+// Constants for the precision of the complement's amounts
+const (
+	CartaPorteDistancePrecision = 2
+	CartaPorteWeightPrecision   = 3
+)
+
+// Default unit of weight used in the complement (kilograms)
+const (
+	CartaPorteDefaultWeightUnit cbc.Code = "KGM"
+)
+
+// Location types
+const (
+	CartaPorteLocationOrigin      cbc.Key = "origin"      // Origen
+	CartaPorteLocationDestination cbc.Key = "destination" // Destino
+)
+
+// Direction of goods in international transport
+const (
+	CartaPorteDirectionEntry cbc.Key = "entry" // Entrada
+	CartaPorteDirectionExit  cbc.Key = "exit"  // Salida
+)
+
+// Figure types (c_FiguraTransporte)
+const (
+	CartaPorteFigureOperator cbc.Code = "01" // Operador
+	CartaPorteFigureOwner    cbc.Code = "02" // Propietario
+	CartaPorteFigureLessor   cbc.Code = "03" // Arrendador
+	CartaPorteFigureNotified cbc.Code = "04" // Notificado
+)
+
+// CartaPorteValidFigureTypes lists the complement's allowed figure types
+var CartaPorteValidFigureTypes = []any{
+	CartaPorteFigureOperator,
+	CartaPorteFigureOwner,
+	CartaPorteFigureLessor,
+	CartaPorteFigureNotified,
+}
+
+// Complement's Codes Patterns
+const (
+	CartaPorteIDPattern         = "^CCC[a-fA-F0-9]{5}-[a-fA-F0-9]{4}-4[a-fA-F0-9]{3}-[89aAbB][a-fA-F0-9]{3}-[a-fA-F0-9]{12}$"
+	CartaPorteLocationIDPattern = "^(OR|DE)[0-9]{6}$"
+	CartaPorteProdServPattern   = "^[0-9]{8}$"
+	CartaPortePlatePattern      = "^[A-Z0-9]{5,7}$"
+)
+
+// Complement's Codes Regexps
+var (
+	CartaPorteIDRegexp         = regexp.MustCompile(CartaPorteIDPattern)
+	CartaPorteLocationIDRegexp = regexp.MustCompile(CartaPorteLocationIDPattern)
+	CartaPorteProdServRegexp   = regexp.MustCompile(CartaPorteProdServPattern)
+	CartaPortePlateRegexp      = regexp.MustCompile(CartaPortePlatePattern)
+)
+
+// CartaPorte carries the data to produce a CFDI's "Complemento Carta Porte"
+// (version 3.1) providing details of goods transported by road within
+// Mexico. It must be included in income ("I") invoices issued by transport
+// companies for their services, and in transport ("T") documents issued
+// by the owners of the goods when moving them with their own vehicles.
+//
+// This struct maps to the `CartaPorte` root node in the CFDI's complement.
+type CartaPorte struct {
+	// Carta Porte identifier, a UUID with the "CCC" prefix (maps to `IdCCP`).
+	ID cbc.Code `json:"id" jsonschema:"title=ID"`
+	// Whether the goods enter or leave the country (maps to `TranspInternac`).
+	International bool `json:"international,omitempty" jsonschema:"title=International"`
+	// Direction of the goods when international, "entry" or "exit" (maps to `EntradaSalidaMerc`).
+	Direction cbc.Key `json:"direction,omitempty" jsonschema:"title=Direction"`
+	// Country of origin or destination of the goods when international (maps to `PaisOrigenDestino`).
+	Country l10n.ISOCountryCode `json:"country,omitempty" jsonschema:"title=Country"`
+	// Sum of the distances travelled to each destination in km (calculated, maps to `TotalDistRec`).
+	TotalDistance num.Amount `json:"total_distance" jsonschema:"title=Total Distance" jsonschema_extras:"calculated=true"`
+	// List of origin and destination locations (maps to `Ubicaciones`).
+	Locations []*CartaPorteLocation `json:"locations" jsonschema:"title=Locations"`
+	// Details of the goods transported and the vehicle used (maps to `Mercancias`).
+	Goods *CartaPorteGoods `json:"goods" jsonschema:"title=Goods"`
+	// List of operators, owners and lessors involved in the transport (maps to `FiguraTransporte`).
+	Figures []*CartaPorteFigure `json:"figures" jsonschema:"title=Figures"`
+}
+
+// CartaPorteLocation represents a place where the goods are collected or
+// delivered. It maps to one `Ubicacion` node in the CFDI's complement.
+type CartaPorteLocation struct {
+	// Type of location, "origin" or "destination" (maps to `TipoUbicacion`).
+	Type cbc.Key `json:"type" jsonschema:"title=Type"`
+	// Identifier of the location, "OR" or "DE" followed by 6 digits (calculated if empty, maps to `IDUbicacion`).
+	ID cbc.Code `json:"id,omitempty" jsonschema:"title=ID"`
+	// Tax identity code of the sender or recipient (maps to `RFCRemitenteDestinatario`).
+	TaxCode cbc.Code `json:"tax_code" jsonschema:"title=Tax Code"`
+	// Name of the sender or recipient (maps to `NombreRemitenteDestinatario`).
+	Name string `json:"name,omitempty" jsonschema:"title=Name"`
+	// Date and time of departure from an origin or arrival at a destination (maps to `FechaHoraSalidaLlegada`).
+	DateTime cal.DateTime `json:"date_time" jsonschema:"title=Date and Time"`
+	// Distance travelled in km to reach a destination (maps to `DistanciaRecorrida`).
+	Distance *num.Amount `json:"distance,omitempty" jsonschema:"title=Distance"`
+	// Address of the location (maps to `Domicilio`).
+	Address *org.Address `json:"address" jsonschema:"title=Address"`
+}
+
+// CartaPorteGoods groups the goods transported and the vehicle that carries
+// them. It maps to the `Mercancias` node in the CFDI's complement.
+type CartaPorteGoods struct {
+	// Sum of the weights of all the goods (calculated, maps to `PesoBrutoTotal`).
+	GrossWeight num.Amount `json:"gross_weight" jsonschema:"title=Gross Weight" jsonschema_extras:"calculated=true"`
+	// Unit of the weights, from the `c_ClaveUnidadPeso` catalogue, "KGM" by default (maps to `UnidadPeso`).
+	WeightUnit cbc.Code `json:"weight_unit" jsonschema:"title=Weight Unit"`
+	// Number of goods lines (calculated, maps to `NumTotalMercancias`).
+	Count int `json:"count" jsonschema:"title=Count" jsonschema_extras:"calculated=true"`
+	// List of the goods transported (maps to `Mercancia`).
+	Lines []*CartaPorteGoodsLine `json:"lines" jsonschema:"title=Lines"`
+	// Vehicle used for road transport (maps to `Autotransporte`).
+	Vehicle *CartaPorteVehicle `json:"vehicle" jsonschema:"title=Vehicle"`
+}
+
+// CartaPorteGoodsLine represents a single type of goods transported. It maps
+// to one `Mercancia` node in the CFDI's complement.
+type CartaPorteGoodsLine struct {
+	// Line number starting from 1 (calculated).
+	Index int `json:"i" jsonschema:"title=Index" jsonschema_extras:"calculated=true"`
+	// Code from the `c_ClaveProdServCP` catalogue for the goods (maps to `BienesTransp`).
+	ProdServ cbc.Code `json:"prod_serv" jsonschema:"title=Product or Service Code"`
+	// Description of the goods (maps to `Descripcion`).
+	Description string `json:"description" jsonschema:"title=Description"`
+	// Quantity of goods (maps to `Cantidad`).
+	Quantity num.Amount `json:"quantity" jsonschema:"title=Quantity"`
+	// Code from the `c_ClaveUnidad` catalogue for the quantity (maps to `ClaveUnidad`).
+	Unit cbc.Code `json:"unit" jsonschema:"title=Unit"`
+	// Whether the goods are hazardous (maps to `MaterialPeligroso`).
+	Hazardous bool `json:"hazardous,omitempty" jsonschema:"title=Hazardous"`
+	// Code from the `c_MaterialPeligroso` catalogue for hazardous goods (maps to `CveMaterialPeligroso`).
+	HazardousCode cbc.Code `json:"hazardous_code,omitempty" jsonschema:"title=Hazardous Code"`
+	// Weight of the goods in the complement's weight unit (maps to `PesoEnKg`).
+	Weight num.Amount `json:"weight" jsonschema:"title=Weight"`
+	// Value of the goods (maps to `ValorMercancia`).
+	Value *num.Amount `json:"value,omitempty" jsonschema:"title=Value"`
+	// Currency of the value (maps to `Moneda`).
+	Currency currency.Code `json:"currency,omitempty" jsonschema:"title=Currency"`
+}
+
+// CartaPorteVehicle provides the details of the vehicle used for road
+// transport. It maps to the `Autotransporte` node in the CFDI's complement.
+type CartaPorteVehicle struct {
+	// Code from the `c_TipoPermiso` catalogue for the SCT permit (maps to `PermSCT`).
+	PermitType cbc.Code `json:"permit_type" jsonschema:"title=Permit Type"`
+	// Number of the SCT permit (maps to `NumPermisoSCT`).
+	PermitNumber string `json:"permit_number" jsonschema:"title=Permit Number"`
+	// Code from the `c_ConfigAutotransporte` catalogue for the vehicle configuration (maps to `ConfigVehicular`).
+	Config cbc.Code `json:"config" jsonschema:"title=Configuration"`
+	// Gross weight of the vehicle in tonnes (maps to `PesoBrutoVehicular`).
+	GrossWeight num.Amount `json:"gross_weight" jsonschema:"title=Gross Weight"`
+	// Licence plate of the vehicle (maps to `PlacaVM`).
+	Plate cbc.Code `json:"plate" jsonschema:"title=Plate"`
+	// Model year of the vehicle (maps to `AnioModeloVM`).
+	ModelYear int `json:"model_year" jsonschema:"title=Model Year"`
+	// Name of the civil liability insurer (maps to `AseguraRespCivil`).
+	Insurer string `json:"insurer" jsonschema:"title=Insurer"`
+	// Number of the civil liability insurance policy (maps to `PolizaRespCivil`).
+	Policy string `json:"policy" jsonschema:"title=Policy"`
+	// Trailers pulled by the vehicle (maps to `Remolques`).
+	Trailers []*CartaPorteTrailer `json:"trailers,omitempty" jsonschema:"title=Trailers"`
+}
+
+// CartaPorteTrailer represents a trailer pulled by the vehicle. It maps to
+// one `Remolque` node in the CFDI's complement.
+type CartaPorteTrailer struct {
+	// Code from the `c_SubTipoRem` catalogue for the trailer type (maps to `SubTipoRem`).
+	Type cbc.Code `json:"type" jsonschema:"title=Type"`
+	// Licence plate of the trailer (maps to `Placa`).
+	Plate cbc.Code `json:"plate" jsonschema:"title=Plate"`
+}
+
+// CartaPorteFigure represents a person involved in the transport, such as
+// the operator driving the vehicle. It maps to one `TiposFigura` node in the
+// CFDI's complement.
+type CartaPorteFigure struct {
+	// Code from the `c_FiguraTransporte` catalogue for the type of figure (maps to `TipoFigura`).
+	Type cbc.Code `json:"type" jsonschema:"title=Type"`
+	// Tax identity code of the figure (maps to `RFCFigura`).
+	TaxCode cbc.Code `json:"tax_code" jsonschema:"title=Tax Code"`
+	// Name of the figure (maps to `NombreFigura`).
+	Name string `json:"name" jsonschema:"title=Name"`
+	// Driving licence number, required for operators (maps to `NumLicencia`).
+	License string `json:"license,omitempty" jsonschema:"title=License"`
+}
+
+// Validate checks the CartaPorte data according to the SAT's rules for
+// the "Complemento Carta Porte".
+func (cp *CartaPorte) Validate() error {
+	return validation.ValidateStruct(cp,
+		validation.Field(&cp.ID,
+			validation.Required,
+			validation.Match(CartaPorteIDRegexp),
+			validation.Skip, // don't use default code validations
+		),
+		validation.Field(&cp.Direction,
+			validation.When(
+				cp.International,
+				validation.Required,
+				validation.In(CartaPorteDirectionEntry, CartaPorteDirectionExit),
+			).Else(
+				validation.Empty,
+			),
+		),
+		validation.Field(&cp.Country,
+			validation.When(
+				cp.International,
+				validation.Required,
+			).Else(
+				validation.Empty,
+			),
+		),
+		validation.Field(&cp.TotalDistance, num.Positive),
+		validation.Field(&cp.Locations,
+			validation.Required,
+			validation.Length(2, 0),
+			validation.By(validateCartaPorteLocationTypes),
+		),
+		validation.Field(&cp.Goods, validation.Required),
+		validation.Field(&cp.Figures,
+			validation.Required,
+			validation.By(validateCartaPorteOperator),
+		),
+	)
+}
+
+// Validate checks the CartaPorteLocation data is valid.
+func (cpl *CartaPorteLocation) Validate() error {
+	return validation.ValidateStruct(cpl,
+		validation.Field(&cpl.Type,
+			validation.Required,
+			validation.In(CartaPorteLocationOrigin, CartaPorteLocationDestination),
+		),
+		validation.Field(&cpl.ID,
+			validation.Match(CartaPorteLocationIDRegexp),
+			validation.By(validateCartaPorteLocationID(cpl.Type)),
+			validation.Skip,
+		),
+		validation.Field(&cpl.TaxCode,
+			validation.Required,
+			validation.By(mx.ValidateTaxCode),
+			validation.Skip,
+		),
+		validation.Field(&cpl.Name, validation.Length(1, 254)),
+		validation.Field(&cpl.DateTime, cal.DateTimeNotZero()),
+		validation.Field(&cpl.Distance,
+			validation.When(
+				cpl.Type == CartaPorteLocationDestination,
+				validation.Required,
+				num.Positive,
+			).Else(
+				validation.Empty,
+			),
+		),
+		validation.Field(&cpl.Address,
+			validation.Required,
+			validation.By(validateCartaPorteAddress),
+		),
+	)
+}
+
+// Validate checks the CartaPorteGoods data is valid.
+func (cpg *CartaPorteGoods) Validate() error {
+	return validation.ValidateStruct(cpg,
+		validation.Field(&cpg.GrossWeight, num.Positive),
+		validation.Field(&cpg.WeightUnit, validation.Required),
+		validation.Field(&cpg.Count, validation.Required),
+		validation.Field(&cpg.Lines, validation.Required),
+		validation.Field(&cpg.Vehicle, validation.Required),
+	)
+}
+
+// Validate checks the CartaPorteGoodsLine data is valid.
+func (cgl *CartaPorteGoodsLine) Validate() error {
+	return validation.ValidateStruct(cgl,
+		validation.Field(&cgl.ProdServ,
+			validation.Required,
+			validation.Match(CartaPorteProdServRegexp),
+		),
+		validation.Field(&cgl.Description,
+			validation.Required,
+			validation.Length(1, 1000),
+		),
+		validation.Field(&cgl.Quantity, num.Positive),
+		validation.Field(&cgl.Unit, validation.Required),
+		validation.Field(&cgl.HazardousCode,
+			validation.When(
+				cgl.Hazardous,
+				validation.Required,
+			).Else(
+				validation.Empty,
+			),
+		),
+		validation.Field(&cgl.Weight, num.Positive),
+		validation.Field(&cgl.Value, num.Min(num.AmountZero)),
+		validation.Field(&cgl.Currency,
+			validation.When(
+				cgl.Value != nil,
+				validation.Required,
+			),
+		),
+	)
+}
+
+// Validate checks the CartaPorteVehicle data is valid.
+func (cpv *CartaPorteVehicle) Validate() error {
+	return validation.ValidateStruct(cpv,
+		validation.Field(&cpv.PermitType, validation.Required),
+		validation.Field(&cpv.PermitNumber,
+			validation.Required,
+			validation.Length(1, 50),
+		),
+		validation.Field(&cpv.Config, validation.Required),
+		validation.Field(&cpv.GrossWeight, num.Positive),
+		validation.Field(&cpv.Plate,
+			validation.Required,
+			validation.Match(CartaPortePlateRegexp),
+		),
+		validation.Field(&cpv.ModelYear,
+			validation.Required,
+			validation.Min(1900),
+		),
+		validation.Field(&cpv.Insurer,
+			validation.Required,
+			validation.Length(1, 50),
+		),
+		validation.Field(&cpv.Policy,
+			validation.Required,
+			validation.Length(1, 30),
+		),
+		validation.Field(&cpv.Trailers, validation.Length(0, 2)),
+	)
+}
+
+// Validate checks the CartaPorteTrailer data is valid.
+func (cpt *CartaPorteTrailer) Validate() error {
+	return validation.ValidateStruct(cpt,
+		validation.Field(&cpt.Type, validation.Required),
+		validation.Field(&cpt.Plate,
+			validation.Required,
+			validation.Match(CartaPortePlateRegexp),
+		),
+	)
+}
+
+// Validate checks the CartaPorteFigure data is valid.
+func (cpf *CartaPorteFigure) Validate() error {
+	return validation.ValidateStruct(cpf,
+		validation.Field(&cpf.Type,
+			validation.Required,
+			validation.In(CartaPorteValidFigureTypes...),
+		),
+		validation.Field(&cpf.TaxCode,
+			validation.Required,
+			validation.By(mx.ValidateTaxCode),
+			validation.Skip,
+		),
+		validation.Field(&cpf.Name,
+			validation.Required,
+			validation.Length(1, 254),
+		),
+		validation.Field(&cpf.License,
+			validation.When(
+				cpf.Type == CartaPorteFigureOperator,
+				validation.Required,
+			),
+			validation.Length(6, 16),
+		),
+	)
+}
+
+func validateCartaPorteLocationTypes(value any) error {
+	locs, _ := value.([]*CartaPorteLocation)
+	origin, destination := false, false
+	for _, l := range locs {
+		if l == nil {
+			continue
+		}
+		switch l.Type {
+		case CartaPorteLocationOrigin:
+			origin = true
+		case CartaPorteLocationDestination:
+			destination = true
+		}
+	}
+	if !origin || !destination {
+		return errors.New("must include at least one origin and one destination")
+	}
+	return nil
+}
+
+func validateCartaPorteLocationID(typ cbc.Key) func(any) error {
+	return func(value any) error {
+		id, _ := value.(cbc.Code)
+		if id == cbc.CodeEmpty || !CartaPorteLocationIDRegexp.MatchString(id.String()) {
+			return nil
+		}
+		if prefix := cartaPorteLocationIDPrefix(typ); prefix != "" && id.String()[:2] != prefix {
+			return fmt.Errorf("must start with '%s' for %s locations", prefix, typ)
+		}
+		return nil
+	}
+}
+
+func validateCartaPorteOperator(value any) error {
+	figures, _ := value.([]*CartaPorteFigure)
+	for _, f := range figures {
+		if f != nil && f.Type == CartaPorteFigureOperator {
+			return nil
+		}
+	}
+	return errors.New("must include an operator")
+}
+
+func validateCartaPorteAddress(value any) error {
+	addr, _ := value.(*org.Address)
+	if addr == nil {
+		return nil
+	}
+	return validation.ValidateStruct(addr,
+		validation.Field(&addr.State, validation.Required),
+		validation.Field(&addr.Country, validation.Required),
+		validation.Field(&addr.Code,
+			validation.Required,
+			validation.When(
+				addr.Country.In("MX"),
+				validation.Match(PostCodeRegexp),
+			),
+		),
+	)
+}
+
+func cartaPorteLocationIDPrefix(typ cbc.Key) string {
+	switch typ {
+	case CartaPorteLocationOrigin:
+		return "OR"
+	case CartaPorteLocationDestination:
+		return "DE"
+	}
+	return ""
+}
+
+// Calculate performs the complement's calculations and normalisations.
+func (cp *CartaPorte) Calculate() error {
+	cp.TotalDistance = num.MakeAmount(0, CartaPorteDistancePrecision)
+
+	seq := make(map[cbc.Key]int)
+	for _, l := range cp.Locations {
+		if l == nil {
+			continue
+		}
+		seq[l.Type]++
+		if l.ID == cbc.CodeEmpty {
+			if prefix := cartaPorteLocationIDPrefix(l.Type); prefix != "" {
+				l.ID = cbc.Code(fmt.Sprintf("%s%06d", prefix, seq[l.Type]))
+			}
+		}
+		if l.Distance != nil {
+			d := l.Distance.Rescale(CartaPorteDistancePrecision)
+			l.Distance = &d
+			if l.Type == CartaPorteLocationDestination {
+				cp.TotalDistance = cp.TotalDistance.Add(d)
+			}
+		}
+	}
+
+	if cp.Goods != nil {
+		cp.Goods.calculate()
+	}
+
+	return nil
+}
+
+func (cpg *CartaPorteGoods) calculate() {
+	if cpg.WeightUnit == cbc.CodeEmpty {
+		cpg.WeightUnit = CartaPorteDefaultWeightUnit
+	}
+	cpg.GrossWeight = num.MakeAmount(0, CartaPorteWeightPrecision)
+	cpg.Count = len(cpg.Lines)
+
+	for i, l := range cpg.Lines {
+		l.Index = i + 1
+		l.Weight = l.Weight.RescaleUp(CartaPorteWeightPrecision)
+		cpg.GrossWeight = cpg.GrossWeight.Add(l.Weight)
+	}
+}
@@ -0,0 +1,259 @@
+package cfdi_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/mx/cfdi"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/head"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/mx"
+	"github.com/invopop/gobl/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validCartaPorte() *cfdi.CartaPorte {
+	return &cfdi.CartaPorte{
+		ID: "CCC7a8c2-1c2d-4a4b-9d3e-0f1e2d3c4b5a",
+		Locations: []*cfdi.CartaPorteLocation{
+			{
+				Type:     cfdi.CartaPorteLocationOrigin,
+				TaxCode:  "EKU9003173C9",
+				DateTime: cal.MakeDateTime(2024, 3, 15, 8, 0, 0),
+				Address: &org.Address{
+					Street:   "Av. Reforma",
+					Number:   "100",
+					Locality: "Puebla",
+					State:    "PUE",
+					Code:     "72000",
+					Country:  "MX",
+				},
+			},
+			{
+				Type:     cfdi.CartaPorteLocationDestination,
+				TaxCode:  "URE180429TM6",
+				DateTime: cal.MakeDateTime(2024, 3, 15, 12, 0, 0),
+				Distance: num.NewAmount(1305, 1),
+				Address: &org.Address{
+					Street:   "Insurgentes Sur",
+					Number:   "200",
+					Locality: "Ciudad de México",
+					State:    "CMX",
+					Code:     "03100",
+					Country:  "MX",
+				},
+			},
+			{
+				Type:     cfdi.CartaPorteLocationDestination,
+				TaxCode:  "URE180429TM6",
+				DateTime: cal.MakeDateTime(2024, 3, 15, 15, 0, 0),
+				Distance: num.NewAmount(45, 0),
+				Address: &org.Address{
+					Street:   "Av. Juárez",
+					Number:   "5",
+					Locality: "Toluca",
+					State:    "MEX",
+					Code:     "50000",
+					Country:  "MX",
+				},
+			},
+		},
+		Goods: &cfdi.CartaPorteGoods{
+			Lines: []*cfdi.CartaPorteGoodsLine{
+				{
+					ProdServ:    "24121500",
+					Description: "Cajas de cartón",
+					Quantity:    num.MakeAmount(100, 0),
+					Unit:        "XBX",
+					Weight:      num.MakeAmount(2505, 1),
+				},
+				{
+					ProdServ:    "43211500",
+					Description: "Computadoras",
+					Quantity:    num.MakeAmount(10, 0),
+					Unit:        "H87",
+					Weight:      num.MakeAmount(85, 0),
+				},
+			},
+			Vehicle: &cfdi.CartaPorteVehicle{
+				PermitType:   "TPAF01",
+				PermitNumber: "0X2XTXZ0X5X0X3X2X1X0",
+				Config:       "C2",
+				GrossWeight:  num.MakeAmount(35, 1),
+				Plate:        "501AAA",
+				ModelYear:    2020,
+				Insurer:      "Seguros SA",
+				Policy:       "154647",
+			},
+		},
+		Figures: []*cfdi.CartaPorteFigure{
+			{
+				Type:    cfdi.CartaPorteFigureOperator,
+				TaxCode: "VAAM130719H60",
+				Name:    "Juan Pérez",
+				License: "a234567890",
+			},
+		},
+	}
+}
+
+func TestValidCartaPorte(t *testing.T) {
+	cp := validCartaPorte()
+	require.NoError(t, cp.Calculate())
+	assert.NoError(t, cp.Validate())
+}
+
+func TestCalculateCartaPorte(t *testing.T) {
+	cp := validCartaPorte()
+	cp.Locations[2].ID = "DE000009"
+
+	require.NoError(t, cp.Calculate())
+
+	assert.Equal(t, "175.50", cp.TotalDistance.String())
+	assert.Equal(t, cbc.Code("OR000001"), cp.Locations[0].ID)
+	assert.Equal(t, cbc.Code("DE000001"), cp.Locations[1].ID)
+	assert.Equal(t, cbc.Code("DE000009"), cp.Locations[2].ID)
+	assert.Equal(t, "130.50", cp.Locations[1].Distance.String())
+
+	assert.Equal(t, cfdi.CartaPorteDefaultWeightUnit, cp.Goods.WeightUnit)
+	assert.Equal(t, 2, cp.Goods.Count)
+	assert.Equal(t, "335.500", cp.Goods.GrossWeight.String())
+	assert.Equal(t, 2, cp.Goods.Lines[1].Index)
+}
+
+func TestInvalidCartaPorte(t *testing.T) {
+	cp := &cfdi.CartaPorte{}
+
+	err := cp.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "id: cannot be blank")
+	assert.Contains(t, err.Error(), "locations: cannot be blank")
+	assert.Contains(t, err.Error(), "goods: cannot be blank")
+	assert.Contains(t, err.Error(), "figures: cannot be blank")
+
+	cp = validCartaPorte()
+	cp.ID = "7a8c2c2d-1c2d-4a4b-9d3e-0f1e2d3c4b5a"
+	cp.Locations = cp.Locations[1:]
+	cp.Figures[0].Type = cfdi.CartaPorteFigureOwner
+	require.NoError(t, cp.Calculate())
+
+	err = cp.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "id: must be in a valid format")
+	assert.Contains(t, err.Error(), "locations: must include at least one origin and one destination")
+	assert.Contains(t, err.Error(), "figures: must include an operator")
+
+	cp = validCartaPorte()
+	cp.International = true
+	require.NoError(t, cp.Calculate())
+
+	err = cp.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "direction: cannot be blank")
+	assert.Contains(t, err.Error(), "country: cannot be blank")
+}
+
+func TestInvalidCartaPorteLocation(t *testing.T) {
+	cp := validCartaPorte()
+	cp.Locations[0].ID = "DE000001"
+	cp.Locations[0].Distance = num.NewAmount(10, 0)
+	cp.Locations[0].TaxCode = "INVALID"
+	cp.Locations[1].Distance = nil
+	cp.Locations[1].Address.Code = "ABC"
+	cp.Locations[2].Address = nil
+	require.NoError(t, cp.Calculate())
+
+	err := cp.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "0: (distance: must be blank; id: must start with 'OR' for origin locations; tax_code: invalid tax identity code.)")
+	assert.Contains(t, err.Error(), "1: (address: (code: must be in a valid format.); distance: cannot be blank.)")
+	assert.Contains(t, err.Error(), "2: (address: cannot be blank.)")
+}
+
+func TestInvalidCartaPorteGoods(t *testing.T) {
+	cp := validCartaPorte()
+	cp.Goods.Lines[0].ProdServ = "2412"
+	cp.Goods.Lines[0].Hazardous = true
+	cp.Goods.Lines[1].Value = num.NewAmount(1000, 0)
+	cp.Goods.Vehicle.Plate = "501-AAA"
+	cp.Goods.Vehicle.ModelYear = 0
+	require.NoError(t, cp.Calculate())
+
+	err := cp.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prod_serv: must be in a valid format")
+	assert.Contains(t, err.Error(), "hazardous_code: cannot be blank")
+	assert.Contains(t, err.Error(), "currency: cannot be blank")
+	assert.Contains(t, err.Error(), "plate: must be in a valid format")
+	assert.Contains(t, err.Error(), "model_year: cannot be blank")
+
+	cp = validCartaPorte()
+	cp.Goods.Vehicle = nil
+	require.NoError(t, cp.Calculate())
+
+	assert.ErrorContains(t, cp.Validate(), "vehicle: cannot be blank")
+}
+
+func TestInvalidCartaPorteFigure(t *testing.T) {
+	cp := validCartaPorte()
+	cp.Figures[0].License = ""
+	cp.Figures = append(cp.Figures, &cfdi.CartaPorteFigure{Type: "99"})
+	require.NoError(t, cp.Calculate())
+
+	err := cp.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "license: cannot be blank")
+	assert.Contains(t, err.Error(), "type: must be a valid value")
+	assert.Contains(t, err.Error(), "name: cannot be blank")
+}
+
+func TestCartaPorteInvoiceDocType(t *testing.T) {
+	inv := validInvoice()
+	obj, err := schema.NewObject(validCartaPorte())
+	require.NoError(t, err)
+	inv.Complements = []*schema.Object{obj}
+	require.NoError(t, inv.Calculate())
+	assert.Equal(t, cbc.Code("I"), inv.Tax.Ext[cfdi.ExtKeyDocType])
+	assert.NoError(t, inv.Validate())
+
+	inv.Type = bill.InvoiceTypeCreditNote
+	inv.Preceding = []*org.DocumentRef{
+		{
+			Code: "123",
+			Stamps: []*head.Stamp{
+				{
+					Provider: mx.StampSATUUID,
+					Value:    "1fac4464-1111-0000-1111-cd37179db12e",
+				},
+			},
+		},
+	}
+	require.NoError(t, inv.Calculate())
+	assert.Equal(t, cbc.Code("E"), inv.Tax.Ext[cfdi.ExtKeyDocType])
+	assertValidationError(t, inv, "complements: (0: carta porte requires document type 'I' or 'T'.)")
+}
+
+func TestTransportInvoice(t *testing.T) {
+	inv := validInvoice()
+	inv.SetTags(cfdi.TagTransport)
+	obj, err := schema.NewObject(validCartaPorte())
+	require.NoError(t, err)
+	inv.Complements = []*schema.Object{obj}
+	require.NoError(t, inv.Calculate())
+	assert.Equal(t, cbc.Code("T"), inv.Tax.Ext[cfdi.ExtKeyDocType])
+	assertValidationError(t, inv, "totals: payable must be zero for transport documents")
+
+	inv.Lines[0].Item.Price = num.MakeAmount(0, 2)
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+}
@@ -31,6 +31,8 @@ func init() {
 	schema.Register(schema.GOBL.Add("regimes/mx"),
 		FuelAccountBalance{},
 		FoodVouchers{},
+		Payments{},
+		CartaPorte{},
 	)
 }
 
@@ -41,6 +43,9 @@ func newAddon() *tax.AddonDef {
 			i18n.EN: "Mexican SAT CFDI v4.X",
 		},
 		Extensions: extensions,
+		Tags: []*tax.TagSet{
+			invoiceTags,
+		},
 		Normalizer: normalize,
 		Scenarios:  scenarios,
 		Validator:  validate,
@@ -41,6 +41,13 @@ var extensions = []*cbc.Definition{
 					i18n.ES: "Comprobante de Egreso",
 				},
 			},
+			{
+				Code: "T",
+				Name: i18n.String{
+					i18n.EN: "Transport",
+					i18n.ES: "Comprobante de Traslado",
+				},
+			},
 		},
 	},
 	{
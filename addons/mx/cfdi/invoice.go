@@ -1,11 +1,15 @@
 package cfdi
 
 import (
+	"errors"
+
 	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/head"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/org"
 	"github.com/invopop/gobl/regimes/mx"
+	"github.com/invopop/gobl/schema"
 	"github.com/invopop/gobl/tax"
 	"github.com/invopop/validation"
 )
@@ -17,6 +21,14 @@ func normalizeInvoice(inv *bill.Invoice) {
 		normalizeItem(line.Item)
 	}
 
+	// CFDI documents include the taxes of each concept, rounded
+	// individually, which must add up to the document totals.
+	if inv.Tax == nil {
+		inv.Tax = new(bill.Tax)
+	}
+	if inv.Tax.Rounding == "" {
+		inv.Tax.Rounding = tax.CalculatorRoundPerLine
+	}
 }
 
 func validateInvoice(inv *bill.Invoice) error {
@@ -52,9 +64,50 @@ func validateInvoice(inv *bill.Invoice) error {
 			validation.Empty.Error("not supported"),
 			validation.Skip,
 		),
+		validation.Field(&inv.Totals,
+			validation.When(
+				isTransport(inv),
+				validation.By(validateTransportTotals),
+			),
+			validation.Skip,
+		),
+		validation.Field(&inv.Complements,
+			validation.Each(
+				validation.By(validateInvoiceComplement(inv)),
+				validation.Skip,
+			),
+			validation.Skip,
+		),
 	)
 }
 
+func validateTransportTotals(value any) error {
+	obj, _ := value.(*bill.Totals)
+	if obj == nil {
+		return nil
+	}
+	if !obj.Payable.IsZero() {
+		return errors.New("payable must be zero for transport documents")
+	}
+	return nil
+}
+
+func validateInvoiceComplement(inv *bill.Invoice) validation.RuleFunc {
+	return func(value any) error {
+		obj, _ := value.(*schema.Object)
+		if obj == nil {
+			return nil
+		}
+		switch obj.Instance().(type) {
+		case *CartaPorte:
+			if !hasDocType(inv, "I", "T") {
+				return errors.New("carta porte requires document type 'I' or 'T'")
+			}
+		}
+		return nil
+	}
+}
+
 func validateInvoiceTax(preceding []*org.DocumentRef) validation.RuleFunc {
 	return func(value any) error {
 		obj, _ := value.(*bill.Tax)
@@ -62,6 +115,9 @@ func validateInvoiceTax(preceding []*org.DocumentRef) validation.RuleFunc {
 			return nil
 		}
 		return validation.ValidateStruct(obj,
+			validation.Field(&obj.Rounding,
+				validation.In(tax.CalculatorRoundPerLine).Error("must round taxes per line"),
+			),
 			validation.Field(&obj.Ext,
 				tax.ExtensionsRequire(
 					ExtKeyDocType,
@@ -168,6 +224,17 @@ func validateInvoicePreceding(value interface{}) error {
 	)
 }
 
+func isTransport(inv *bill.Invoice) bool {
+	return hasDocType(inv, "T")
+}
+
+func hasDocType(inv *bill.Invoice, codes ...cbc.Code) bool {
+	if inv.Tax == nil {
+		return false
+	}
+	return inv.Tax.Ext.Get(ExtKeyDocType).In(codes...)
+}
+
 func isMexican(party *org.Party) bool {
 	if party == nil || party.TaxID == nil {
 		return false
@@ -89,6 +89,26 @@ func TestNormalizeInvoice(t *testing.T) {
 		require.NotNil(t, inv.Tax)
 		assert.Equal(t, cbc.Code("21000"), inv.Tax.Ext[cfdi.ExtKeyIssuePlace])
 	})
+	t.Run("rounds taxes per line", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Addons = tax.WithAddons(cfdi.V4)
+		inv.Lines[0].Item.Price = num.MakeAmount(1003, 2)
+		require.NoError(t, inv.Calculate())
+		require.NoError(t, inv.Validate())
+		assert.Equal(t, tax.CalculatorRoundPerLine, inv.Tax.Rounding)
+		require.Len(t, inv.Lines[0].TaxAmounts, 1)
+		assert.Equal(t, cbc.Code("VAT"), inv.Lines[0].TaxAmounts[0].Category)
+		assert.Equal(t, "16.0%", inv.Lines[0].TaxAmounts[0].Percent.String())
+		assert.Equal(t, "1.60", inv.Lines[0].TaxAmounts[0].Amount.String())
+	})
+	t.Run("rejects other rounding rules", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Addons = tax.WithAddons(cfdi.V4)
+		inv.Tax.Rounding = tax.CalculatorRoundThenSum
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, tax.CalculatorRoundThenSum, inv.Tax.Rounding)
+		assert.ErrorContains(t, inv.Validate(), "tax: (rounding: must round taxes per line.)")
+	})
 	t.Run("with supplier address code", func(t *testing.T) {
 		inv := validInvoice()
 		inv.Addons = tax.WithAddons(cfdi.V4)
@@ -0,0 +1,510 @@
+package cfdi
+
+import (
+	"errors"
+
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/regimes/mx"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/gobl/uuid"
+	"github.com/invopop/validation"
+)
+
+// Constants for the precision of the complement's amounts
+const (
+	PaymentsFinalPrecision        = 2
+	PaymentsExchangeRatePrecision = 6
+)
+
+// Tax object codes used in related documents (c_ObjetoImp)
+const (
+	PaymentsTaxObjectNotSubject      cbc.Code = "01" // No objeto de impuesto
+	PaymentsTaxObjectSubject         cbc.Code = "02" // Sí objeto de impuesto
+	PaymentsTaxObjectSubjectNoDetail cbc.Code = "03" // Sí objeto del impuesto y no obligado al desglose
+	PaymentsTaxObjectSubjectNotDue   cbc.Code = "04" // Sí objeto del impuesto y no causa impuesto
+)
+
+// PaymentsValidTaxObjects lists the complement's allowed tax object codes
+var PaymentsValidTaxObjects = []any{
+	PaymentsTaxObjectNotSubject,
+	PaymentsTaxObjectSubject,
+	PaymentsTaxObjectSubjectNoDetail,
+	PaymentsTaxObjectSubjectNotDue,
+}
+
+// PaymentsValidTaxCodes lists the complement's allowed tax codes
+var PaymentsValidTaxCodes = []any{
+	tax.CategoryVAT,
+	mx.TaxCategoryIEPS,
+	mx.TaxCategoryRVAT,
+	mx.TaxCategoryRIEPS,
+	mx.TaxCategoryISR,
+}
+
+// paymentsRetainedTaxCodes lists the tax codes that map to `Retenciones` nodes
+var paymentsRetainedTaxCodes = []cbc.Code{
+	mx.TaxCategoryRVAT,
+	mx.TaxCategoryRIEPS,
+	mx.TaxCategoryISR,
+}
+
+// Payments carries the data to produce a CFDI's "Complemento para Recepción
+// de Pagos" (version 2.0) providing details of the payments received against
+// invoices previously issued with the "PPD" (pago en parcialidades o
+// diferido) payment method.
+//
+// This struct maps to the `Pagos` root node in the CFDI's complement.
+type Payments struct {
+	// Summary of the payment amounts and taxes in MXN (calculated, maps to `Totales`).
+	Totals *PaymentsTotals `json:"totals" jsonschema:"title=Totals" jsonschema_extras:"calculated=true"`
+	// List of payments received (maps to `Pago`).
+	Lines []*PaymentsLine `json:"lines" jsonschema:"title=Lines"`
+}
+
+// PaymentsTotals summarises the amounts of all the payments in the complement
+// converted to MXN. It maps to the `Totales` node in the CFDI's complement.
+type PaymentsTotals struct {
+	// Total VAT retained (maps to `TotalRetencionesIVA`).
+	RetainedVAT *num.Amount `json:"retained_vat,omitempty" jsonschema:"title=Retained VAT"`
+	// Total ISR retained (maps to `TotalRetencionesISR`).
+	RetainedISR *num.Amount `json:"retained_isr,omitempty" jsonschema:"title=Retained ISR"`
+	// Total IEPS retained (maps to `TotalRetencionesIEPS`).
+	RetainedIEPS *num.Amount `json:"retained_ieps,omitempty" jsonschema:"title=Retained IEPS"`
+	// Total base subject to VAT at 16% (maps to `TotalTrasladosBaseIVA16`).
+	VAT16Base *num.Amount `json:"vat_16_base,omitempty" jsonschema:"title=VAT 16% Base"`
+	// Total VAT at 16% (maps to `TotalTrasladosImpuestoIVA16`).
+	VAT16Amount *num.Amount `json:"vat_16_amount,omitempty" jsonschema:"title=VAT 16% Amount"`
+	// Total base subject to VAT at 8% (maps to `TotalTrasladosBaseIVA8`).
+	VAT8Base *num.Amount `json:"vat_8_base,omitempty" jsonschema:"title=VAT 8% Base"`
+	// Total VAT at 8% (maps to `TotalTrasladosImpuestoIVA8`).
+	VAT8Amount *num.Amount `json:"vat_8_amount,omitempty" jsonschema:"title=VAT 8% Amount"`
+	// Total base subject to VAT at 0% (maps to `TotalTrasladosBaseIVA0`).
+	VAT0Base *num.Amount `json:"vat_0_base,omitempty" jsonschema:"title=VAT 0% Base"`
+	// Total VAT at 0% (maps to `TotalTrasladosImpuestoIVA0`).
+	VAT0Amount *num.Amount `json:"vat_0_amount,omitempty" jsonschema:"title=VAT 0% Amount"`
+	// Total base exempt from VAT (maps to `TotalTrasladosBaseIVAExento`).
+	VATExemptBase *num.Amount `json:"vat_exempt_base,omitempty" jsonschema:"title=VAT Exempt Base"`
+	// Sum of all the payment amounts (maps to `MontoTotalPagos`).
+	Total num.Amount `json:"total" jsonschema:"title=Total"`
+}
+
+// PaymentsLine represents a single payment received. It maps to one `Pago`
+// node in the CFDI's complement.
+type PaymentsLine struct {
+	// Line number starting from 1 (calculated).
+	Index int `json:"i" jsonschema:"title=Index" jsonschema_extras:"calculated=true"`
+	// Date and time the payment was received (maps to `FechaPago`).
+	Date cal.DateTime `json:"date" jsonschema:"title=Date"`
+	// Code from the `c_FormaPago` catalogue for the means of payment (maps to `FormaDePagoP`).
+	Means cbc.Code `json:"means" jsonschema:"title=Means"`
+	// Currency of the payment (maps to `MonedaP`).
+	Currency currency.Code `json:"currency" jsonschema:"title=Currency"`
+	// Exchange rate from the payment's currency to MXN (maps to `TipoCambioP`).
+	ExchangeRate *num.Amount `json:"exchange_rate,omitempty" jsonschema:"title=Exchange Rate"`
+	// Amount paid (maps to `Monto`).
+	Amount num.Amount `json:"amount" jsonschema:"title=Amount"`
+	// Reference of the payment operation, such as a transfer or cheque number (maps to `NumOperacion`).
+	Operation string `json:"operation,omitempty" jsonschema:"title=Operation"`
+	// Tax identity code of the payer's bank (maps to `RfcEmisorCtaOrd`).
+	PayerBankTaxCode cbc.Code `json:"payer_bank_tax_code,omitempty" jsonschema:"title=Payer's Bank Tax Code"`
+	// Name of the payer's bank when foreign (maps to `NomBancoOrdExt`).
+	PayerBankName string `json:"payer_bank_name,omitempty" jsonschema:"title=Payer's Bank Name"`
+	// Account used by the payer (maps to `CtaOrdenante`).
+	PayerAccount string `json:"payer_account,omitempty" jsonschema:"title=Payer's Account"`
+	// Tax identity code of the payee's bank (maps to `RfcEmisorCtaBen`).
+	PayeeBankTaxCode cbc.Code `json:"payee_bank_tax_code,omitempty" jsonschema:"title=Payee's Bank Tax Code"`
+	// Account that received the payment (maps to `CtaBeneficiario`).
+	PayeeAccount string `json:"payee_account,omitempty" jsonschema:"title=Payee's Account"`
+	// List of the documents the payment settles (maps to `DoctoRelacionado`).
+	Documents []*PaymentsDocument `json:"documents" jsonschema:"title=Documents"`
+	// Summary of the taxes of the related documents in the payment's currency
+	// (calculated, maps to `ImpuestosP`).
+	Taxes []*PaymentsTax `json:"taxes,omitempty" jsonschema:"title=Taxes" jsonschema_extras:"calculated=true"`
+}
+
+// PaymentsDocument represents a previously issued invoice that is totally or
+// partially settled by a payment. It maps to one `DoctoRelacionado` node in the
+// CFDI's complement.
+type PaymentsDocument struct {
+	// Fiscal folio (UUID) of the related document (maps to `IdDocumento`).
+	UUID uuid.UUID `json:"uuid" jsonschema:"title=UUID"`
+	// Series of the related document (maps to `Serie`).
+	Series cbc.Code `json:"series,omitempty" jsonschema:"title=Series"`
+	// Code of the related document (maps to `Folio`).
+	Code cbc.Code `json:"code,omitempty" jsonschema:"title=Code"`
+	// Currency of the related document (maps to `MonedaDR`).
+	Currency currency.Code `json:"currency" jsonschema:"title=Currency"`
+	// Number of units of the document's currency equivalent to one unit of the
+	// payment's currency (maps to `EquivalenciaDR`).
+	ExchangeRate *num.Amount `json:"exchange_rate,omitempty" jsonschema:"title=Exchange Rate"`
+	// Number of the installment this payment corresponds to, starting from 1 (maps to `NumParcialidad`).
+	Installment int `json:"installment" jsonschema:"title=Installment"`
+	// Amount pending to be paid before this payment (maps to `ImpSaldoAnt`).
+	PreviousBalance num.Amount `json:"previous_balance" jsonschema:"title=Previous Balance"`
+	// Amount paid in the document's currency (maps to `ImpPagado`).
+	Paid num.Amount `json:"paid" jsonschema:"title=Paid"`
+	// Amount pending to be paid after this payment (calculated, maps to `ImpSaldoInsoluto`).
+	RemainingBalance num.Amount `json:"remaining_balance" jsonschema:"title=Remaining Balance" jsonschema_extras:"calculated=true"`
+	// Code from the `c_ObjetoImp` catalogue indicating if the payment is subject to tax (maps to `ObjetoImpDR`).
+	TaxObject cbc.Code `json:"tax_object" jsonschema:"title=Tax Object"`
+	// Taxes applied to the amount paid (maps to `ImpuestosDR`).
+	Taxes []*PaymentsTax `json:"taxes,omitempty" jsonschema:"title=Taxes"`
+}
+
+// PaymentsTax represents a single tax applied to the amount paid. It maps to
+// one `TrasladoDR` or `RetencionDR` node, or to one `TrasladoP` or `RetencionP`
+// node when summarising the taxes of a payment.
+type PaymentsTax struct {
+	// Category that identifies the tax (maps to `ImpuestoDR`).
+	Category cbc.Code `json:"cat" jsonschema:"title=Category"`
+	// Amount the tax is applied to (maps to `BaseDR`).
+	Base num.Amount `json:"base" jsonschema:"title=Base"`
+	// Percent applicable to the base, empty when exempt (maps to `TasaOCuotaDR`).
+	Percent *num.Percentage `json:"percent,omitempty" jsonschema:"title=Percent"`
+	// Amount of the tax once the percent has been applied (calculated, maps to `ImporteDR`).
+	Amount *num.Amount `json:"amount,omitempty" jsonschema:"title=Amount" jsonschema_extras:"calculated=true"`
+}
+
+// Validate checks the Payments data according to the SAT's
+// rules for the "Complemento para Recepción de Pagos".
+func (pc *Payments) Validate() error {
+	return validation.ValidateStruct(pc,
+		validation.Field(&pc.Totals, validation.Required),
+		validation.Field(&pc.Lines, validation.Required),
+	)
+}
+
+// Validate checks the PaymentsTotals data is valid.
+func (pt *PaymentsTotals) Validate() error {
+	return validation.ValidateStruct(pt,
+		validation.Field(&pt.Total, num.Positive),
+	)
+}
+
+// Validate checks the PaymentsLine data is valid.
+func (pl *PaymentsLine) Validate() error {
+	return validation.ValidateStruct(pl,
+		validation.Field(&pl.Date, cal.DateTimeNotZero()),
+		validation.Field(&pl.Means,
+			validation.Required,
+			validation.By(validatePaymentsMeans),
+		),
+		validation.Field(&pl.Currency,
+			validation.Required,
+		),
+		validation.Field(&pl.ExchangeRate,
+			validation.When(
+				pl.Currency != currency.MXN,
+				validation.Required,
+				num.Positive,
+			),
+			validation.When(
+				pl.Currency == currency.MXN,
+				validation.By(validatePaymentsExchangeRateIsOne),
+			),
+		),
+		validation.Field(&pl.Amount,
+			num.Positive,
+			validation.By(validatePaymentsLineAmount(pl)),
+		),
+		validation.Field(&pl.Operation, validation.Length(1, 100)),
+		validation.Field(&pl.PayerBankTaxCode,
+			validation.By(mx.ValidateTaxCode),
+			validation.Skip, // don't use default code validations
+		),
+		validation.Field(&pl.PayerBankName, validation.Length(1, 300)),
+		validation.Field(&pl.PayerAccount, validation.Length(10, 50)),
+		validation.Field(&pl.PayeeBankTaxCode,
+			validation.By(mx.ValidateTaxCode),
+			validation.Skip,
+		),
+		validation.Field(&pl.PayeeAccount, validation.Length(10, 50)),
+		validation.Field(&pl.Documents,
+			validation.Required,
+			validation.Each(validation.By(validatePaymentsDocumentCurrency(pl))),
+		),
+		validation.Field(&pl.Taxes),
+	)
+}
+
+// Validate checks the PaymentsDocument data is valid.
+func (pd *PaymentsDocument) Validate() error {
+	return validation.ValidateStruct(pd,
+		validation.Field(&pd.UUID, validation.Required),
+		validation.Field(&pd.Series, validation.Length(1, 25)),
+		validation.Field(&pd.Code, validation.Length(1, 40)),
+		validation.Field(&pd.Currency,
+			validation.Required,
+		),
+		validation.Field(&pd.ExchangeRate, num.Positive),
+		validation.Field(&pd.Installment, validation.Required, validation.Min(1)),
+		validation.Field(&pd.PreviousBalance, num.Positive),
+		validation.Field(&pd.Paid, num.Positive),
+		validation.Field(&pd.RemainingBalance,
+			num.Min(num.MakeAmount(0, 0)),
+		),
+		validation.Field(&pd.TaxObject,
+			validation.Required,
+			validation.In(PaymentsValidTaxObjects...),
+		),
+		validation.Field(&pd.Taxes,
+			validation.When(
+				pd.TaxObject == PaymentsTaxObjectSubject,
+				validation.Required,
+			),
+			validation.When(
+				pd.TaxObject != PaymentsTaxObjectSubject,
+				validation.Empty,
+			),
+		),
+	)
+}
+
+// Validate checks the PaymentsTax data is valid.
+func (pt *PaymentsTax) Validate() error {
+	return validation.ValidateStruct(pt,
+		validation.Field(&pt.Category,
+			validation.Required,
+			validation.In(PaymentsValidTaxCodes...),
+		),
+		validation.Field(&pt.Base, num.Positive),
+		validation.Field(&pt.Percent,
+			validation.When(
+				pt.Category.In(paymentsRetainedTaxCodes...),
+				validation.Required,
+			),
+		),
+	)
+}
+
+func validatePaymentsMeans(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	if code == "99" {
+		return errors.New("must be a defined payment means")
+	}
+	if def := tax.ExtensionForKey(ExtKeyPaymentMeans); def != nil && !def.HasCode(code) {
+		return errors.New("must be a valid value")
+	}
+	return nil
+}
+
+func validatePaymentsExchangeRateIsOne(value any) error {
+	rate, ok := value.(*num.Amount)
+	if !ok || rate == nil {
+		return nil
+	}
+	if rate.Compare(num.MakeAmount(1, 0)) != 0 {
+		return errors.New("must be 1 when no conversion is required")
+	}
+	return nil
+}
+
+// validatePaymentsLineAmount checks that the payment covers the amounts paid
+// of all the related documents once converted to the payment's currency.
+func validatePaymentsLineAmount(pl *PaymentsLine) func(any) error {
+	return func(_ any) error {
+		sum := num.MakeAmount(0, PaymentsFinalPrecision)
+		for _, d := range pl.Documents {
+			if d == nil {
+				continue
+			}
+			sum = sum.Add(d.paidInPaymentCurrency())
+		}
+		if pl.Amount.Compare(sum) < 0 {
+			return errors.New("must be greater than or equal to the sum of documents paid")
+		}
+		return nil
+	}
+}
+
+func validatePaymentsDocumentCurrency(pl *PaymentsLine) func(any) error {
+	return func(value any) error {
+		pd, ok := value.(*PaymentsDocument)
+		if !ok || pd == nil {
+			return nil
+		}
+		return validation.ValidateStruct(pd,
+			validation.Field(&pd.ExchangeRate,
+				validation.When(
+					pd.Currency != pl.Currency,
+					validation.Required,
+				),
+				validation.When(
+					pd.Currency == pl.Currency,
+					validation.By(validatePaymentsExchangeRateIsOne),
+				),
+			),
+		)
+	}
+}
+
+// Calculate performs the complement's calculations and normalisations.
+func (pc *Payments) Calculate() error {
+	pc.Totals = &PaymentsTotals{
+		Total: num.MakeAmount(0, PaymentsFinalPrecision),
+	}
+
+	for i, l := range pc.Lines {
+		l.Index = i + 1
+		l.calculate()
+		pc.Totals.add(l)
+	}
+
+	return nil
+}
+
+func (pl *PaymentsLine) calculate() {
+	if pl.Currency == currency.MXN && pl.ExchangeRate == nil {
+		pl.ExchangeRate = num.NewAmount(1, 0)
+	}
+	if pl.ExchangeRate != nil {
+		*pl.ExchangeRate = pl.ExchangeRate.RescaleDown(PaymentsExchangeRatePrecision)
+	}
+	pl.Amount = pl.Amount.Rescale(PaymentsFinalPrecision)
+
+	pl.Taxes = nil
+	for _, d := range pl.Documents {
+		if d == nil {
+			continue
+		}
+		if d.Currency == currency.CodeEmpty {
+			d.Currency = pl.Currency
+		}
+		d.calculate(pl.Currency)
+
+		// Summarise taxes in the payment's currency, grouping by
+		// category and percent.
+		for _, dt := range d.Taxes {
+			pt := pl.taxFor(dt)
+			pt.Base = pt.Base.Add(d.toPaymentCurrency(dt.Base))
+			if dt.Amount != nil {
+				a := pt.Amount.Add(d.toPaymentCurrency(*dt.Amount))
+				pt.Amount = &a
+			}
+		}
+	}
+
+	for _, t := range pl.Taxes {
+		t.Base = t.Base.Rescale(PaymentsFinalPrecision)
+		if t.Amount != nil {
+			*t.Amount = t.Amount.Rescale(PaymentsFinalPrecision)
+		}
+	}
+}
+
+// taxFor finds or creates the payment tax summary row that matches
+// the category and percent of the document's tax.
+func (pl *PaymentsLine) taxFor(dt *PaymentsTax) *PaymentsTax {
+	for _, pt := range pl.Taxes {
+		if pt.Category != dt.Category {
+			continue
+		}
+		if pt.Percent == nil && dt.Percent == nil {
+			return pt
+		}
+		if pt.Percent != nil && dt.Percent != nil && pt.Percent.Equals(*dt.Percent) {
+			return pt
+		}
+	}
+	pt := &PaymentsTax{
+		Category: dt.Category,
+		Base:     num.MakeAmount(0, PaymentsExchangeRatePrecision),
+		Percent:  dt.Percent,
+	}
+	if dt.Percent != nil {
+		pt.Amount = num.NewAmount(0, PaymentsExchangeRatePrecision)
+	}
+	pl.Taxes = append(pl.Taxes, pt)
+	return pt
+}
+
+func (pd *PaymentsDocument) calculate(cur currency.Code) {
+	if pd.Currency == cur && pd.ExchangeRate == nil {
+		pd.ExchangeRate = num.NewAmount(1, 0)
+	}
+	if pd.ExchangeRate != nil {
+		*pd.ExchangeRate = pd.ExchangeRate.RescaleDown(PaymentsExchangeRatePrecision)
+	}
+	pd.PreviousBalance = pd.PreviousBalance.Rescale(PaymentsFinalPrecision)
+	pd.Paid = pd.Paid.Rescale(PaymentsFinalPrecision)
+	pd.RemainingBalance = pd.PreviousBalance.Subtract(pd.Paid)
+
+	for _, t := range pd.Taxes {
+		t.Base = t.Base.Rescale(PaymentsFinalPrecision)
+		t.Amount = nil
+		if t.Percent != nil {
+			a := t.Percent.Of(t.Base).Rescale(PaymentsFinalPrecision)
+			t.Amount = &a
+		}
+	}
+}
+
+// toPaymentCurrency converts an amount in the document's currency into the
+// payment's currency using the document's exchange rate.
+func (pd *PaymentsDocument) toPaymentCurrency(a num.Amount) num.Amount {
+	a = a.RescaleUp(PaymentsExchangeRatePrecision)
+	if pd.ExchangeRate == nil || pd.ExchangeRate.IsZero() {
+		return a
+	}
+	return a.Divide(*pd.ExchangeRate)
+}
+
+func (pd *PaymentsDocument) paidInPaymentCurrency() num.Amount {
+	return pd.toPaymentCurrency(pd.Paid).Rescale(PaymentsFinalPrecision)
+}
+
+// add includes the line's amounts, converted to MXN, in the totals.
+func (pt *PaymentsTotals) add(pl *PaymentsLine) {
+	rate := num.MakeAmount(1, 0)
+	if pl.ExchangeRate != nil {
+		rate = *pl.ExchangeRate
+	}
+	toMXN := func(a num.Amount) num.Amount {
+		return a.Multiply(rate).Rescale(PaymentsFinalPrecision)
+	}
+
+	pt.Total = pt.Total.Add(toMXN(pl.Amount))
+
+	for _, t := range pl.Taxes {
+		amount := num.MakeAmount(0, PaymentsFinalPrecision)
+		if t.Amount != nil {
+			amount = *t.Amount
+		}
+		switch t.Category {
+		case mx.TaxCategoryRVAT:
+			pt.RetainedVAT = addPaymentsTotal(pt.RetainedVAT, toMXN(amount))
+		case mx.TaxCategoryISR:
+			pt.RetainedISR = addPaymentsTotal(pt.RetainedISR, toMXN(amount))
+		case mx.TaxCategoryRIEPS:
+			pt.RetainedIEPS = addPaymentsTotal(pt.RetainedIEPS, toMXN(amount))
+		case tax.CategoryVAT:
+			switch {
+			case t.Percent == nil:
+				pt.VATExemptBase = addPaymentsTotal(pt.VATExemptBase, toMXN(t.Base))
+			case t.Percent.Equals(num.MakePercentage(160, 3)):
+				pt.VAT16Base = addPaymentsTotal(pt.VAT16Base, toMXN(t.Base))
+				pt.VAT16Amount = addPaymentsTotal(pt.VAT16Amount, toMXN(amount))
+			case t.Percent.Equals(num.MakePercentage(80, 3)):
+				pt.VAT8Base = addPaymentsTotal(pt.VAT8Base, toMXN(t.Base))
+				pt.VAT8Amount = addPaymentsTotal(pt.VAT8Amount, toMXN(amount))
+			case t.Percent.IsZero():
+				pt.VAT0Base = addPaymentsTotal(pt.VAT0Base, toMXN(t.Base))
+				pt.VAT0Amount = addPaymentsTotal(pt.VAT0Amount, toMXN(amount))
+			}
+		}
+	}
+}
+
+func addPaymentsTotal(total *num.Amount, a num.Amount) *num.Amount {
+	if total == nil {
+		return &a
+	}
+	sum := total.Add(a)
+	return &sum
+}
@@ -0,0 +1,240 @@
+package cfdi_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/addons/mx/cfdi"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/regimes/mx"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validPayments() *cfdi.Payments {
+	return &cfdi.Payments{
+		Lines: []*cfdi.PaymentsLine{
+			{
+				Date:     cal.MakeDateTime(2024, 3, 15, 12, 0, 0),
+				Means:    "03",
+				Currency: currency.MXN,
+				Amount:   num.MakeAmount(116000, 2),
+				Documents: []*cfdi.PaymentsDocument{
+					{
+						UUID:            "1fac4464-1111-0000-1111-cd37179db12e",
+						Series:          "TEST",
+						Code:            "00001",
+						Installment:     1,
+						PreviousBalance: num.MakeAmount(232000, 2),
+						Paid:            num.MakeAmount(116000, 2),
+						TaxObject:       "02",
+						Taxes: []*cfdi.PaymentsTax{
+							{
+								Category: tax.CategoryVAT,
+								Base:     num.MakeAmount(100000, 2),
+								Percent:  num.NewPercentage(160, 3),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidPayments(t *testing.T) {
+	pc := validPayments()
+	require.NoError(t, pc.Calculate())
+	assert.NoError(t, pc.Validate())
+}
+
+func TestInvalidPayments(t *testing.T) {
+	pc := &cfdi.Payments{}
+
+	err := pc.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "totals: cannot be blank")
+	assert.Contains(t, err.Error(), "lines: cannot be blank")
+}
+
+func TestInvalidPaymentsLine(t *testing.T) {
+	pc := &cfdi.Payments{Lines: []*cfdi.PaymentsLine{{}}}
+
+	err := pc.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "date: required")
+	assert.Contains(t, err.Error(), "means: cannot be blank")
+	assert.Contains(t, err.Error(), "currency: cannot be blank")
+	assert.Contains(t, err.Error(), "documents: cannot be blank")
+
+	pc = validPayments()
+	pc.Lines[0].Means = "99"
+	pc.Lines[0].PayerAccount = "123"
+	require.NoError(t, pc.Calculate())
+
+	err = pc.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "means: must be a defined payment means")
+	assert.Contains(t, err.Error(), "payer_account: the length must be between 10 and 50")
+
+	pc.Lines[0].Means = "ZZ"
+	assert.ErrorContains(t, pc.Validate(), "means: must be a valid value")
+}
+
+func TestInvalidPaymentsLineAmount(t *testing.T) {
+	pc := validPayments()
+	pc.Lines[0].Amount = num.MakeAmount(100000, 2)
+	require.NoError(t, pc.Calculate())
+
+	err := pc.Validate()
+
+	assert.ErrorContains(t, err, "amount: must be greater than or equal to the sum of documents paid")
+}
+
+func TestInvalidPaymentsExchangeRates(t *testing.T) {
+	pc := validPayments()
+	pc.Lines[0].Currency = currency.USD
+	pc.Lines[0].Documents[0].Currency = currency.MXN
+	require.NoError(t, pc.Calculate())
+
+	err := pc.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lines: (0: (documents: (0: (exchange_rate: cannot be blank.).); exchange_rate: cannot be blank.).)")
+
+	pc = validPayments()
+	pc.Lines[0].ExchangeRate = num.NewAmount(175, 1)
+	require.NoError(t, pc.Calculate())
+
+	err = pc.Validate()
+
+	assert.ErrorContains(t, err, "exchange_rate: must be 1 when no conversion is required")
+}
+
+func TestInvalidPaymentsDocument(t *testing.T) {
+	pc := validPayments()
+	pc.Lines[0].Documents[0] = &cfdi.PaymentsDocument{}
+	require.NoError(t, pc.Calculate())
+
+	err := pc.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "uuid: cannot be blank")
+	assert.Contains(t, err.Error(), "installment: cannot be blank")
+	assert.Contains(t, err.Error(), "tax_object: cannot be blank")
+
+	pc = validPayments()
+	pc.Lines[0].Documents[0].Paid = num.MakeAmount(300000, 2)
+	pc.Lines[0].Amount = num.MakeAmount(300000, 2)
+	require.NoError(t, pc.Calculate())
+
+	err = pc.Validate()
+
+	assert.ErrorContains(t, err, "remaining_balance: must be no less than 0")
+
+	pc = validPayments()
+	pc.Lines[0].Documents[0].TaxObject = "01"
+	require.NoError(t, pc.Calculate())
+
+	err = pc.Validate()
+
+	assert.ErrorContains(t, err, "taxes: must be blank")
+
+	pc = validPayments()
+	pc.Lines[0].Documents[0].Taxes = nil
+	require.NoError(t, pc.Calculate())
+
+	err = pc.Validate()
+
+	assert.ErrorContains(t, err, "taxes: cannot be blank")
+}
+
+func TestInvalidPaymentsTax(t *testing.T) {
+	pc := validPayments()
+	pc.Lines[0].Documents[0].Taxes = []*cfdi.PaymentsTax{
+		{Category: "IVA", Base: num.MakeAmount(100, 0)},
+		{Category: mx.TaxCategoryISR, Base: num.MakeAmount(100, 0)},
+	}
+	require.NoError(t, pc.Calculate())
+
+	err := pc.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cat: must be a valid value")
+	assert.Contains(t, err.Error(), "percent: cannot be blank")
+}
+
+func TestCalculatePayments(t *testing.T) {
+	pc := validPayments()
+	pc.Lines[0].Documents[0].Taxes = append(pc.Lines[0].Documents[0].Taxes,
+		&cfdi.PaymentsTax{
+			Category: mx.TaxCategoryRVAT,
+			Base:     num.MakeAmount(100000, 2),
+			Percent:  num.NewPercentage(106667, 6),
+		},
+	)
+
+	require.NoError(t, pc.Calculate())
+
+	l := pc.Lines[0]
+	assert.Equal(t, 1, l.Index)
+	assert.Equal(t, "1", l.ExchangeRate.String())
+	d := l.Documents[0]
+	assert.Equal(t, currency.MXN, d.Currency)
+	assert.Equal(t, "1", d.ExchangeRate.String())
+	assert.Equal(t, "1160.00", d.RemainingBalance.String())
+	assert.Equal(t, "160.00", d.Taxes[0].Amount.String())
+	assert.Equal(t, "106.67", d.Taxes[1].Amount.String())
+
+	require.Len(t, l.Taxes, 2)
+	assert.Equal(t, "1000.00", l.Taxes[0].Base.String())
+	assert.Equal(t, "160.00", l.Taxes[0].Amount.String())
+	assert.Equal(t, "106.67", l.Taxes[1].Amount.String())
+
+	assert.Equal(t, "1160.00", pc.Totals.Total.String())
+	assert.Equal(t, "1000.00", pc.Totals.VAT16Base.String())
+	assert.Equal(t, "160.00", pc.Totals.VAT16Amount.String())
+	assert.Equal(t, "106.67", pc.Totals.RetainedVAT.String())
+	assert.Nil(t, pc.Totals.VAT8Base)
+}
+
+func TestCalculatePaymentsForeignCurrency(t *testing.T) {
+	pc := validPayments()
+	l := pc.Lines[0]
+	l.Currency = currency.USD
+	l.ExchangeRate = num.NewAmount(175, 1)
+	l.Amount = num.MakeAmount(100, 0)
+	d := l.Documents[0]
+	d.Currency = currency.MXN
+	d.ExchangeRate = num.NewAmount(175, 1)
+	d.Paid = num.MakeAmount(175000, 2)
+	d.Taxes[0].Base = num.MakeAmount(150862, 2)
+	d.Taxes = append(d.Taxes, &cfdi.PaymentsTax{
+		Category: tax.CategoryVAT,
+		Base:     num.MakeAmount(1000, 2),
+	})
+
+	require.NoError(t, pc.Calculate())
+	assert.NoError(t, pc.Validate())
+
+	assert.Equal(t, "100.00", l.Amount.String())
+	assert.Equal(t, "570.00", d.RemainingBalance.String())
+	assert.Equal(t, "241.38", d.Taxes[0].Amount.String())
+	assert.Nil(t, d.Taxes[1].Amount)
+
+	require.Len(t, l.Taxes, 2)
+	assert.Equal(t, "86.21", l.Taxes[0].Base.String())
+	assert.Equal(t, "13.79", l.Taxes[0].Amount.String())
+	assert.Equal(t, "0.57", l.Taxes[1].Base.String())
+	assert.Nil(t, l.Taxes[1].Amount)
+
+	assert.Equal(t, "1750.00", pc.Totals.Total.String())
+	assert.Equal(t, "1508.68", pc.Totals.VAT16Base.String())
+	assert.Equal(t, "241.33", pc.Totals.VAT16Amount.String())
+	assert.Equal(t, "9.98", pc.Totals.VATExemptBase.String())
+}
@@ -3,9 +3,30 @@ package cfdi
 import (
 	"github.com/invopop/gobl/bill"
 	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
 	"github.com/invopop/gobl/tax"
 )
 
+// Invoice tags specific to the CFDI addon
+const (
+	// TagTransport is used to issue a "Comprobante de Traslado" (type "T")
+	// that supports the movement of goods without a sale.
+	TagTransport cbc.Key = "transport"
+)
+
+var invoiceTags = &tax.TagSet{
+	Schema: bill.ShortSchemaInvoice,
+	List: []*cbc.Definition{
+		{
+			Key: TagTransport,
+			Name: i18n.String{
+				i18n.EN: "Transport",
+				i18n.ES: "Traslado",
+			},
+		},
+	},
+}
+
 var scenarios = []*tax.ScenarioSet{
 	invoiceScenarios,
 }
@@ -20,6 +41,13 @@ var invoiceScenarios = &tax.ScenarioSet{
 				ExtKeyDocType: "I",
 			},
 		},
+		{
+			Types: []cbc.Key{bill.InvoiceTypeStandard},
+			Tags:  []cbc.Key{TagTransport},
+			Ext: tax.Extensions{
+				ExtKeyDocType: "T",
+			},
+		},
 		{
 			Types: []cbc.Key{bill.InvoiceTypeCreditNote},
 			Ext: tax.Extensions{
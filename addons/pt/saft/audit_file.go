@@ -0,0 +1,202 @@
+package saft
+
+import (
+	"encoding/xml"
+
+	"github.com/invopop/gobl/num"
+)
+
+// SAF-T (PT) audit file constants
+const (
+	AuditFileNamespace = "urn:OECD:StandardAuditFile-Tax:PT_1.04_01"
+	AuditFileVersion   = "1.04_01"
+)
+
+// AuditFile is the root of the SAF-T (PT) file used to export billing data
+// to the Portuguese tax authority (AT). Only the structures required for
+// invoicing software (tax accounting basis "F") are defined. Element names
+// follow the official XSD.
+type AuditFile struct {
+	XMLName         xml.Name         `xml:"AuditFile"`
+	Namespace       string           `xml:"xmlns,attr"`
+	Header          *Header          `xml:"Header"`
+	MasterFiles     *MasterFiles     `xml:"MasterFiles"`
+	SourceDocuments *SourceDocuments `xml:"SourceDocuments,omitempty"`
+}
+
+// Header contains the details of the company and the software that produced
+// the file.
+type Header struct {
+	AuditFileVersion          string   `xml:"AuditFileVersion"`
+	CompanyID                 string   `xml:"CompanyID"`
+	TaxRegistrationNumber     string   `xml:"TaxRegistrationNumber"`
+	TaxAccountingBasis        string   `xml:"TaxAccountingBasis"`
+	CompanyName               string   `xml:"CompanyName"`
+	BusinessName              string   `xml:"BusinessName,omitempty"`
+	CompanyAddress            *Address `xml:"CompanyAddress"`
+	FiscalYear                int      `xml:"FiscalYear"`
+	StartDate                 string   `xml:"StartDate"`
+	EndDate                   string   `xml:"EndDate"`
+	CurrencyCode              string   `xml:"CurrencyCode"`
+	DateCreated               string   `xml:"DateCreated"`
+	TaxEntity                 string   `xml:"TaxEntity"`
+	ProductCompanyTaxID       string   `xml:"ProductCompanyTaxID"`
+	SoftwareCertificateNumber string   `xml:"SoftwareCertificateNumber"`
+	ProductID                 string   `xml:"ProductID"`
+	ProductVersion            string   `xml:"ProductVersion"`
+}
+
+// Address is used for the company and customer addresses.
+type Address struct {
+	BuildingNumber string `xml:"BuildingNumber,omitempty"`
+	StreetName     string `xml:"StreetName,omitempty"`
+	AddressDetail  string `xml:"AddressDetail"`
+	City           string `xml:"City"`
+	PostalCode     string `xml:"PostalCode"`
+	Region         string `xml:"Region,omitempty"`
+	Country        string `xml:"Country"`
+}
+
+// MasterFiles contains the customers, products and tax rates referenced by
+// the source documents.
+type MasterFiles struct {
+	Customer []*Customer `xml:"Customer"`
+	Product  []*Product  `xml:"Product"`
+	TaxTable *TaxTable   `xml:"TaxTable,omitempty"`
+}
+
+// Customer contains the details of a customer.
+type Customer struct {
+	CustomerID           string   `xml:"CustomerID"`
+	AccountID            string   `xml:"AccountID"`
+	CustomerTaxID        string   `xml:"CustomerTaxID"`
+	CompanyName          string   `xml:"CompanyName"`
+	BillingAddress       *Address `xml:"BillingAddress"`
+	SelfBillingIndicator int      `xml:"SelfBillingIndicator"`
+}
+
+// Product contains the details of a product or service.
+type Product struct {
+	ProductType        string `xml:"ProductType"`
+	ProductCode        string `xml:"ProductCode"`
+	ProductDescription string `xml:"ProductDescription"`
+	ProductNumberCode  string `xml:"ProductNumberCode"`
+}
+
+// TaxTable lists the tax rates used in the source documents.
+type TaxTable struct {
+	TaxTableEntry []*TaxTableEntry `xml:"TaxTableEntry"`
+}
+
+// TaxTableEntry defines a single tax rate.
+type TaxTableEntry struct {
+	TaxType          string      `xml:"TaxType"`
+	TaxCountryRegion string      `xml:"TaxCountryRegion"`
+	TaxCode          string      `xml:"TaxCode"`
+	Description      string      `xml:"Description"`
+	TaxPercentage    *num.Amount `xml:"TaxPercentage,omitempty"`
+}
+
+// SourceDocuments contains the documents issued during the period.
+type SourceDocuments struct {
+	SalesInvoices *SalesInvoices `xml:"SalesInvoices"`
+}
+
+// SalesInvoices contains the invoices, debit and credit notes issued.
+type SalesInvoices struct {
+	NumberOfEntries int        `xml:"NumberOfEntries"`
+	TotalDebit      num.Amount `xml:"TotalDebit"`
+	TotalCredit     num.Amount `xml:"TotalCredit"`
+	Invoice         []*Invoice `xml:"Invoice"`
+}
+
+// Invoice represents a sales document.
+type Invoice struct {
+	InvoiceNo       string          `xml:"InvoiceNo"`
+	ATCUD           string          `xml:"ATCUD"`
+	DocumentStatus  *DocumentStatus `xml:"DocumentStatus"`
+	Hash            string          `xml:"Hash"`
+	HashControl     string          `xml:"HashControl"`
+	Period          int             `xml:"Period"`
+	InvoiceDate     string          `xml:"InvoiceDate"`
+	InvoiceType     string          `xml:"InvoiceType"`
+	SpecialRegimes  *SpecialRegimes `xml:"SpecialRegimes"`
+	SourceID        string          `xml:"SourceID"`
+	SystemEntryDate string          `xml:"SystemEntryDate"`
+	CustomerID      string          `xml:"CustomerID"`
+	Line            []*Line         `xml:"Line"`
+	DocumentTotals  *DocumentTotals `xml:"DocumentTotals"`
+}
+
+// DocumentStatus describes the current state of the document.
+type DocumentStatus struct {
+	InvoiceStatus     string `xml:"InvoiceStatus"`
+	InvoiceStatusDate string `xml:"InvoiceStatusDate"`
+	SourceID          string `xml:"SourceID"`
+	SourceBilling     string `xml:"SourceBilling"`
+}
+
+// SpecialRegimes indicates the special regimes applied to the document.
+type SpecialRegimes struct {
+	SelfBillingIndicator         int `xml:"SelfBillingIndicator"`
+	CashVATSchemeIndicator       int `xml:"CashVATSchemeIndicator"`
+	ThirdPartiesBillingIndicator int `xml:"ThirdPartiesBillingIndicator"`
+}
+
+// Line represents a document line.
+type Line struct {
+	LineNumber         int         `xml:"LineNumber"`
+	ProductCode        string      `xml:"ProductCode"`
+	ProductDescription string      `xml:"ProductDescription"`
+	Quantity           num.Amount  `xml:"Quantity"`
+	UnitOfMeasure      string      `xml:"UnitOfMeasure"`
+	UnitPrice          num.Amount  `xml:"UnitPrice"`
+	TaxPointDate       string      `xml:"TaxPointDate"`
+	References         *References `xml:"References,omitempty"`
+	Description        string      `xml:"Description"`
+	DebitAmount        *num.Amount `xml:"DebitAmount,omitempty"`
+	CreditAmount       *num.Amount `xml:"CreditAmount,omitempty"`
+	Tax                *LineTax    `xml:"Tax"`
+	TaxExemptionReason string      `xml:"TaxExemptionReason,omitempty"`
+	TaxExemptionCode   string      `xml:"TaxExemptionCode,omitempty"`
+	SettlementAmount   *num.Amount `xml:"SettlementAmount,omitempty"`
+}
+
+// References points to the document being corrected by a credit or debit note.
+type References struct {
+	Reference string `xml:"Reference"`
+	Reason    string `xml:"Reason,omitempty"`
+}
+
+// LineTax contains the tax applied to a line.
+type LineTax struct {
+	TaxType          string      `xml:"TaxType"`
+	TaxCountryRegion string      `xml:"TaxCountryRegion"`
+	TaxCode          string      `xml:"TaxCode"`
+	TaxPercentage    *num.Amount `xml:"TaxPercentage,omitempty"`
+}
+
+// DocumentTotals contains the totals of the document.
+type DocumentTotals struct {
+	TaxPayable num.Amount `xml:"TaxPayable"`
+	NetTotal   num.Amount `xml:"NetTotal"`
+	GrossTotal num.Amount `xml:"GrossTotal"`
+	Currency   *Currency  `xml:"Currency,omitempty"`
+}
+
+// Currency is used for documents issued in a currency other than euros.
+type Currency struct {
+	CurrencyCode   string     `xml:"CurrencyCode"`
+	CurrencyAmount num.Amount `xml:"CurrencyAmount"`
+	ExchangeRate   num.Amount `xml:"ExchangeRate"`
+}
+
+// Bytes provides the XML representation of the audit file including the
+// XML declaration.
+func (af *AuditFile) Bytes() ([]byte, error) {
+	data, err := xml.MarshalIndent(af, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,616 @@
+package saft
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/head"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/pt"
+	"github.com/invopop/gobl/tax"
+)
+
+const (
+	unknown            = "Desconhecido"
+	finalConsumerID    = "999999990"
+	finalConsumerName  = "Consumidor final"
+	defaultSourceID    = "1"
+	defaultHashControl = "1"
+)
+
+// Document contains an invoice to include in the audit file alongside the
+// stamps from its envelope's header.
+type Document struct {
+	Invoice *bill.Invoice
+	Stamps  []*head.Stamp
+	// EntryTime is the date and time the document was recorded in the
+	// system. If empty, the start of the issue date will be used.
+	EntryTime *cal.DateTime
+}
+
+// Software identifies the certified invoicing software that produced the
+// documents.
+type Software struct {
+	// CompanyTaxID is the NIF of the software producer.
+	CompanyTaxID string
+	// CertificateNumber assigned by the AT to the software.
+	CertificateNumber string
+	// ProductID in the "Product name/Company name" format.
+	ProductID string
+	// ProductVersion of the software.
+	ProductVersion string
+}
+
+// ExportOptions define the company, period and software used to build the
+// audit file.
+type ExportOptions struct {
+	// TaxID of the company whose documents should be exported.
+	TaxID *tax.Identity
+	// Period covered by the file, usually a month or a fiscal year.
+	Period cal.Period
+	// Software details for the header.
+	Software Software
+	// Created date of the file, today if empty.
+	Created cal.Date
+	// Key is the software producer's private key, used to sign the hash of
+	// documents that do not include an "at-hash" stamp.
+	Key *rsa.PrivateKey
+	// KeyVersion of the private key used to sign hashes, "1" by default.
+	KeyVersion string
+	// PreviousHashes contains the hash of the last document issued before
+	// the period for each series, identified by the invoice type and series,
+	// e.g. "FT SERIES-A".
+	PreviousHashes map[string]string
+	// SeriesCodes contains the validation codes assigned by the AT to each
+	// series, identified in the same way as the previous hashes, and used to
+	// build the ATCUD of documents without an "at-atcud" stamp.
+	SeriesCodes map[string]string
+}
+
+// NewAuditFile builds the SAF-T (PT) audit file with the invoices issued by the
+// company identified in the options during the period. Invoices from other
+// suppliers or issued outside of the period are ignored. Amounts in other
+// currencies are converted into euros using the invoice's exchange rates.
+//
+// Documents are sorted by type, series and code, and the hash of each is taken
+// from the "at-hash" stamp, or signed with the key provided in the options and
+// chained to the previous document of the same series.
+func NewAuditFile(opts *ExportOptions, docs []*Document) (*AuditFile, error) {
+	if opts == nil || opts.TaxID == nil || opts.TaxID.Code == "" {
+		return nil, errors.New("tax ID required")
+	}
+	if err := opts.Period.Validate(); err != nil {
+		return nil, fmt.Errorf("period: %w", err)
+	}
+	e := &exporter{
+		opts:      opts,
+		customers: make(map[string]*Customer),
+		products:  make(map[string]*Product),
+		taxes:     make(map[string]*TaxTableEntry),
+		sales: &SalesInvoices{
+			TotalDebit:  currency.EUR.Def().Zero(),
+			TotalCredit: currency.EUR.Def().Zero(),
+		},
+	}
+	var supplier *org.Party
+	for _, doc := range docs {
+		inv := doc.Invoice
+		if inv == nil || !e.includes(inv) {
+			continue
+		}
+		if supplier == nil {
+			supplier = inv.Supplier
+		}
+		if err := e.addDocument(doc); err != nil {
+			return nil, fmt.Errorf("invoice %s: %w", invoiceCode(inv), err)
+		}
+	}
+	if supplier == nil {
+		return nil, errors.New("no invoices found for tax ID in period")
+	}
+	if err := e.chainHashes(); err != nil {
+		return nil, err
+	}
+	return &AuditFile{
+		Namespace:   AuditFileNamespace,
+		Header:      e.header(supplier),
+		MasterFiles: e.masterFiles(),
+		SourceDocuments: &SourceDocuments{
+			SalesInvoices: e.sales,
+		},
+	}, nil
+}
+
+type exporter struct {
+	opts      *ExportOptions
+	customers map[string]*Customer
+	products  map[string]*Product
+	taxes     map[string]*TaxTableEntry
+	sales     *SalesInvoices
+	entries   []*entry
+}
+
+// entry keeps the details needed to calculate the hash chain
+type entry struct {
+	series string
+	code   int
+	inv    *Invoice
+	hash   bool // hash already provided
+}
+
+func (e *exporter) includes(inv *bill.Invoice) bool {
+	if inv.IssueDate.Before(e.opts.Period.Start.Date) || inv.IssueDate.After(e.opts.Period.End.Date) {
+		return false
+	}
+	if inv.Supplier == nil || inv.Supplier.TaxID == nil {
+		return false
+	}
+	tID := inv.Supplier.TaxID
+	return tID.Country == e.opts.TaxID.Country && tID.Code == e.opts.TaxID.Code
+}
+
+func (e *exporter) addDocument(doc *Document) error {
+	orig := doc.Invoice
+	inv, err := orig.ConvertInto(currency.EUR)
+	if err != nil {
+		return err
+	}
+	if len(inv.Discounts) > 0 || len(inv.Charges) > 0 {
+		return errors.New("document discounts and charges not supported")
+	}
+	var it cbc.Code
+	if inv.Tax != nil {
+		it = inv.Tax.Ext.Get(ExtKeyInvoiceType)
+	}
+	if it == cbc.CodeEmpty {
+		return errors.New("invoice type extension missing")
+	}
+	ser, code, err := seriesAndNumber(inv)
+	if err != nil {
+		return err
+	}
+
+	series := fmt.Sprintf("%s %s", it, ser)
+	entryTime := systemEntryTime(doc.EntryTime, inv.IssueDate)
+	doc2 := &Invoice{
+		InvoiceNo: fmt.Sprintf("%s/%d", series, code),
+		DocumentStatus: &DocumentStatus{
+			InvoiceStatus:     "N",
+			InvoiceStatusDate: entryTime,
+			SourceID:          defaultSourceID,
+			SourceBilling:     "P",
+		},
+		HashControl:     e.hashControl(),
+		Period:          int(inv.IssueDate.Month),
+		InvoiceDate:     inv.IssueDate.String(),
+		InvoiceType:     it.String(),
+		SpecialRegimes:  specialRegimes(inv),
+		SourceID:        defaultSourceID,
+		SystemEntryDate: entryTime,
+		CustomerID:      e.addCustomer(inv.Customer),
+		DocumentTotals: &DocumentTotals{
+			TaxPayable: inv.Totals.Tax,
+			NetTotal:   inv.Totals.Total,
+			GrossTotal: inv.Totals.TotalWithTax,
+		},
+	}
+	if orig.Currency != currency.EUR {
+		doc2.DocumentTotals.Currency = &Currency{
+			CurrencyCode:   orig.Currency.String(),
+			CurrencyAmount: orig.Totals.TotalWithTax,
+			ExchangeRate:   exchangeRate(orig),
+		}
+	}
+
+	atcud, err := e.atcud(doc.Stamps, series, code)
+	if err != nil {
+		return err
+	}
+	doc2.ATCUD = atcud
+
+	debit := inv.Type == bill.InvoiceTypeCreditNote
+	refs := references(inv)
+	for _, l := range inv.Lines {
+		line, err := e.line(inv, l, debit)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", l.Index, err)
+		}
+		line.References = refs
+		doc2.Line = append(doc2.Line, line)
+	}
+
+	if debit {
+		e.sales.TotalDebit = e.sales.TotalDebit.Add(inv.Totals.Total)
+	} else {
+		e.sales.TotalCredit = e.sales.TotalCredit.Add(inv.Totals.Total)
+	}
+	e.sales.NumberOfEntries++
+
+	en := &entry{series: series, code: code, inv: doc2}
+	if st := head.GetStamp(doc.Stamps, pt.StampProviderATHash); st != nil {
+		doc2.Hash = st.Value
+		en.hash = true
+	}
+	e.entries = append(e.entries, en)
+	return nil
+}
+
+func (e *exporter) line(inv *bill.Invoice, l *bill.Line, debit bool) (*Line, error) {
+	if l.Item == nil {
+		return nil, errors.New("item required")
+	}
+	vat := l.Taxes.Get(tax.CategoryVAT)
+	if vat == nil {
+		return nil, errors.New("VAT tax required")
+	}
+	line := &Line{
+		LineNumber:         l.Index,
+		ProductCode:        e.addProduct(l.Item),
+		ProductDescription: truncate(l.Item.Name, 200),
+		Quantity:           l.Quantity,
+		UnitOfMeasure:      unitOfMeasure(l.Item.Unit),
+		UnitPrice:          l.Item.UnitPrice(),
+		TaxPointDate:       inv.IssueDate.String(),
+		Description:        truncate(l.Item.Name, 200),
+		Tax:                e.addTax(inv, vat),
+	}
+	total := l.Total
+	if debit {
+		line.DebitAmount = &total
+	} else {
+		line.CreditAmount = &total
+	}
+	if code := vat.Ext.Get(ExtKeyExemption); code != cbc.CodeEmpty {
+		line.TaxExemptionCode = code.String()
+		line.TaxExemptionReason = exemptionReason(code)
+	}
+	if len(l.Discounts) > 0 {
+		sa := l.Sum.Subtract(l.Total)
+		line.SettlementAmount = &sa
+	}
+	return line, nil
+}
+
+func (e *exporter) addCustomer(p *org.Party) string {
+	c := &Customer{
+		CustomerID:     finalConsumerID,
+		AccountID:      unknown,
+		CustomerTaxID:  finalConsumerID,
+		CompanyName:    finalConsumerName,
+		BillingAddress: unknownAddress(),
+	}
+	if p != nil && p.TaxID != nil && p.TaxID.Code != cbc.CodeEmpty {
+		c.CustomerID = fmt.Sprintf("%s%s", p.TaxID.Country, p.TaxID.Code)
+		c.CustomerTaxID = p.TaxID.Code.String()
+		c.CompanyName = truncate(p.Name, 100)
+		if len(p.Addresses) > 0 {
+			c.BillingAddress = address(p.Addresses[0], p.TaxID.Country.Code())
+		} else {
+			c.BillingAddress.Country = p.TaxID.Country.Code().String()
+		}
+	}
+	if _, ok := e.customers[c.CustomerID]; !ok {
+		e.customers[c.CustomerID] = c
+	}
+	return c.CustomerID
+}
+
+func (e *exporter) addProduct(item *org.Item) string {
+	code := item.Ref
+	if code == "" {
+		code = truncate(item.Name, 60)
+	}
+	if _, ok := e.products[code]; !ok {
+		e.products[code] = &Product{
+			ProductType:        productType(item),
+			ProductCode:        code,
+			ProductDescription: truncate(item.Name, 200),
+			ProductNumberCode:  code,
+		}
+	}
+	return code
+}
+
+func (e *exporter) addTax(inv *bill.Invoice, c *tax.Combo) *LineTax {
+	lt := &LineTax{
+		TaxType:          "IVA",
+		TaxCountryRegion: taxCountryRegion(c),
+		TaxCode:          c.Ext.Get(ExtKeyTaxRate).String(),
+	}
+	if c.Percent != nil {
+		p := c.Percent.Amount().Rescale(2)
+		lt.TaxPercentage = &p
+	}
+	key := strings.Join([]string{lt.TaxCountryRegion, lt.TaxCode, pointerString(lt.TaxPercentage)}, ":")
+	if _, ok := e.taxes[key]; !ok {
+		e.taxes[key] = &TaxTableEntry{
+			TaxType:          lt.TaxType,
+			TaxCountryRegion: lt.TaxCountryRegion,
+			TaxCode:          lt.TaxCode,
+			Description:      taxDescription(inv, c),
+			TaxPercentage:    lt.TaxPercentage,
+		}
+	}
+	return lt
+}
+
+func (e *exporter) atcud(stamps []*head.Stamp, series string, code int) (string, error) {
+	if st := head.GetStamp(stamps, pt.StampProviderATATCUD); st != nil {
+		return st.Value, nil
+	}
+	if sc, ok := e.opts.SeriesCodes[series]; ok {
+		return fmt.Sprintf("%s-%d", sc, code), nil
+	}
+	return "", fmt.Errorf("ATCUD stamp or validation code for series '%s' required", series)
+}
+
+func (e *exporter) hashControl() string {
+	if e.opts.KeyVersion != "" {
+		return e.opts.KeyVersion
+	}
+	return defaultHashControl
+}
+
+// chainHashes sorts the documents and signs the hashes of those that don't
+// have one yet, using the hash of the previous document in the series.
+func (e *exporter) chainHashes() error {
+	sort.SliceStable(e.entries, func(i, j int) bool {
+		a, b := e.entries[i], e.entries[j]
+		if a.series != b.series {
+			return a.series < b.series
+		}
+		return a.code < b.code
+	})
+	prev := make(map[string]string)
+	for k, v := range e.opts.PreviousHashes {
+		prev[k] = v
+	}
+	for _, en := range e.entries {
+		doc := en.inv
+		if !en.hash {
+			if e.opts.Key == nil {
+				return fmt.Errorf("invoice %s: hash stamp or signing key required", doc.InvoiceNo)
+			}
+			msg := HashMessage(doc.InvoiceDate, doc.SystemEntryDate, doc.InvoiceNo, doc.DocumentTotals.GrossTotal, prev[en.series])
+			hash, err := SignHash(e.opts.Key, msg)
+			if err != nil {
+				return fmt.Errorf("invoice %s: %w", doc.InvoiceNo, err)
+			}
+			doc.Hash = hash
+		}
+		prev[en.series] = doc.Hash
+		e.sales.Invoice = append(e.sales.Invoice, doc)
+	}
+	return nil
+}
+
+func (e *exporter) header(supplier *org.Party) *Header {
+	created := e.opts.Created
+	if created.IsZero() {
+		created = cal.Today()
+	}
+	h := &Header{
+		AuditFileVersion:          AuditFileVersion,
+		CompanyID:                 e.opts.TaxID.Code.String(),
+		TaxRegistrationNumber:     e.opts.TaxID.Code.String(),
+		TaxAccountingBasis:        "F",
+		CompanyName:               truncate(supplier.Name, 100),
+		CompanyAddress:            unknownAddress(),
+		FiscalYear:                e.opts.Period.Start.Year,
+		StartDate:                 e.opts.Period.Start.String(),
+		EndDate:                   e.opts.Period.End.String(),
+		CurrencyCode:              currency.EUR.String(),
+		DateCreated:               created.String(),
+		TaxEntity:                 "Global",
+		ProductCompanyTaxID:       e.opts.Software.CompanyTaxID,
+		SoftwareCertificateNumber: e.opts.Software.CertificateNumber,
+		ProductID:                 e.opts.Software.ProductID,
+		ProductVersion:            e.opts.Software.ProductVersion,
+	}
+	if supplier.Alias != "" {
+		h.BusinessName = truncate(supplier.Alias, 60)
+	}
+	if len(supplier.Addresses) > 0 {
+		h.CompanyAddress = address(supplier.Addresses[0], l10n.PT)
+	}
+	return h
+}
+
+func (e *exporter) masterFiles() *MasterFiles {
+	mf := new(MasterFiles)
+	for _, k := range sortedKeys(e.customers) {
+		mf.Customer = append(mf.Customer, e.customers[k])
+	}
+	for _, k := range sortedKeys(e.products) {
+		mf.Product = append(mf.Product, e.products[k])
+	}
+	if len(e.taxes) > 0 {
+		mf.TaxTable = new(TaxTable)
+		for _, k := range sortedKeys(e.taxes) {
+			mf.TaxTable.TaxTableEntry = append(mf.TaxTable.TaxTableEntry, e.taxes[k])
+		}
+	}
+	return mf
+}
+
+func address(a *org.Address, country l10n.Code) *Address {
+	if a.Country != "" {
+		country = a.Country.Code()
+	}
+	detail := strings.TrimSpace(strings.Join([]string{a.Street, a.Number}, " "))
+	if detail == "" {
+		detail = unknown
+	}
+	ad := &Address{
+		AddressDetail: truncate(detail, 100),
+		City:          orUnknown(a.Locality),
+		PostalCode:    orUnknown(a.Code.String()),
+		Region:        a.Region,
+		Country:       country.String(),
+	}
+	return ad
+}
+
+func unknownAddress() *Address {
+	return &Address{
+		AddressDetail: unknown,
+		City:          unknown,
+		PostalCode:    unknown,
+		Country:       unknown,
+	}
+}
+
+func references(inv *bill.Invoice) *References {
+	if len(inv.Preceding) == 0 {
+		return nil
+	}
+	p := inv.Preceding[0]
+	it := p.Ext.Get(ExtKeyInvoiceType)
+	if it == cbc.CodeEmpty {
+		it = "FT"
+	}
+	ref := fmt.Sprintf("%s %s", it, p.Code)
+	if p.Series != cbc.CodeEmpty {
+		ref = fmt.Sprintf("%s %s/%s", it, p.Series, p.Code)
+	}
+	return &References{
+		Reference: truncate(ref, 60),
+		Reason:    truncate(p.Reason, 50),
+	}
+}
+
+// seriesAndNumber determines the series and sequential number of the invoice
+// from its series and code, or from the code alone when it contains the series
+// followed by a slash, e.g. "SERIES-A/123".
+func seriesAndNumber(inv *bill.Invoice) (string, int, error) {
+	ser := inv.Series.String()
+	code := inv.Code.String()
+	if ser == "" {
+		i := strings.LastIndex(code, "/")
+		if i < 1 {
+			return "", 0, errors.New("series required")
+		}
+		ser, code = code[:i], code[i+1:]
+	}
+	n, err := strconv.Atoi(code)
+	if err != nil || n < 1 {
+		return "", 0, errors.New("code must be a positive sequential number")
+	}
+	return ser, n, nil
+}
+
+func specialRegimes(inv *bill.Invoice) *SpecialRegimes {
+	sr := new(SpecialRegimes)
+	if inv.HasTags(tax.TagSelfBilled) {
+		sr.SelfBillingIndicator = 1
+	}
+	return sr
+}
+
+func systemEntryTime(dt *cal.DateTime, date cal.Date) string {
+	if dt != nil && !dt.IsZero() {
+		return dt.String()
+	}
+	return date.String() + "T00:00:00"
+}
+
+func exchangeRate(inv *bill.Invoice) num.Amount {
+	if er := currency.MatchExchangeRate(inv.ExchangeRates, inv.Currency, currency.EUR); er != nil {
+		return er.Amount
+	}
+	return num.AmountZero
+}
+
+func taxCountryRegion(c *tax.Combo) string {
+	if r := c.Ext.Get(pt.ExtKeyRegion); r != cbc.CodeEmpty {
+		return r.String()
+	}
+	if c.Country != "" {
+		return c.Country.String()
+	}
+	return l10n.PT.String()
+}
+
+func taxDescription(inv *bill.Invoice, c *tax.Combo) string {
+	if rd := inv.RegimeDef(); rd != nil && c.Rate != cbc.KeyEmpty {
+		if r := rd.RateDef(c.Category, c.Rate); r != nil {
+			return r.Name.In(i18n.PT)
+		}
+	}
+	return c.Ext.Get(ExtKeyTaxRate).String()
+}
+
+func exemptionReason(code cbc.Code) string {
+	for _, kd := range extensions {
+		if kd.Key != ExtKeyExemption {
+			continue
+		}
+		if d := kd.CodeDef(code); d != nil {
+			return truncate(d.Name.In(i18n.PT), 60)
+		}
+	}
+	return ""
+}
+
+func productType(item *org.Item) string {
+	if item.Key == "services" {
+		return "S"
+	}
+	return "P"
+}
+
+func unitOfMeasure(u org.Unit) string {
+	if u == org.UnitEmpty {
+		return "UN"
+	}
+	return string(u)
+}
+
+func invoiceCode(inv *bill.Invoice) string {
+	if inv.Series != "" {
+		return fmt.Sprintf("%s-%s", inv.Series, inv.Code)
+	}
+	return inv.Code.String()
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return unknown
+	}
+	return s
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
+
+func pointerString(a *num.Amount) string {
+	if a == nil {
+		return ""
+	}
+	return a.String()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,290 @@
+package saft_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" // nolint:gosec
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/invopop/gobl/addons/pt/saft"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/head"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/pt"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportInvoice(code cbc.Code) *bill.Invoice {
+	return &bill.Invoice{
+		Regime:    tax.WithRegime("PT"),
+		Addons:    tax.WithAddons(saft.V1),
+		Series:    "SERIES-A",
+		Code:      code,
+		Currency:  "EUR",
+		IssueDate: cal.MakeDate(2024, 3, 5),
+		Supplier: &org.Party{
+			Name: "Hotelzinho",
+			TaxID: &tax.Identity{
+				Country: "PT",
+				Code:    "545259045",
+			},
+			Addresses: []*org.Address{
+				{
+					Street:   "Rua do Hotelzinho",
+					Number:   "12",
+					Locality: "Lisboa",
+					Code:     "1000-000",
+				},
+			},
+		},
+		Customer: &org.Party{
+			Name: "Empresa Cliente",
+			TaxID: &tax.Identity{
+				Country: "PT",
+				Code:    "503504564",
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(2, 0),
+				Item: &org.Item{
+					Ref:   "QD",
+					Name:  "Noite em quarto duplo",
+					Price: num.MakeAmount(10000, 2),
+				},
+				Discounts: []*bill.LineDiscount{
+					{
+						Percent: num.NewPercentage(10, 2),
+					},
+				},
+				Taxes: tax.Set{
+					{
+						Category: tax.CategoryVAT,
+						Rate:     tax.RateReduced,
+					},
+				},
+			},
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Livro",
+					Price: num.MakeAmount(2000, 2),
+				},
+				Taxes: tax.Set{
+					{
+						Category: tax.CategoryVAT,
+						Rate:     tax.RateExempt,
+						Ext: tax.Extensions{
+							saft.ExtKeyExemption: "M07",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func exportOptions() *saft.ExportOptions {
+	return &saft.ExportOptions{
+		TaxID: &tax.Identity{
+			Country: "PT",
+			Code:    "545259045",
+		},
+		Period: cal.Period{
+			Start: cal.MakeDate(2024, 3, 1),
+			End:   cal.MakeDate(2024, 3, 31),
+		},
+		Software: saft.Software{
+			CompanyTaxID:      "999999990",
+			CertificateNumber: "0000",
+			ProductID:         "GOBL/Invopop",
+			ProductVersion:    "1.0",
+		},
+		Created: cal.MakeDate(2024, 4, 2),
+	}
+}
+
+func TestNewAuditFile(t *testing.T) {
+	inv := exportInvoice("1")
+	require.NoError(t, inv.Calculate())
+	cn := exportInvoice("1")
+	cn.Type = bill.InvoiceTypeCreditNote
+	cn.IssueDate = cal.MakeDate(2024, 3, 10)
+	cn.Preceding = []*org.DocumentRef{
+		{
+			Series: "SERIES-A",
+			Code:   "1",
+			Reason: "Devolução",
+		},
+	}
+	cn.Lines = cn.Lines[1:]
+	require.NoError(t, cn.Calculate())
+	other := exportInvoice("2")
+	other.IssueDate = cal.MakeDate(2024, 4, 1)
+	require.NoError(t, other.Calculate())
+
+	docs := []*saft.Document{
+		{
+			Invoice: inv,
+			Stamps: []*head.Stamp{
+				{Provider: pt.StampProviderATATCUD, Value: "CSDF7T5H-1"},
+				{Provider: pt.StampProviderATHash, Value: "hash-ft-1"},
+			},
+		},
+		{
+			Invoice: cn,
+			Stamps: []*head.Stamp{
+				{Provider: pt.StampProviderATATCUD, Value: "ABCD1234-1"},
+				{Provider: pt.StampProviderATHash, Value: "hash-nc-1"},
+			},
+		},
+		{Invoice: other},
+	}
+
+	af, err := saft.NewAuditFile(exportOptions(), docs)
+	require.NoError(t, err)
+
+	h := af.Header
+	assert.Equal(t, "545259045", h.TaxRegistrationNumber)
+	assert.Equal(t, "Hotelzinho", h.CompanyName)
+	assert.Equal(t, "Rua do Hotelzinho 12", h.CompanyAddress.AddressDetail)
+	assert.Equal(t, 2024, h.FiscalYear)
+	assert.Equal(t, "2024-03-31", h.EndDate)
+	assert.Equal(t, "2024-04-02", h.DateCreated)
+
+	mf := af.MasterFiles
+	require.Len(t, mf.Customer, 1)
+	assert.Equal(t, "PT503504564", mf.Customer[0].CustomerID)
+	require.Len(t, mf.Product, 2)
+	assert.Equal(t, "Livro", mf.Product[0].ProductCode)
+	assert.Equal(t, "QD", mf.Product[1].ProductCode)
+	require.Len(t, mf.TaxTable.TaxTableEntry, 2)
+	assert.Equal(t, "ISE", mf.TaxTable.TaxTableEntry[0].TaxCode)
+	assert.Equal(t, "RED", mf.TaxTable.TaxTableEntry[1].TaxCode)
+	assert.Equal(t, "6.00", mf.TaxTable.TaxTableEntry[1].TaxPercentage.String())
+
+	si := af.SourceDocuments.SalesInvoices
+	assert.Equal(t, 2, si.NumberOfEntries)
+	assert.Equal(t, "200.00", si.TotalCredit.String())
+	assert.Equal(t, "20.00", si.TotalDebit.String())
+	require.Len(t, si.Invoice, 2)
+
+	ft := si.Invoice[0]
+	assert.Equal(t, "FT SERIES-A/1", ft.InvoiceNo)
+	assert.Equal(t, "CSDF7T5H-1", ft.ATCUD)
+	assert.Equal(t, "hash-ft-1", ft.Hash)
+	assert.Equal(t, 3, ft.Period)
+	assert.Equal(t, "2024-03-05T00:00:00", ft.SystemEntryDate)
+	assert.Equal(t, "210.80", ft.DocumentTotals.GrossTotal.String())
+	require.Len(t, ft.Line, 2)
+	assert.Equal(t, "180.00", ft.Line[0].CreditAmount.String())
+	assert.Equal(t, "20.00", ft.Line[0].SettlementAmount.String())
+	assert.Nil(t, ft.Line[0].DebitAmount)
+	assert.Equal(t, "M07", ft.Line[1].TaxExemptionCode)
+	assert.NotEmpty(t, ft.Line[1].TaxExemptionReason)
+
+	nc := si.Invoice[1]
+	assert.Equal(t, "NC SERIES-A/1", nc.InvoiceNo)
+	require.Len(t, nc.Line, 1)
+	assert.Equal(t, "20.00", nc.Line[0].DebitAmount.String())
+	assert.Equal(t, "FT SERIES-A/1", nc.Line[0].References.Reference)
+
+	data, err := af.Bytes()
+	require.NoError(t, err)
+	out := string(data)
+	assert.True(t, strings.HasPrefix(out, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, out, `<AuditFile xmlns="urn:OECD:StandardAuditFile-Tax:PT_1.04_01">`)
+	assert.Contains(t, out, `<InvoiceNo>FT SERIES-A/1</InvoiceNo>`)
+}
+
+func TestNewAuditFileHashChain(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	var docs []*saft.Document
+	for _, code := range []cbc.Code{"2", "1"} {
+		inv := exportInvoice(code)
+		require.NoError(t, inv.Calculate())
+		docs = append(docs, &saft.Document{Invoice: inv})
+	}
+	opts := exportOptions()
+	opts.Key = key
+	opts.SeriesCodes = map[string]string{"FT SERIES-A": "CSDF7T5H"}
+	opts.PreviousHashes = map[string]string{"FT SERIES-A": "prev-hash"}
+
+	af, err := saft.NewAuditFile(opts, docs)
+	require.NoError(t, err)
+	invs := af.SourceDocuments.SalesInvoices.Invoice
+	require.Len(t, invs, 2)
+	assert.Equal(t, "CSDF7T5H-1", invs[0].ATCUD)
+	assert.Equal(t, "CSDF7T5H-2", invs[1].ATCUD)
+
+	verify := func(hash, msg string) {
+		t.Helper()
+		sig, err := base64.StdEncoding.DecodeString(hash)
+		require.NoError(t, err)
+		sum := sha1.Sum([]byte(msg)) // nolint:gosec
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, sum[:], sig))
+	}
+	verify(invs[0].Hash, "2024-03-05;2024-03-05T00:00:00;FT SERIES-A/1;210.80;prev-hash")
+	verify(invs[1].Hash, "2024-03-05;2024-03-05T00:00:00;FT SERIES-A/2;210.80;"+invs[0].Hash)
+}
+
+func TestNewAuditFileErrors(t *testing.T) {
+	t.Run("missing tax ID", func(t *testing.T) {
+		opts := exportOptions()
+		opts.TaxID = nil
+		_, err := saft.NewAuditFile(opts, nil)
+		assert.EqualError(t, err, "tax ID required")
+	})
+	t.Run("no invoices", func(t *testing.T) {
+		_, err := saft.NewAuditFile(exportOptions(), nil)
+		assert.EqualError(t, err, "no invoices found for tax ID in period")
+	})
+	t.Run("missing ATCUD", func(t *testing.T) {
+		inv := exportInvoice("1")
+		require.NoError(t, inv.Calculate())
+		_, err := saft.NewAuditFile(exportOptions(), []*saft.Document{{Invoice: inv}})
+		assert.EqualError(t, err, "invoice SERIES-A-1: ATCUD stamp or validation code for series 'FT SERIES-A' required")
+	})
+	t.Run("missing hash", func(t *testing.T) {
+		inv := exportInvoice("1")
+		require.NoError(t, inv.Calculate())
+		docs := []*saft.Document{
+			{
+				Invoice: inv,
+				Stamps: []*head.Stamp{
+					{Provider: pt.StampProviderATATCUD, Value: "CSDF7T5H-1"},
+				},
+			},
+		}
+		_, err := saft.NewAuditFile(exportOptions(), docs)
+		assert.EqualError(t, err, "invoice FT SERIES-A/1: hash stamp or signing key required")
+	})
+	t.Run("invalid code", func(t *testing.T) {
+		inv := exportInvoice("A1")
+		require.NoError(t, inv.Calculate())
+		_, err := saft.NewAuditFile(exportOptions(), []*saft.Document{{Invoice: inv}})
+		assert.EqualError(t, err, "invoice SERIES-A-A1: code must be a positive sequential number")
+	})
+	t.Run("series in code", func(t *testing.T) {
+		inv := exportInvoice("SEQ/7")
+		inv.Series = ""
+		require.NoError(t, inv.Calculate())
+		opts := exportOptions()
+		opts.Key, _ = rsa.GenerateKey(rand.Reader, 1024)
+		opts.SeriesCodes = map[string]string{"FT SEQ": "XYZ"}
+		af, err := saft.NewAuditFile(opts, []*saft.Document{{Invoice: inv}})
+		require.NoError(t, err)
+		assert.Equal(t, "FT SEQ/7", af.SourceDocuments.SalesInvoices.Invoice[0].InvoiceNo)
+		assert.Equal(t, "XYZ-7", af.SourceDocuments.SalesInvoices.Invoice[0].ATCUD)
+	})
+}
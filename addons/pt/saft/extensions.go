@@ -1,6 +1,7 @@
 package saft
 
 import (
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/i18n"
 )
@@ -114,6 +115,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Article 16, No. 6 of the VAT code",
 					i18n.PT: "Artigo 16.°, n.° 6 do CIVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-79-C",
+				},
 			},
 			{
 				Code: "M02",
@@ -135,6 +139,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Exempt pursuant to article 14 of the VAT code",
 					i18n.PT: "Isento artigo 14.° do CIVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-G",
+				},
 			},
 			{
 				Code: "M06",
@@ -149,6 +156,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Exempt pursuant to article 9 of the VAT code",
 					i18n.PT: "Isento artigo 9.° do CIVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-132",
+				},
 			},
 			{
 				Code: "M09",
@@ -177,6 +187,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Margin scheme - Travel agencies / Decree-Law No. 221/85 of 3rd July",
 					i18n.PT: "Regime da margem de lucro - Agências de viagens / Decreto-Lei n.° 221/85, de 3 de julho",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-D",
+				},
 			},
 			{
 				Code: "M13",
@@ -184,6 +197,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Margin scheme - Second-hand goods / Decree-Law No. 199/96 of 18th October",
 					i18n.PT: "Regime da margem de lucro - Bens em segunda mão / Decreto-Lei n.° 199/96, de 18 de outubro",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-F",
+				},
 			},
 			{
 				Code: "M14",
@@ -191,6 +207,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Margin scheme - Works of art / Decree-Law No. 199/96 of 18th October",
 					i18n.PT: "Regime da margem de lucro - Objetos de arte / Decreto-Lei n.° 199/96, de 18 de outubro",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-I",
+				},
 			},
 			{
 				Code: "M15",
@@ -198,6 +217,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Margin scheme - Collector’s items and antiques / Decree-Law No. 199/96 of 18th October",
 					i18n.PT: "Regime da margem de lucro - Objetos de coleção e antiguidades / Decreto-Lei n.° 199/96, de 18 de outubro",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-J",
+				},
 			},
 			{
 				Code: "M16",
@@ -205,6 +227,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Exempt pursuant to Article 14 of the RITI",
 					i18n.PT: "Isento artigo 14.° do RITI",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-IC",
+				},
 			},
 			{
 				Code: "M19",
@@ -240,6 +265,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT - reverse charge / Article 2 No. 1 paragraph i) of the VAT code",
 					i18n.PT: "IVA - autoliquidação / Artigo 2.° n.° 1 alínea i) do CIVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "M31",
@@ -247,6 +275,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT - reverse charge / Article 2 No. 1 paragraph j) of the VAT code",
 					i18n.PT: "IVA - autoliquidação / Artigo 2.° n.° 1 alínea j) do CIVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "M32",
@@ -254,6 +285,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT - reverse charge / Article 2 No. 1 paragraph l) of the VAT code",
 					i18n.PT: "IVA - autoliquidação / Artigo 2.° n.° 1 alínea I) do CIVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "M33",
@@ -261,6 +295,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT - reverse charge / Article 2 No. 1 paragraph m) of the VAT code",
 					i18n.PT: "IVA - autoliquidação / Artigo 2.° n.° 1 alínea m) do CIVA",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "M40",
@@ -268,6 +305,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT - reverse charge / Article 6 No. 6 paragraph a) of the VAT code, to the contrary",
 					i18n.PT: "IVA - autoliquidação / Artigo 6.° n.° 6 alínea a) do CIVA, a contrário",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "M41",
@@ -275,6 +315,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT - reverse charge / Article 8 No. 3 of the RITI",
 					i18n.PT: "IVA - autoliquidação / Artigo 8.° n.° 3 do RITI",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "M42",
@@ -282,6 +325,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT - reverse charge / Decree-Law No. 21/2007 of 29 January",
 					i18n.PT: "IVA - autoliquidação / Decreto-Lei n.° 21/2007, de 29 de janeiro",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "M43",
@@ -289,6 +335,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "VAT - reverse charge / Decree-Law No. 362/99 of 16th September",
 					i18n.PT: "IVA - autoliquidação / Decreto-Lei n.° 362/99, de 16 de setembro",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-AE",
+				},
 			},
 			{
 				Code: "M99",
@@ -296,6 +345,9 @@ var extensions = []*cbc.Definition{
 					i18n.EN: "Not subject to tax or not taxed",
 					i18n.PT: "Não sujeito ou não tributado",
 				},
+				Map: cbc.CodeMap{
+					cef.ExtKeyVATEX: "VATEX-EU-O",
+				},
 			},
 		},
 	},
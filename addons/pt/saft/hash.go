@@ -0,0 +1,37 @@
+package saft
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" // nolint:gosec // required by the AT specification
+	"encoding/base64"
+	"strings"
+
+	"github.com/invopop/gobl/num"
+)
+
+// HashMessage provides the text that must be signed in order to calculate
+// a document's hash, chained to the hash of the previous document in the
+// same series.
+func HashMessage(date, entry, invoiceNo string, gross num.Amount, prev string) string {
+	return strings.Join([]string{
+		date,
+		entry,
+		invoiceNo,
+		gross.Rescale(2).String(),
+		prev,
+	}, ";")
+}
+
+// SignHash signs the hash message with the software producer's private key
+// using RSA-SHA1, and returns the base64 encoded signature to use as the
+// document's hash.
+func SignHash(key *rsa.PrivateKey, msg string) (string, error) {
+	sum := sha1.Sum([]byte(msg)) // nolint:gosec
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
@@ -1,6 +1,7 @@
 package saft
 
 import (
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/tax"
 	"github.com/invopop/validation"
 )
@@ -24,6 +25,8 @@ var taxRateMap = tax.Extensions{
 }
 
 func normalizeTaxCombo(combo *tax.Combo) {
+	combo.Ext = cef.NormalizeVATEX(combo.Ext, ExtKeyExemption)
+
 	// copy the SAF-T tax rate code to the line
 	switch combo.Category {
 	case tax.CategoryVAT:
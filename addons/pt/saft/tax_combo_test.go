@@ -5,6 +5,7 @@ import (
 
 	_ "github.com/invopop/gobl"
 	"github.com/invopop/gobl/addons/pt/saft"
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/tax"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +42,30 @@ func TestTaxComboNormalize(t *testing.T) {
 		ad.Normalizer(combo)
 		assert.Equal(t, "NOR", combo.Ext[saft.ExtKeyTaxRate].String())
 	})
+
+	t.Run("exemption with VATEX", func(t *testing.T) {
+		combo := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				saft.ExtKeyExemption: "M16",
+			},
+		}
+		ad.Normalizer(combo)
+		assert.Equal(t, "VATEX-EU-IC", combo.Ext[cef.ExtKeyVATEX].String())
+	})
+
+	t.Run("VATEX with exemption", func(t *testing.T) {
+		combo := &tax.Combo{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateExempt,
+			Ext: tax.Extensions{
+				cef.ExtKeyVATEX: "VATEX-EU-O",
+			},
+		}
+		ad.Normalizer(combo)
+		assert.Equal(t, "M99", combo.Ext[saft.ExtKeyExemption].String())
+	})
 }
 
 func TestTaxRateKeyMap(t *testing.T) {
@@ -148,14 +148,14 @@ func (m *Charge) GetTotal() num.Amount {
 	return m.Amount
 }
 
-func (m *Charge) removeIncludedTaxes(cat cbc.Code) *Charge {
+func (m *Charge) removeIncludedTaxes(cats []cbc.Code) *Charge {
 	accuracy := defaultTaxRemovalAccuracy
-	rate := m.Taxes.Get(cat)
-	if rate == nil || rate.Percent == nil {
+	divisor := includedTaxDivisor(m, cats)
+	if divisor == nil {
 		return m
 	}
 	m2 := *m
-	m2.Amount = m2.Amount.Upscale(accuracy).Remove(*rate.Percent)
+	m2.Amount = m2.Amount.Upscale(accuracy).Divide(*divisor)
 	return &m2
 }
 
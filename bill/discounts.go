@@ -164,14 +164,14 @@ func (m *Discount) GetTotal() num.Amount {
 	return m.Amount.Invert()
 }
 
-func (m *Discount) removeIncludedTaxes(cat cbc.Code) *Discount {
+func (m *Discount) removeIncludedTaxes(cats []cbc.Code) *Discount {
 	accuracy := defaultTaxRemovalAccuracy
-	rate := m.Taxes.Get(cat)
-	if rate == nil || rate.Percent == nil {
+	divisor := includedTaxDivisor(m, cats)
+	if divisor == nil {
 		return m
 	}
 	m2 := *m
-	m2.Amount = m2.Amount.Upscale(accuracy).Remove(*rate.Percent)
+	m2.Amount = m2.Amount.Upscale(accuracy).Divide(*divisor)
 	return &m2
 }
 
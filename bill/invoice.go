@@ -11,6 +11,7 @@ import (
 	"github.com/invopop/gobl/currency"
 	"github.com/invopop/gobl/internal"
 	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/org"
 	"github.com/invopop/gobl/schema"
 	"github.com/invopop/gobl/tax"
@@ -209,6 +210,8 @@ func (inv *Invoice) Invert() error {
 	payable := inv.Totals.Payable.Invert()
 
 	for _, row := range inv.Lines {
+		// sub-lines in the breakdown describe a single unit of the item, so
+		// only the line's quantity needs to be inverted.
 		row.Quantity = row.Quantity.Invert()
 		for _, d := range row.Discounts {
 			d.Amount = d.Amount.Invert()
@@ -332,7 +335,7 @@ func (inv *Invoice) ValidationContext(ctx context.Context) context.Context {
 //
 // A new invoice object is returned, leaving the original instance untouched.
 func (inv *Invoice) RemoveIncludedTaxes() (*Invoice, error) {
-	if inv.Tax == nil || inv.Tax.PricesInclude.IsEmpty() {
+	if inv.Tax == nil || len(inv.Tax.PricesInclude) == 0 {
 		return inv, nil // nothing to do!
 	}
 
@@ -361,7 +364,7 @@ func (inv *Invoice) RemoveIncludedTaxes() (*Invoice, error) {
 	}
 
 	tx := *i2.Tax
-	tx.PricesInclude = ""
+	tx.PricesInclude = nil
 	i2.Tax = &tx
 
 	if err := i2.Calculate(); err != nil {
@@ -380,6 +383,32 @@ func (inv *Invoice) RemoveIncludedTaxes() (*Invoice, error) {
 	return &i2, nil
 }
 
+// applyCashRounding will replace the totals' rounding amount so that the
+// amount to pay, either the due amount after advances or the payable amount,
+// matches the increments of the currency or regime's cash rounding rule, if
+// one applies to the payment means.
+func (inv *Invoice) applyCashRounding(r *tax.RegimeDef, zero num.Amount) {
+	if inv.Payment == nil || inv.Payment.Instructions == nil {
+		return
+	}
+	rr := r.CashRoundingFor(inv.Currency)
+	if !rr.AppliesTo(inv.Payment.Instructions.Key) {
+		return
+	}
+	t := inv.Totals
+	a := t.TotalWithTax
+	if t.Advances != nil {
+		a = a.Subtract(*t.Advances)
+	}
+	a = a.RescaleWith(zero.Exp(), r.RoundingMode())
+	rnd := rr.Round(a).Subtract(a)
+	if rnd.IsZero() {
+		t.Rounding = nil
+		return
+	}
+	t.Rounding = &rnd
+}
+
 // supplierTaxCountry determines the tax country for the invoice based on the supplier tax
 // identity.
 func (inv *Invoice) supplierTaxCountry() l10n.TaxCountryCode {
@@ -450,7 +479,7 @@ func (inv *Invoice) calculate() error {
 	// Build list of taxable lines
 	tls := make([]tax.TaxableLine, 0)
 	for _, l := range inv.Lines {
-		tls = append(tls, l)
+		tls = append(tls, l.TaxableLines()...)
 	}
 	for _, l := range inv.Discounts {
 		tls = append(tls, l)
@@ -460,39 +489,32 @@ func (inv *Invoice) calculate() error {
 	}
 
 	// Now figure out the tax totals
-	var pit cbc.Code
-	if inv.Tax != nil && inv.Tax.PricesInclude != "" {
-		pit = inv.Tax.PricesInclude
-	}
 	t.Taxes = new(tax.Total)
 	tc := &tax.TotalCalculator{
-		Zero:     zero,
-		Country:  inv.Regime.Country,
-		Tags:     inv.GetTags(),
-		Date:     *date,
-		Lines:    tls,
-		Includes: pit,
+		Zero:    zero,
+		Country: inv.Regime.Country,
+		Tags:    inv.GetTags(),
+		Date:    *date,
+		Lines:   tls,
+	}
+	if inv.Tax != nil {
+		tc.Includes = inv.Tax.PricesInclude
+		tc.Rounding = inv.Tax.Rounding
 	}
 	if err := tc.Calculate(t.Taxes); err != nil {
 		return err
 	}
 
 	// Remove any included taxes from the total.
-	ct := t.Taxes.Category(pit)
-	if ct != nil {
-		ti := ct.PreciseAmount()
-		t.TaxIncluded = &ti
-		t.Total = t.Total.Subtract(ti)
+	if ti := t.Taxes.PreciseIncluded(); ti != nil {
+		t.TaxIncluded = ti
+		t.Total = t.Total.Subtract(*ti)
 	}
 
 	// Finally calculate the total with *all* the taxes.
 	t.Tax = t.Taxes.PreciseSum()
 	t.TotalWithTax = t.Total.Add(t.Tax)
 	t.Payable = t.TotalWithTax
-	if t.Rounding != nil {
-		// BT-144 in EN16931
-		t.Payable = t.Payable.Add(*t.Rounding)
-	}
 
 	// Remove taxes object if it doesn't contain any categories
 	if len(t.Taxes.Categories) == 0 {
@@ -501,9 +523,18 @@ func (inv *Invoice) calculate() error {
 
 	if inv.Payment != nil {
 		inv.Payment.calculateAdvances(zero, t.TotalWithTax)
+		t.Advances = inv.Payment.totalAdvance(zero)
+	}
 
+	inv.applyCashRounding(r, zero)
+	if t.Rounding != nil {
+		// BT-144 in EN16931
+		t.Payable = t.Payable.Add(*t.Rounding)
+	}
+
+	if inv.Payment != nil {
 		// Deal with advances, if any
-		if t.Advances = inv.Payment.totalAdvance(zero); t.Advances != nil {
+		if t.Advances != nil {
 			v := t.Payable.Subtract(*t.Advances)
 			t.Due = &v
 		}
@@ -512,7 +543,7 @@ func (inv *Invoice) calculate() error {
 		inv.Payment.Terms.CalculateDues(zero, t.Payable)
 	}
 
-	t.round(zero)
+	t.round(zero, r.RoundingMode())
 
 	// Complements
 	if err := calculateComplements(inv.Complements); err != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/org"
 	"github.com/invopop/gobl/pay"
 )
 
@@ -62,55 +63,71 @@ func (inv *Invoice) convertLines(ex *currency.ExchangeRate) []*Line {
 }
 
 func (l *Line) convertInto(ex *currency.ExchangeRate) *Line {
-	accuracy := defaultCurrencyConversionAccuracy
-
 	l2 := *l
-	l2i := *l.Item
+	l2.Item = convertItemInto(l.Item, ex)
+	if len(l.Breakdown) > 0 {
+		l2.Breakdown = make([]*SubLine, len(l.Breakdown))
+		for i, sl := range l.Breakdown {
+			l2.Breakdown[i] = sl.convertInto(ex)
+		}
+	}
+	l2.Discounts = convertLineDiscountsInto(l.Discounts, ex)
+	l2.Charges = convertLineChargesInto(l.Charges, ex)
+	return &l2
+}
+
+func convertItemInto(item *org.Item, ex *currency.ExchangeRate) *org.Item {
+	accuracy := defaultCurrencyConversionAccuracy
+	i2 := *item
 
 	// Add current price to the list of alternative prices
-	l2i.AltPrices = append(l2i.AltPrices, &currency.Amount{
+	i2.AltPrices = append(i2.AltPrices, &currency.Amount{
 		Currency: ex.From,
-		Value:    l2i.Price,
+		Value:    i2.Price,
 	})
 
 	// Use alt price if available
 	altFound := false
-	for i, ap := range l2i.AltPrices {
+	for i, ap := range i2.AltPrices {
 		if ap.Currency == ex.To {
-			l2i.Price = ap.Value
+			i2.Price = ap.Value
 			// remove this alt price from the list
-			l2i.AltPrices = append(l2i.AltPrices[:i], l2i.AltPrices[i+1:]...)
+			i2.AltPrices = append(i2.AltPrices[:i], i2.AltPrices[i+1:]...)
 			altFound = true
 			break
 		}
 	}
 	if !altFound {
 		// Perform exchange
-		l2i.Price = l2i.Price.Upscale(accuracy).Multiply(ex.Amount)
+		i2.Price = i2.Price.Upscale(accuracy).Multiply(ex.Amount)
 	}
+	return &i2
+}
 
-	if len(l2.Discounts) > 0 {
-		rows := make([]*LineDiscount, len(l2.Discounts))
-		for i, v := range l.Discounts {
-			d := *v
-			d.Amount = d.Amount.Upscale(accuracy).Multiply(ex.Amount)
-			rows[i] = &d
-		}
-		l2.Discounts = rows
+func convertLineDiscountsInto(discounts []*LineDiscount, ex *currency.ExchangeRate) []*LineDiscount {
+	if len(discounts) == 0 {
+		return discounts
 	}
-
-	if len(l2.Charges) > 0 {
-		rows := make([]*LineCharge, len(l2.Charges))
-		for i, v := range l.Charges {
-			d := *v
-			d.Amount = d.Amount.Upscale(accuracy).Multiply(ex.Amount)
-			rows[i] = &d
-		}
-		l2.Charges = rows
+	rows := make([]*LineDiscount, len(discounts))
+	for i, v := range discounts {
+		d := *v
+		d.Amount = d.Amount.Upscale(defaultCurrencyConversionAccuracy).Multiply(ex.Amount)
+		rows[i] = &d
 	}
+	return rows
+}
 
-	l2.Item = &l2i
-	return &l2
+func convertLineChargesInto(charges []*LineCharge, ex *currency.ExchangeRate) []*LineCharge {
+	if len(charges) == 0 {
+		return charges
+	}
+	rows := make([]*LineCharge, len(charges))
+	for i, v := range charges {
+		c := *v
+		c.Amount = c.Amount.Upscale(defaultCurrencyConversionAccuracy).Multiply(ex.Amount)
+		rows[i] = &c
+	}
+	return rows
 }
 
 func (inv *Invoice) convertDiscounts(ex *currency.ExchangeRate) []*Discount {
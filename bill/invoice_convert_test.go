@@ -178,3 +178,41 @@ func TestInvoiceConvertInto(t *testing.T) {
 		assert.Equal(t, "671.16", i2.Totals.Due.String())
 	})
 }
+
+func TestInvoiceConvertIntoBaseQuantity(t *testing.T) {
+	lines := []*bill.Line{
+		{
+			Quantity: num.MakeAmount(2500, 0),
+			Item: &org.Item{
+				Name:         "Screws",
+				Price:        num.MakeAmount(1250, 2),
+				BaseQuantity: num.NewAmount(1000, 0),
+			},
+			Taxes: tax.Set{
+				{
+					Category: "VAT",
+					Rate:     tax.RateStandard,
+				},
+			},
+		},
+	}
+	inv := baseInvoice(t, lines...)
+	inv.Tax = nil
+	inv.ExchangeRates = []*currency.ExchangeRate{
+		{
+			From:   currency.EUR,
+			To:     currency.USD,
+			Amount: num.MakeAmount(112, 2),
+		},
+	}
+	require.NoError(t, inv.Calculate())
+	assert.Equal(t, "31.25", inv.Totals.Sum.String())
+
+	i2, err := inv.ConvertInto(currency.USD)
+	require.NoError(t, err)
+	l0 := i2.Lines[0]
+	assert.Equal(t, "14.0000", l0.Item.Price.String())
+	assert.Equal(t, "1000", l0.Item.BaseQuantity.String())
+	assert.Equal(t, "35.0000", l0.Sum.String())
+	assert.Equal(t, "35.00", i2.Totals.Sum.String())
+}
@@ -219,7 +219,7 @@ func testInvoiceESForCorrection(t *testing.T) *bill.Invoice {
 		Series: "TEST",
 		Code:   "123",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -266,7 +266,7 @@ func testInvoicePTForCorrection(t *testing.T) *bill.Invoice {
 		Series: "TEST",
 		Code:   "123",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -358,7 +358,7 @@ func testInvoiceCOForCorrection(t *testing.T) *bill.Invoice {
 		Series: "TEST",
 		Code:   "123",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -222,7 +222,7 @@ func TestRemoveIncludedTax2(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -286,7 +286,7 @@ func TestRemoveIncludedTax3(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -379,7 +379,7 @@ func TestRemoveIncludedTax4(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -531,7 +531,7 @@ func TestRemoveIncludedTaxQuantity(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -605,7 +605,7 @@ func TestRemoveIncludedTaxDeep(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -686,7 +686,7 @@ func TestRemoveIncludedTaxDeep2(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -737,6 +737,187 @@ func TestRemoveIncludedTaxDeep2(t *testing.T) {
 	assert.Equal(t, i.Totals.Payable.String(), i2.Totals.Payable.String())
 }
 
+func TestRemoveIncludedTaxMultiple(t *testing.T) {
+	i := &bill.Invoice{
+		Series:    "TEST",
+		Code:      "00123",
+		Currency:  "CAD",
+		IssueDate: cal.MakeDate(2024, 6, 13),
+		Tax: &bill.Tax{
+			PricesInclude: []cbc.Code{tax.CategoryGST, "PST"},
+		},
+		Supplier: &org.Party{
+			Name: "Test Supplier",
+			TaxID: &tax.Identity{
+				Country: "CA",
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Item",
+					Price: num.MakeAmount(11200, 2),
+				},
+				Taxes: tax.Set{
+					{Category: tax.CategoryGST, Percent: num.NewPercentage(5, 2)},
+					{Category: "PST", Percent: num.NewPercentage(7, 2)},
+				},
+			},
+			{
+				Quantity: num.MakeAmount(3, 0),
+				Item: &org.Item{
+					Name:  "Item 2",
+					Price: num.MakeAmount(1999, 2),
+				},
+				Taxes: tax.Set{
+					{Category: tax.CategoryGST, Percent: num.NewPercentage(5, 2)},
+				},
+			},
+		},
+	}
+	require.NoError(t, i.Calculate())
+
+	assert.Equal(t, "171.97", i.Totals.Sum.String())
+	assert.Equal(t, "14.86", i.Totals.TaxIncluded.String())
+	assert.Equal(t, "157.11", i.Totals.Total.String())
+	assert.Equal(t, "7.86", i.Totals.Taxes.Category(tax.CategoryGST).Included.String())
+	assert.Equal(t, "7.00", i.Totals.Taxes.Category("PST").Included.String())
+	assert.Equal(t, "171.97", i.Totals.Payable.String())
+
+	i2, err := i.RemoveIncludedTaxes()
+	require.NoError(t, err)
+	assert.Empty(t, i2.Tax.PricesInclude)
+	assert.Equal(t, "100.0000", i2.Lines[0].Item.Price.String())
+	assert.Equal(t, "19.0381", i2.Lines[1].Item.Price.String())
+	assert.Nil(t, i2.Totals.TaxIncluded)
+	assert.Equal(t, i.Totals.Total.String(), i2.Totals.Total.String())
+	assert.Equal(t, i.Totals.Tax.String(), i2.Totals.Tax.String())
+	assert.Equal(t, i.Totals.Payable.String(), i2.Totals.Payable.String())
+}
+
+func TestRemoveIncludedTaxRoundTrip(t *testing.T) {
+	roundTrip := func(t *testing.T, i *bill.Invoice) *bill.Invoice {
+		t.Helper()
+		require.NoError(t, i.Calculate())
+		i2, err := i.RemoveIncludedTaxes()
+		require.NoError(t, err)
+		assert.Nil(t, i2.Totals.Rounding, "no rounding adjustment required")
+		assert.Equal(t, i.Totals.Total.String(), i2.Totals.Total.String())
+		assert.Equal(t, i.Totals.Tax.String(), i2.Totals.Tax.String())
+		assert.Equal(t, i.Totals.Payable.String(), i2.Totals.Payable.String())
+		return i2
+	}
+
+	t.Run("compound category", func(t *testing.T) {
+		i := &bill.Invoice{
+			Code:      "123TEST",
+			Currency:  "EUR",
+			IssueDate: cal.MakeDate(2024, 6, 13),
+			Tax: &bill.Tax{
+				PricesInclude: []cbc.Code{"IGIC"},
+			},
+			Supplier: &org.Party{
+				Name: "Test Supplier",
+				TaxID: &tax.Identity{
+					Country: "ES",
+					Code:    "B98602642",
+				},
+			},
+			Lines: []*bill.Line{
+				{
+					Quantity: num.MakeAmount(1, 0),
+					Item: &org.Item{
+						Name:  "Item",
+						Price: num.MakeAmount(10735, 2),
+					},
+					Taxes: tax.Set{
+						{Category: "IGIC", Rate: "standard"},
+						{Category: "AIEM", Percent: num.NewPercentage(50, 3)},
+					},
+				},
+			},
+		}
+		i2 := roundTrip(t, i)
+		assert.Equal(t, "100.00", i.Totals.Total.String())
+		assert.Equal(t, "100.0000", i2.Lines[0].Item.Price.String())
+	})
+
+	t.Run("fixed amount", func(t *testing.T) {
+		i := &bill.Invoice{
+			Regime:    tax.WithRegime("MX"),
+			Code:      "123TEST",
+			Currency:  "MXN",
+			IssueDate: cal.MakeDate(2024, 6, 13),
+			Tax: &bill.Tax{
+				PricesInclude: []cbc.Code{"IEPS"},
+			},
+			Supplier: &org.Party{
+				TaxID: &tax.Identity{
+					Country: "MX",
+					Code:    "EKU9003173C9",
+				},
+			},
+			Lines: []*bill.Line{
+				{
+					Quantity: num.MakeAmount(2, 0),
+					Item: &org.Item{
+						Name:  "Refresco 1L",
+						Price: num.MakeAmount(5000, 2),
+					},
+					Taxes: tax.Set{
+						{Category: "IEPS", Fixed: num.NewAmount(650, 2), Per: tax.PerUnit},
+					},
+				},
+			},
+			Discounts: []*bill.Discount{
+				{
+					Reason: "Returned bottle",
+					Amount: num.MakeAmount(1300, 2),
+					Taxes: tax.Set{
+						{Category: "IEPS", Fixed: num.NewAmount(650, 2)},
+					},
+				},
+			},
+		}
+		i2 := roundTrip(t, i)
+		assert.Equal(t, "80.50", i.Totals.Total.String())
+		assert.Equal(t, "43.5000", i2.Lines[0].Item.Price.String())
+		assert.Equal(t, "6.5000", i2.Discounts[0].Amount.String())
+	})
+}
+
+func TestRemoveIncludedTaxBaseQuantity(t *testing.T) {
+	lines := []*bill.Line{
+		{
+			Quantity: num.MakeAmount(2500, 0),
+			Item: &org.Item{
+				Name:         "Screws",
+				Price:        num.MakeAmount(1210, 2),
+				BaseQuantity: num.NewAmount(1000, 0),
+			},
+			Taxes: tax.Set{
+				{
+					Category: "VAT",
+					Percent:  num.NewPercentage(21, 2),
+				},
+			},
+		},
+	}
+	i := baseInvoice(t, lines...)
+	require.NoError(t, i.Calculate())
+	assert.Equal(t, "30.25", i.Totals.Sum.String())
+
+	i2, err := i.RemoveIncludedTaxes()
+	require.NoError(t, err)
+	l0 := i2.Lines[0]
+	assert.Equal(t, "10.0000", l0.Item.Price.String())
+	assert.Equal(t, "1000", l0.Item.BaseQuantity.String())
+	assert.Equal(t, "25.0000", l0.Sum.String())
+	assert.Equal(t, i.Totals.Total.String(), i2.Totals.Total.String())
+	assert.Equal(t, i.Totals.Payable.String(), i2.Totals.Payable.String())
+}
+
 func TestCalculateTotalsWithFractions(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
@@ -794,6 +975,315 @@ func TestCalculateTotalsWithFractions(t *testing.T) {
 	assert.Equal(t, "122.61", i.Totals.Total.String())
 }
 
+func TestCalculateTotalsWithFixedTax(t *testing.T) {
+	cuota := num.MakeAmount(16451, 4)
+	i := &bill.Invoice{
+		Regime:   tax.WithRegime("MX"),
+		Code:     "123TEST",
+		Currency: "MXN",
+		Supplier: &org.Party{
+			TaxID: &tax.Identity{
+				Country: "MX",
+				Code:    "EKU9003173C9",
+			},
+		},
+		IssueDate: cal.MakeDate(2024, 6, 13),
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(24, 0),
+				Item: &org.Item{
+					Name:  "Refresco 1L",
+					Price: num.MakeAmount(2000, 2),
+				},
+				Taxes: tax.Set{
+					{
+						Category: "VAT",
+						Rate:     "standard",
+					},
+					{
+						Category: "IEPS",
+						Fixed:    &cuota,
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, i.Calculate())
+
+	ct := i.Totals.Taxes.Category("IEPS")
+	require.NotNil(t, ct)
+	assert.Equal(t, "24", ct.Rates[0].Quantity.String())
+	assert.Equal(t, "39.48", ct.Amount.String())
+	assert.Equal(t, "480.00", i.Totals.Total.String())
+	assert.Equal(t, "116.28", i.Totals.Tax.String())
+	assert.Equal(t, "596.28", i.Totals.TotalWithTax.String())
+}
+
+func TestCalculateTotalsWithFixedTaxSign(t *testing.T) {
+	newInvoice := func(fixed num.Amount, per cbc.Key) *bill.Invoice {
+		return &bill.Invoice{
+			Regime:   tax.WithRegime("MX"),
+			Code:     "123TEST",
+			Currency: "MXN",
+			Supplier: &org.Party{
+				TaxID: &tax.Identity{
+					Country: "MX",
+					Code:    "EKU9003173C9",
+				},
+			},
+			IssueDate: cal.MakeDate(2024, 6, 13),
+			Lines: []*bill.Line{
+				{
+					Quantity: num.MakeAmount(24, 0),
+					Item: &org.Item{
+						Name:  "Refresco 1L",
+						Price: num.MakeAmount(2000, 2),
+					},
+					Taxes: tax.Set{
+						{
+							Category: "VAT",
+							Rate:     "standard",
+						},
+						{
+							Category: "IEPS",
+							Fixed:    &fixed,
+							Per:      per,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("invert per line", func(t *testing.T) {
+		i := newInvoice(num.MakeAmount(100, 2), tax.PerLine)
+		require.NoError(t, i.Calculate())
+		ct := i.Totals.Taxes.Category("IEPS")
+		assert.Equal(t, "1.00", ct.Amount.String())
+		require.NoError(t, i.Invert())
+		ct = i.Totals.Taxes.Category("IEPS")
+		assert.Equal(t, "-1", ct.Rates[0].Quantity.String())
+		assert.Equal(t, "-1.00", ct.Amount.String())
+		assert.Equal(t, "-557.80", i.Totals.Payable.String())
+	})
+
+	t.Run("invert per unit", func(t *testing.T) {
+		i := newInvoice(num.MakeAmount(16451, 4), tax.PerUnit)
+		require.NoError(t, i.Calculate())
+		require.NoError(t, i.Invert())
+		ct := i.Totals.Taxes.Category("IEPS")
+		assert.Equal(t, "-24", ct.Rates[0].Quantity.String())
+		assert.Equal(t, "-39.48", ct.Amount.String())
+	})
+
+	t.Run("negative line", func(t *testing.T) {
+		i := newInvoice(num.MakeAmount(100, 2), tax.PerLine)
+		l := *i.Lines[0]
+		l.Quantity = num.MakeAmount(-4, 0)
+		l.Taxes = tax.Set{
+			{Category: "VAT", Rate: "standard"},
+			{Category: "IEPS", Fixed: num.NewAmount(100, 2), Per: tax.PerLine},
+		}
+		i.Lines = append(i.Lines, &l)
+		require.NoError(t, i.Calculate())
+		ct := i.Totals.Taxes.Category("IEPS")
+		assert.Equal(t, "0", ct.Rates[0].Quantity.String())
+		assert.Equal(t, "0.00", ct.Amount.String())
+	})
+
+	t.Run("discount", func(t *testing.T) {
+		i := newInvoice(num.MakeAmount(150, 2), tax.PerUnit)
+		i.Discounts = []*bill.Discount{
+			{
+				Reason: "Returned bottle",
+				Amount: num.MakeAmount(2000, 2),
+				Taxes: tax.Set{
+					{
+						Category: "VAT",
+						Rate:     "standard",
+					},
+					{
+						Category: "IEPS",
+						Fixed:    num.NewAmount(150, 2),
+						Per:      tax.PerUnit,
+					},
+				},
+			},
+		}
+		require.NoError(t, i.Calculate())
+		ct := i.Totals.Taxes.Category("IEPS")
+		assert.Equal(t, "23", ct.Rates[0].Quantity.String())
+		assert.Equal(t, "34.50", ct.Amount.String())
+		assert.Equal(t, "460.00", i.Totals.Total.String())
+	})
+}
+
+func TestCalculateRoundPerLine(t *testing.T) {
+	inv := &bill.Invoice{
+		Code:      "123TEST",
+		Currency:  "EUR",
+		IssueDate: cal.MakeDate(2024, 6, 13),
+		Tax: &bill.Tax{
+			Rounding: tax.CalculatorRoundPerLine,
+		},
+		Supplier: &org.Party{
+			Name: "Test Supplier",
+			TaxID: &tax.Identity{
+				Country: "ES",
+				Code:    "B98602642",
+			},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		inv.Lines = append(inv.Lines, &bill.Line{
+			Quantity: num.MakeAmount(1, 0),
+			Item: &org.Item{
+				Name:  "Item",
+				Price: num.MakeAmount(103, 2),
+			},
+			Taxes: tax.Set{
+				{Category: "VAT", Rate: "standard"},
+			},
+		})
+	}
+	require.NoError(t, inv.Calculate())
+	require.NoError(t, inv.Calculate()) // amounts must not accumulate
+	for _, l := range inv.Lines {
+		require.Len(t, l.TaxAmounts, 1)
+		assert.Equal(t, "0.22", l.TaxAmounts[0].Amount.String())
+	}
+	assert.Equal(t, "0.66", inv.Totals.Tax.String())
+
+	inv.Tax.Rounding = ""
+	require.NoError(t, inv.Calculate())
+	assert.Nil(t, inv.Lines[0].TaxAmounts)
+	assert.Equal(t, "0.65", inv.Totals.Tax.String())
+
+	inv.Tax.Rounding = "per-line"
+	assert.ErrorContains(t, inv.Validate(), "tax: (rounding: must be a valid value.)")
+}
+
+func TestCalculateCashRounding(t *testing.T) {
+	newInvoice := func(means cbc.Key) *bill.Invoice {
+		return &bill.Invoice{
+			Regime:   tax.WithRegime("CH"),
+			Code:     "123TEST",
+			Currency: "CHF",
+			Supplier: &org.Party{
+				TaxID: &tax.Identity{
+					Country: "CH",
+				},
+			},
+			IssueDate: cal.MakeDate(2024, 6, 13),
+			Lines: []*bill.Line{
+				{
+					Quantity: num.MakeAmount(3, 0),
+					Item: &org.Item{
+						Name:  "Kaffee",
+						Price: num.MakeAmount(410, 2),
+					},
+					Taxes: tax.Set{
+						{
+							Category: "VAT",
+							Rate:     "standard",
+						},
+					},
+				},
+			},
+			Payment: &bill.Payment{
+				Instructions: &pay.Instructions{
+					Key: means,
+				},
+			},
+		}
+	}
+
+	t.Run("cash", func(t *testing.T) {
+		inv := newInvoice(pay.MeansKeyCash)
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "13.30", inv.Totals.TotalWithTax.String())
+		assert.Nil(t, inv.Totals.Rounding)
+	})
+
+	t.Run("cash with rounding", func(t *testing.T) {
+		inv := newInvoice(pay.MeansKeyCash)
+		inv.Lines[0].Quantity = num.MakeAmount(4, 0)
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "17.73", inv.Totals.TotalWithTax.String())
+		require.NotNil(t, inv.Totals.Rounding)
+		assert.Equal(t, "0.02", inv.Totals.Rounding.String())
+		assert.Equal(t, "17.75", inv.Totals.Payable.String())
+	})
+
+	t.Run("card", func(t *testing.T) {
+		inv := newInvoice(pay.MeansKeyCard)
+		inv.Lines[0].Quantity = num.MakeAmount(4, 0)
+		require.NoError(t, inv.Calculate())
+		assert.Nil(t, inv.Totals.Rounding)
+		assert.Equal(t, "17.73", inv.Totals.Payable.String())
+	})
+
+	t.Run("cash with advance", func(t *testing.T) {
+		inv := newInvoice(pay.MeansKeyCash)
+		inv.Lines[0].Quantity = num.MakeAmount(4, 0)
+		inv.Payment.Advances = []*pay.Advance{
+			{
+				Description: "Deposit",
+				Amount:      num.MakeAmount(501, 2),
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "17.73", inv.Totals.TotalWithTax.String())
+		require.NotNil(t, inv.Totals.Rounding)
+		assert.Equal(t, "-0.02", inv.Totals.Rounding.String())
+		assert.Equal(t, "17.71", inv.Totals.Payable.String())
+		assert.Equal(t, "12.70", inv.Totals.Due.String())
+	})
+
+	t.Run("currency rule", func(t *testing.T) {
+		inv := newInvoice(pay.MeansKeyCash)
+		inv.Regime = tax.WithRegime("CA")
+		inv.Currency = "CAD"
+		inv.Supplier.TaxID.Country = "CA"
+		inv.Lines[0].Taxes[0].Category = "GST"
+		inv.Lines[0].Quantity = num.MakeAmount(4, 0)
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "17.22", inv.Totals.TotalWithTax.String())
+		require.NotNil(t, inv.Totals.Rounding)
+		assert.Equal(t, "-0.02", inv.Totals.Rounding.String())
+		assert.Equal(t, "17.20", inv.Totals.Payable.String())
+	})
+
+	t.Run("currency rule with larger increment", func(t *testing.T) {
+		inv := newInvoice(pay.MeansKeyCash)
+		inv.Regime = tax.WithRegime("DK")
+		inv.Currency = "DKK"
+		inv.Supplier.TaxID.Country = "DK"
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "15.38", inv.Totals.TotalWithTax.String())
+		require.NotNil(t, inv.Totals.Rounding)
+		assert.Equal(t, "0.12", inv.Totals.Rounding.String())
+		assert.Equal(t, "15.50", inv.Totals.Payable.String())
+	})
+
+	t.Run("currency without rule", func(t *testing.T) {
+		inv := newInvoice(pay.MeansKeyCash)
+		inv.Regime = tax.WithRegime("US")
+		inv.Currency = "USD"
+		inv.Supplier.TaxID.Country = "US"
+		inv.Lines[0].Taxes[0] = &tax.Combo{
+			Category: "ST",
+			Percent:  num.NewPercentage(5, 2),
+		}
+		inv.Lines[0].Quantity = num.MakeAmount(4, 0)
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "17.22", inv.Totals.TotalWithTax.String())
+		assert.Nil(t, inv.Totals.Rounding)
+		assert.Equal(t, "17.22", inv.Totals.Payable.String())
+	})
+}
+
 func TestApplyCustomerRates(t *testing.T) {
 	t.Run("missing customer", func(t *testing.T) {
 		lines := []*bill.Line{
@@ -894,7 +1384,7 @@ func TestCalculate(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -962,7 +1452,7 @@ func TestCalculateInverted(t *testing.T) {
 	i := &bill.Invoice{
 		Code: "123TEST",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			TaxID: &tax.Identity{
@@ -1198,7 +1688,7 @@ func baseInvoice(t *testing.T, lines ...*bill.Line) *bill.Invoice {
 		Code:      "00123",
 		IssueDate: cal.MakeDate(2022, 6, 13),
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			Name: "Test Supplier",
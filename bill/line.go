@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/invopop/gobl/cal"
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/currency"
 	"github.com/invopop/gobl/num"
@@ -21,8 +22,18 @@ type Line struct {
 	Index int `json:"i" jsonschema:"title=Index" jsonschema_extras:"calculated=true"`
 	// Number of items
 	Quantity num.Amount `json:"quantity" jsonschema:"title=Quantity"`
+	// Single identifier provided by the supplier for the object on the line being
+	// invoiced, such as a meter reading or subscription ID.
+	Identifier *org.Identity `json:"identifier,omitempty" jsonschema:"title=Identifier"`
+	// A period of time relevant to when the service or item is delivered.
+	Period *cal.Period `json:"period,omitempty" jsonschema:"title=Period"`
+	// Code used to reference the line in the buyer's purchase order.
+	Order cbc.Code `json:"order,omitempty" jsonschema:"title=Order Reference"`
 	// Details about what is being sold
 	Item *org.Item `json:"item" jsonschema:"title=Item"`
+	// Breakdown of the components included in a single unit of the item. Unless
+	// informative, the sub-line totals determine the item's price.
+	Breakdown []*SubLine `json:"breakdown,omitempty" jsonschema:"title=Breakdown"`
 	// Result of quantity multiplied by the item's price (calculated)
 	Sum num.Amount `json:"sum" jsonschema:"title=Sum" jsonschema_extras:"calculated=true"`
 	// Discounts applied to this line
@@ -33,6 +44,9 @@ type Line struct {
 	Taxes tax.Set `json:"taxes,omitempty" jsonschema:"title=Taxes"`
 	// Total line amount after applying discounts to the sum (calculated).
 	Total num.Amount `json:"total" jsonschema:"title=Total"  jsonschema_extras:"calculated=true"`
+	// Tax amounts of each category applied to the line, only set when the taxes
+	// are rounded per line (calculated).
+	TaxAmounts []*LineTax `json:"tax_amounts,omitempty" jsonschema:"title=Tax Amounts" jsonschema_extras:"calculated=true"`
 	// Set of specific notes for this line that may be required for
 	// clarification.
 	Notes []*cbc.Note `json:"notes,omitempty" jsonschema:"title=Notes"`
@@ -51,6 +65,35 @@ func (l *Line) GetTotal() num.Amount {
 	return l.total
 }
 
+// GetQuantity provides the number of units used to calculate fixed
+// amount taxes.
+func (l *Line) GetQuantity() num.Amount {
+	return l.Quantity
+}
+
+// AddTaxAmount adds the amount calculated for the tax combo to the line's
+// tax amounts, used when rounding taxes per line. Amounts are grouped by
+// category, rate and percent, so that the parts of a line with a breakdown
+// taxed at different rates are reported separately.
+func (l *Line) AddTaxAmount(c *tax.Combo, amount num.Amount) {
+	for _, lt := range l.TaxAmounts {
+		if lt.matches(c) {
+			lt.Amount = lt.Amount.MatchPrecision(amount).Add(amount)
+			return
+		}
+	}
+	lt := &LineTax{
+		Category: c.Category,
+		Rate:     c.Rate,
+		Amount:   amount,
+	}
+	if c.Percent != nil {
+		p := *c.Percent
+		lt.Percent = &p
+	}
+	l.TaxAmounts = append(l.TaxAmounts, lt)
+}
+
 // ValidateWithContext ensures the line contains everything required using
 // the provided context that should include the regime.
 func (l *Line) ValidateWithContext(ctx context.Context) error {
@@ -58,7 +101,11 @@ func (l *Line) ValidateWithContext(ctx context.Context) error {
 		validation.Field(&l.UUID),
 		validation.Field(&l.Index, validation.Required),
 		validation.Field(&l.Quantity, validation.Required),
+		validation.Field(&l.Identifier),
+		validation.Field(&l.Period),
+		validation.Field(&l.Order),
 		validation.Field(&l.Item, validation.Required),
+		validation.Field(&l.Breakdown, validation.By(l.checkBreakdownPrice)),
 		validation.Field(&l.Sum, validation.Required),
 		validation.Field(&l.Discounts),
 		validation.Field(&l.Charges),
@@ -74,18 +121,115 @@ func (l *Line) Normalize(normalizers tax.Normalizers) {
 	l.Taxes = tax.CleanSet(l.Taxes)
 	l.Discounts = CleanLineDiscounts(l.Discounts)
 	l.Charges = CleanLineCharges(l.Charges)
+	l.Order = cbc.NormalizeCode(l.Order)
 	normalizers.Each(l)
+	tax.Normalize(normalizers, l.Identifier)
 	tax.Normalize(normalizers, l.Taxes)
 	tax.Normalize(normalizers, l.Item)
+	tax.Normalize(normalizers, l.Breakdown)
 	tax.Normalize(normalizers, l.Discounts)
 	tax.Normalize(normalizers, l.Charges)
 }
 
+func (l *Line) checkBreakdownPrice(_ any) error {
+	if l.Item == nil {
+		return nil
+	}
+	price := breakdownPrice(l.Breakdown, num.AmountZero)
+	if price != nil && !price.Equals(l.Item.Price) {
+		return fmt.Errorf("sum of totals %s must match item price %s", price.String(), l.Item.Price.String())
+	}
+	return nil
+}
+
+// TaxableLines provides the list of taxable lines to use when calculating
+// tax totals. Lines whose breakdown defines taxes will have their total
+// split between each set of taxes in proportion to the sub-line totals.
+func (l *Line) TaxableLines() []tax.TaxableLine {
+	parts := l.taxableParts()
+	if len(parts) == 0 {
+		return []tax.TaxableLine{l}
+	}
+	tls := make([]tax.TaxableLine, len(parts))
+	for i, p := range parts {
+		tls[i] = p
+	}
+	return tls
+}
+
+func (l *Line) taxableParts() []*taxableLinePart {
+	taxed := false
+	for _, sl := range l.Breakdown {
+		if !sl.Informative && len(sl.Taxes) > 0 {
+			taxed = true
+			break
+		}
+	}
+	if !taxed {
+		return nil
+	}
+
+	// Group the sub-line totals by the set of taxes to apply, using the
+	// line's own taxes for sub-lines that do not define any.
+	own := &taxableLinePart{line: l, taxes: l.Taxes, quantity: l.Quantity}
+	weights := []num.Amount{num.MakeAmount(0, l.total.Exp())}
+	parts := []*taxableLinePart{own}
+	sum := weights[0]
+	for _, sl := range l.Breakdown {
+		if sl.Informative || sl.Item == nil {
+			continue
+		}
+		sum = sum.Add(sl.total)
+		if len(sl.Taxes) == 0 {
+			weights[0] = weights[0].Add(sl.total)
+			continue
+		}
+		weights = append(weights, sl.total)
+		parts = append(parts, &taxableLinePart{
+			line:     l,
+			taxes:    sl.Taxes,
+			quantity: sl.Quantity.Multiply(l.Quantity),
+		})
+	}
+	if sum.IsZero() {
+		return nil
+	}
+	if weights[0].IsZero() {
+		weights = weights[1:]
+		parts = parts[1:]
+	}
+
+	// Share out the line's total, leaving any remainder for the last part
+	// so that the sum always matches.
+	rem := l.total
+	for i, p := range parts {
+		if i == len(parts)-1 {
+			p.total = rem
+			break
+		}
+		p.total = l.total.Multiply(weights[i]).Divide(sum)
+		rem = rem.Subtract(p.total)
+	}
+	return parts
+}
+
 // calculate figures out the totals according to quantity and discounts.
 func (l *Line) calculate(cur currency.Code, rates []*currency.ExchangeRate) error {
+	l.TaxAmounts = nil // set by the totals calculator if required
 	if l.Item == nil {
 		return nil
 	}
+	zero := cur.Def().Zero()
+
+	// Sub-lines in the breakdown determine the item's price in the
+	// document's currency.
+	if err := calculateSubLines(l.Breakdown, cur, rates); err != nil {
+		return validation.Errors{"breakdown": err}
+	}
+	if price := breakdownPrice(l.Breakdown, zero); price != nil {
+		l.Item.Price = *price
+		l.Item.Currency = currency.CodeEmpty
+	}
 
 	// Perform currency manipulation to ensure item's price is
 	// in the document's currency.
@@ -93,45 +237,58 @@ func (l *Line) calculate(cur currency.Code, rates []*currency.ExchangeRate) erro
 		return err
 	}
 
+	l.Sum, l.total = calculateLineAmounts(l.Item, l.Quantity, l.Discounts, l.Charges, zero)
+	l.Total = l.total.Rescale(l.Item.Price.Exp())
+
+	return nil
+}
+
+// calculateLineAmounts determines the sum of the item's price for the quantity
+// provided, and the total with greater precision after applying the discounts
+// and charges.
+func calculateLineAmounts(item *org.Item, quantity num.Amount, discounts []*LineDiscount, charges []*LineCharge, zero num.Amount) (num.Amount, num.Amount) {
 	// Increase price accuracy for calculations
-	zero := cur.Def().Zero()
-	price := l.Item.Price
+	price := item.Price
 	price = price.RescaleUp(zero.Exp() + 2)
 
 	// Calculate the line sum and total
-	l.Sum = price.Multiply(l.Quantity)
-	l.total = l.Sum
+	sum := price.Multiply(quantity)
+	if bq := item.BaseQuantity; bq != nil && !bq.IsZero() {
+		// price applies to a number of units
+		sum = sum.Divide(*bq)
+	}
+	total := sum
 
-	for _, d := range l.Discounts {
+	for _, d := range discounts {
 		if d.Percent != nil && !d.Percent.IsZero() {
-			d.Amount = d.Percent.Of(l.Sum) // always override
+			d.Amount = d.Percent.Of(sum) // always override
 		}
 		d.Amount = d.Amount.MatchPrecision(zero)
-		l.total = l.total.Subtract(d.Amount)
-		d.Amount = d.Amount.Rescale(l.Item.Price.Exp())
+		total = total.Subtract(d.Amount)
+		d.Amount = d.Amount.Rescale(item.Price.Exp())
 	}
 
-	for _, c := range l.Charges {
+	for _, c := range charges {
 		if c.Percent != nil && !c.Percent.IsZero() {
-			c.Amount = c.Percent.Of(l.Sum) // always override
+			c.Amount = c.Percent.Of(sum) // always override
 		}
 		c.Amount = c.Amount.MatchPrecision(zero)
-		l.total = l.total.Add(c.Amount)
-		c.Amount = c.Amount.Rescale(l.Item.Price.Exp())
+		total = total.Add(c.Amount)
+		c.Amount = c.Amount.Rescale(item.Price.Exp())
 	}
 
-	// Rescale the final sum and total
-	l.Sum = l.Sum.Rescale(l.Item.Price.Exp())
-	l.Total = l.total.Rescale(l.Item.Price.Exp())
-
-	return nil
+	// Rescale the final sum
+	return sum.Rescale(item.Price.Exp()), total
 }
 
 // calculateItemPrice will attempt to perform any currency conversion process on
 // the line item's data so that the currency always matches that of the
 // document.
 func (l *Line) calculateItemPrice(cur currency.Code, rates []*currency.ExchangeRate) error {
-	item := l.Item
+	return calculateItemPrice(l.Item, cur, rates)
+}
+
+func calculateItemPrice(item *org.Item, cur currency.Code, rates []*currency.ExchangeRate) error {
 	icur := item.Currency
 	if icur == currency.CodeEmpty {
 		icur = cur
@@ -168,42 +325,58 @@ func (l *Line) calculateItemPrice(cur currency.Code, rates []*currency.ExchangeR
 	return nil
 }
 
-func (l *Line) removeIncludedTaxes(cat cbc.Code) *Line {
-	accuracy := defaultTaxRemovalAccuracy
-	rate := l.Taxes.Get(cat)
-	if rate == nil || rate.Percent == nil {
-		return l
+func (l *Line) removeIncludedTaxes(cats []cbc.Code) *Line {
+	l2 := *l
+	divisor := includedTaxDivisor(l, cats)
+	if len(l.Breakdown) > 0 {
+		l2.Breakdown = make([]*SubLine, len(l.Breakdown))
+		for i, sl := range l.Breakdown {
+			l2.Breakdown[i] = sl.removeIncludedTaxes(l, divisor, cats)
+		}
 	}
 
-	l2 := *l
-	l2i := *l.Item
+	if divisor == nil {
+		return &l2
+	}
 
-	l2i.AltPrices = nil // empty alternative prices
-	l2i.Price = l.Item.Price.Upscale(accuracy).Remove(*rate.Percent)
+	l2.Item = removeItemIncludedTaxes(l.Item, *divisor)
 	// assume sum and total will be calculated automatically
+	l2.Discounts = removeLineDiscountsIncludedTaxes(l.Discounts, *divisor)
+	l2.Charges = removeLineChargesIncludedTaxes(l.Charges, *divisor)
+	return &l2
+}
 
-	if len(l2.Discounts) > 0 {
-		rows := make([]*LineDiscount, len(l2.Discounts))
-		for i, v := range l.Discounts {
-			d := *v
-			d.Amount = d.Amount.Upscale(accuracy).Remove(*rate.Percent)
-			rows[i] = &d
-		}
-		l2.Discounts = rows
-	}
+func removeItemIncludedTaxes(item *org.Item, divisor num.Amount) *org.Item {
+	i2 := *item
+	i2.AltPrices = nil // empty alternative prices
+	i2.Price = item.Price.Upscale(defaultTaxRemovalAccuracy).Divide(divisor)
+	return &i2
+}
 
-	if len(l2.Charges) > 0 {
-		rows := make([]*LineCharge, len(l2.Charges))
-		for i, v := range l.Charges {
-			d := *v
-			d.Amount = d.Amount.Upscale(accuracy).Remove(*rate.Percent)
-			rows[i] = &d
-		}
-		l2.Charges = rows
+func removeLineDiscountsIncludedTaxes(discounts []*LineDiscount, divisor num.Amount) []*LineDiscount {
+	if len(discounts) == 0 {
+		return discounts
+	}
+	rows := make([]*LineDiscount, len(discounts))
+	for i, v := range discounts {
+		d := *v
+		d.Amount = d.Amount.Upscale(defaultTaxRemovalAccuracy).Divide(divisor)
+		rows[i] = &d
 	}
+	return rows
+}
 
-	l2.Item = &l2i
-	return &l2
+func removeLineChargesIncludedTaxes(charges []*LineCharge, divisor num.Amount) []*LineCharge {
+	if len(charges) == 0 {
+		return charges
+	}
+	rows := make([]*LineCharge, len(charges))
+	for i, v := range charges {
+		c := *v
+		c.Amount = c.Amount.Upscale(defaultTaxRemovalAccuracy).Divide(divisor)
+		rows[i] = &c
+	}
+	return rows
 }
 
 func calculateLines(lines []*Line, cur currency.Code, rates []*currency.ExchangeRate) error {
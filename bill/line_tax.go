@@ -0,0 +1,31 @@
+package bill
+
+import (
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+// LineTax contains the amount of a tax category and rate calculated for a
+// single line, used when formats require the taxes of each line to be reported
+// and rounded individually.
+type LineTax struct {
+	// Tax category code the amount belongs to.
+	Category cbc.Code `json:"cat" jsonschema:"title=Category"`
+	// Rate key of the combo, if any.
+	Rate cbc.Key `json:"rate,omitempty" jsonschema:"title=Rate"`
+	// Percent applied to the line's total, empty for fixed amounts.
+	Percent *num.Percentage `json:"percent,omitempty" jsonschema:"title=Percent"`
+	// Amount of tax applied to the line's total.
+	Amount num.Amount `json:"amount" jsonschema:"title=Amount"`
+}
+
+func (lt *LineTax) matches(c *tax.Combo) bool {
+	if lt.Category != c.Category || lt.Rate != c.Rate {
+		return false
+	}
+	if lt.Percent == nil || c.Percent == nil {
+		return lt.Percent == nil && c.Percent == nil
+	}
+	return lt.Percent.Equals(*c.Percent)
+}
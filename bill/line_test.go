@@ -135,3 +135,52 @@ func TestLineNormalize(t *testing.T) {
 	assert.Len(t, line.Discounts, 0)
 	assert.Len(t, line.Charges, 0)
 }
+
+func TestLineCalculateBaseQuantity(t *testing.T) {
+	t.Run("price per 1000 units", func(t *testing.T) {
+		line := &Line{
+			Quantity: num.MakeAmount(2500, 0),
+			Item: &org.Item{
+				Name:         "Screws",
+				Price:        num.MakeAmount(1250, 2),
+				BaseQuantity: num.NewAmount(1000, 0),
+			},
+			Discounts: []*LineDiscount{
+				{
+					Percent: num.NewPercentage(10, 2),
+				},
+			},
+		}
+		require.NoError(t, line.calculate(currency.EUR, nil))
+		assert.Equal(t, "31.25", line.Sum.String())
+		assert.Equal(t, "3.13", line.Discounts[0].Amount.String())
+		assert.Equal(t, "28.13", line.Total.String())
+		assert.Equal(t, "12.50", line.Item.Price.String())
+	})
+	t.Run("price per 100 kg with fractions", func(t *testing.T) {
+		line := &Line{
+			Quantity: num.MakeAmount(1575, 1),
+			Item: &org.Item{
+				Name:         "Flour",
+				Price:        num.MakeAmount(4599, 2),
+				Unit:         org.UnitKilogram,
+				BaseQuantity: num.NewAmount(100, 0),
+				BaseUnit:     org.UnitKilogram,
+			},
+		}
+		require.NoError(t, line.calculate(currency.EUR, nil))
+		assert.Equal(t, "72.43", line.Sum.String())
+		assert.Equal(t, "72.43", line.Total.String())
+	})
+	t.Run("no base quantity", func(t *testing.T) {
+		line := &Line{
+			Quantity: num.MakeAmount(3, 0),
+			Item: &org.Item{
+				Name:  "Test Item",
+				Price: num.MakeAmount(1250, 2),
+			},
+		}
+		require.NoError(t, line.calculate(currency.EUR, nil))
+		assert.Equal(t, "37.50", line.Sum.String())
+	})
+}
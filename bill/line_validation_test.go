@@ -4,10 +4,13 @@ import (
 	"testing"
 
 	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
 	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
 	"github.com/invopop/gobl/tax"
 	"github.com/invopop/validation"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLineRequireTaxValidation(t *testing.T) {
@@ -44,3 +47,49 @@ func TestLineRequireTaxValidation(t *testing.T) {
 	)
 	assert.ErrorContains(t, err, "taxes: missing category IRPEF.")
 }
+
+func TestLinePeriodAndReferences(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		inv := baseInvoiceWithLines(t)
+		l := inv.Lines[0]
+		l.Identifier = &org.Identity{
+			Label: "Meter",
+			Code:  "MTR-001",
+		}
+		l.Period = &cal.Period{
+			Start: cal.MakeDate(2024, 1, 1),
+			End:   cal.MakeDate(2024, 1, 31),
+		}
+		l.Order = " PO-1-10 "
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "MTR-001", l.Identifier.Code.String())
+		assert.Equal(t, "PO-1-10", l.Order.String())
+		assert.NoError(t, inv.Validate())
+	})
+	t.Run("same day period", func(t *testing.T) {
+		inv := baseInvoiceWithLines(t)
+		inv.Lines[0].Period = &cal.Period{
+			Start: cal.MakeDate(2024, 1, 1),
+			End:   cal.MakeDate(2024, 1, 1),
+		}
+		require.NoError(t, inv.Calculate())
+		assert.NoError(t, inv.Validate())
+	})
+	t.Run("invalid period", func(t *testing.T) {
+		inv := baseInvoiceWithLines(t)
+		inv.Lines[0].Period = &cal.Period{
+			Start: cal.MakeDate(2024, 1, 31),
+			End:   cal.MakeDate(2024, 1, 1),
+		}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "lines: (0: (period: (end: too early; start: too late.).).)")
+	})
+	t.Run("invalid identifier", func(t *testing.T) {
+		inv := baseInvoiceWithLines(t)
+		inv.Lines[0].Identifier = &org.Identity{
+			Label: "Meter",
+		}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "lines: (0: (identifier: (code: cannot be blank.).).)")
+	})
+}
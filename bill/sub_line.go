@@ -0,0 +1,180 @@
+package bill
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/gobl/uuid"
+	"github.com/invopop/validation"
+)
+
+// SubLine provides a simplified line that can be embedded inside a line's
+// breakdown in order to describe the components of a single unit of the
+// parent line's item, such as the products and services included in a bundle.
+type SubLine struct {
+	uuid.Identify
+	// Line number inside the parent line (calculated)
+	Index int `json:"i" jsonschema:"title=Index" jsonschema_extras:"calculated=true"`
+	// Number of items included in a single unit of the parent line
+	Quantity num.Amount `json:"quantity" jsonschema:"title=Quantity"`
+	// Details about the component
+	Item *org.Item `json:"item" jsonschema:"title=Item"`
+	// Result of quantity multiplied by the item's price (calculated)
+	Sum num.Amount `json:"sum" jsonschema:"title=Sum" jsonschema_extras:"calculated=true"`
+	// Discounts applied to this sub-line
+	Discounts []*LineDiscount `json:"discounts,omitempty" jsonschema:"title=Discounts"`
+	// Charges applied to this sub-line
+	Charges []*LineCharge `json:"charges,omitempty" jsonschema:"title=Charges"`
+	// Taxes to apply to this component instead of those of the parent line.
+	Taxes tax.Set `json:"taxes,omitempty" jsonschema:"title=Taxes"`
+	// When true, the sub-line's amounts are for information only and will not
+	// be included in the parent line's price.
+	Informative bool `json:"informative,omitempty" jsonschema:"title=Informative"`
+	// Total sub-line amount after applying discounts to the sum (calculated).
+	Total num.Amount `json:"total" jsonschema:"title=Total" jsonschema_extras:"calculated=true"`
+	// Set of specific notes for this sub-line that may be required for
+	// clarification.
+	Notes []*cbc.Note `json:"notes,omitempty" jsonschema:"title=Notes"`
+
+	// internal amount provided with greater precision
+	total num.Amount
+}
+
+// ValidateWithContext ensures the sub-line contains everything required.
+func (sl *SubLine) ValidateWithContext(ctx context.Context) error {
+	return tax.ValidateStructWithContext(ctx, sl,
+		validation.Field(&sl.UUID),
+		validation.Field(&sl.Index, validation.Required),
+		validation.Field(&sl.Quantity, validation.Required),
+		validation.Field(&sl.Item, validation.Required),
+		validation.Field(&sl.Sum, validation.Required),
+		validation.Field(&sl.Discounts),
+		validation.Field(&sl.Charges),
+		validation.Field(&sl.Taxes,
+			validation.When(
+				sl.Informative,
+				validation.Empty.Error("must be blank when informative"),
+			),
+		),
+		validation.Field(&sl.Total, validation.Required),
+		validation.Field(&sl.Notes),
+	)
+}
+
+// Normalize performs normalization on the sub-line and embedded objects using the
+// provided list of normalizers.
+func (sl *SubLine) Normalize(normalizers tax.Normalizers) {
+	sl.Taxes = tax.CleanSet(sl.Taxes)
+	sl.Discounts = CleanLineDiscounts(sl.Discounts)
+	sl.Charges = CleanLineCharges(sl.Charges)
+	normalizers.Each(sl)
+	tax.Normalize(normalizers, sl.Taxes)
+	tax.Normalize(normalizers, sl.Item)
+	tax.Normalize(normalizers, sl.Discounts)
+	tax.Normalize(normalizers, sl.Charges)
+}
+
+func (sl *SubLine) calculate(cur currency.Code, rates []*currency.ExchangeRate) error {
+	if sl.Item == nil {
+		return nil
+	}
+	if err := calculateItemPrice(sl.Item, cur, rates); err != nil {
+		return err
+	}
+	sl.Sum, sl.total = calculateLineAmounts(sl.Item, sl.Quantity, sl.Discounts, sl.Charges, cur.Def().Zero())
+	sl.Total = sl.total.Rescale(sl.Item.Price.Exp())
+	return nil
+}
+
+func (sl *SubLine) convertInto(ex *currency.ExchangeRate) *SubLine {
+	sl2 := *sl
+	sl2.Item = convertItemInto(sl.Item, ex)
+	sl2.Discounts = convertLineDiscountsInto(sl.Discounts, ex)
+	sl2.Charges = convertLineChargesInto(sl.Charges, ex)
+	return &sl2
+}
+
+// removeIncludedTaxes uses the parent line's divisor to remove included taxes,
+// unless the sub-line defines its own taxes.
+func (sl *SubLine) removeIncludedTaxes(l *Line, divisor *num.Amount, cats []cbc.Code) *SubLine {
+	if len(sl.Taxes) > 0 {
+		divisor = includedTaxDivisor(&taxableLinePart{
+			taxes:    sl.Taxes,
+			total:    sl.total.Multiply(l.Quantity),
+			quantity: sl.Quantity.Multiply(l.Quantity),
+		}, cats)
+	}
+	if divisor == nil {
+		return sl
+	}
+	sl2 := *sl
+	sl2.Item = removeItemIncludedTaxes(sl.Item, *divisor)
+	sl2.Discounts = removeLineDiscountsIncludedTaxes(sl.Discounts, *divisor)
+	sl2.Charges = removeLineChargesIncludedTaxes(sl.Charges, *divisor)
+	return &sl2
+}
+
+func calculateSubLines(lines []*SubLine, cur currency.Code, rates []*currency.ExchangeRate) error {
+	for i, sl := range lines {
+		sl.Index = i + 1
+		if err := sl.calculate(cur, rates); err != nil {
+			return validation.Errors{strconv.Itoa(i): err}
+		}
+	}
+	return nil
+}
+
+// breakdownPrice provides the sum of the totals of the sub-lines that are
+// not informative, or nil if there are none.
+func breakdownPrice(lines []*SubLine, zero num.Amount) *num.Amount {
+	var price *num.Amount
+	for _, sl := range lines {
+		if sl.Informative || sl.Item == nil {
+			continue
+		}
+		p := zero
+		if price != nil {
+			p = *price
+		}
+		p = p.MatchPrecision(sl.Total).Add(sl.Total)
+		price = &p
+	}
+	return price
+}
+
+// taxableLinePart is used to split the total of a line whose breakdown
+// defines taxes between each of the sets of taxes to apply.
+type taxableLinePart struct {
+	line     *Line
+	taxes    tax.Set
+	total    num.Amount
+	quantity num.Amount
+}
+
+// GetTaxes provides the set of taxes to apply to this part of the line.
+func (p *taxableLinePart) GetTaxes() tax.Set {
+	return p.taxes
+}
+
+// GetTotal provides this part's share of the line's total.
+func (p *taxableLinePart) GetTotal() num.Amount {
+	return p.total
+}
+
+// GetQuantity provides the number of units used to calculate fixed
+// amount taxes.
+func (p *taxableLinePart) GetQuantity() num.Amount {
+	return p.quantity
+}
+
+// AddTaxAmount adds the tax amount calculated for this part to the line.
+func (p *taxableLinePart) AddTaxAmount(c *tax.Combo, amount num.Amount) {
+	if p.line != nil {
+		p.line.AddTaxAmount(c, amount)
+	}
+}
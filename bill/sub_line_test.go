@@ -0,0 +1,237 @@
+package bill_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bundleLine() *bill.Line {
+	return &bill.Line{
+		Quantity: num.MakeAmount(2, 0),
+		Item: &org.Item{
+			Name: "Laptop bundle",
+		},
+		Breakdown: []*bill.SubLine{
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Laptop",
+					Price: num.MakeAmount(90000, 2),
+				},
+			},
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Warranty",
+					Price: num.MakeAmount(10000, 2),
+				},
+				Discounts: []*bill.LineDiscount{
+					{
+						Percent: num.NewPercentage(10, 2),
+					},
+				},
+			},
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Shipping",
+					Price: num.MakeAmount(2000, 2),
+				},
+				Informative: true,
+			},
+		},
+		Taxes: tax.Set{
+			{
+				Category: tax.CategoryVAT,
+				Rate:     tax.RateStandard,
+			},
+		},
+	}
+}
+
+func TestLineBreakdownCalculate(t *testing.T) {
+	t.Run("rolls up into price", func(t *testing.T) {
+		inv := baseInvoice(t, bundleLine())
+		inv.Tax = nil
+		require.NoError(t, inv.Calculate())
+		l0 := inv.Lines[0]
+		assert.Equal(t, 1, l0.Breakdown[0].Index)
+		assert.Equal(t, "900.00", l0.Breakdown[0].Total.String())
+		assert.Equal(t, "10.00", l0.Breakdown[1].Discounts[0].Amount.String())
+		assert.Equal(t, "90.00", l0.Breakdown[1].Total.String())
+		assert.Equal(t, "20.00", l0.Breakdown[2].Total.String())
+		assert.Equal(t, "990.00", l0.Item.Price.String())
+		assert.Equal(t, "1980.00", l0.Sum.String())
+		assert.Equal(t, "1980.00", inv.Totals.Sum.String())
+		assert.Equal(t, "415.80", inv.Totals.Tax.String())
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("informative only", func(t *testing.T) {
+		l := bundleLine()
+		l.Item.Price = num.MakeAmount(50000, 2)
+		l.Breakdown = l.Breakdown[2:]
+		inv := baseInvoice(t, l)
+		inv.Tax = nil
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "500.00", inv.Lines[0].Item.Price.String())
+		assert.Equal(t, "1000.00", inv.Totals.Sum.String())
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("sub-lines with own taxes", func(t *testing.T) {
+		l := bundleLine()
+		l.Quantity = num.MakeAmount(1, 0)
+		l.Breakdown[1].Taxes = tax.Set{
+			{
+				Category: tax.CategoryVAT,
+				Rate:     tax.RateSuperReduced,
+			},
+		}
+		l.Discounts = []*bill.LineDiscount{
+			{
+				Percent: num.NewPercentage(10, 2),
+			},
+		}
+		inv := baseInvoice(t, l)
+		inv.Tax = nil
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "891.00", inv.Totals.Total.String())
+		vat := inv.Totals.Taxes.Category(tax.CategoryVAT)
+		require.Len(t, vat.Rates, 2)
+		assert.Equal(t, "standard", vat.Rates[0].Key.String())
+		assert.Equal(t, "810.00", vat.Rates[0].Base.String())
+		assert.Equal(t, "170.10", vat.Rates[0].Amount.String())
+		assert.Equal(t, "super-reduced", vat.Rates[1].Key.String())
+		assert.Equal(t, "81.00", vat.Rates[1].Base.String())
+		assert.Equal(t, "3.24", vat.Rates[1].Amount.String())
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("sub-lines with own taxes rounded per line", func(t *testing.T) {
+		l := bundleLine()
+		l.Quantity = num.MakeAmount(1, 0)
+		l.Breakdown[1].Taxes = tax.Set{
+			{
+				Category: tax.CategoryVAT,
+				Rate:     tax.RateSuperReduced,
+			},
+		}
+		inv := baseInvoice(t, l)
+		inv.Tax = &bill.Tax{
+			Rounding: tax.CalculatorRoundPerLine,
+		}
+		require.NoError(t, inv.Calculate())
+		ta := inv.Lines[0].TaxAmounts
+		require.Len(t, ta, 2)
+		assert.Equal(t, tax.RateStandard, ta[0].Rate)
+		assert.Equal(t, "21.0%", ta[0].Percent.String())
+		assert.Equal(t, "189.00", ta[0].Amount.String())
+		assert.Equal(t, tax.RateSuperReduced, ta[1].Rate)
+		assert.Equal(t, "4.0%", ta[1].Percent.String())
+		assert.Equal(t, "3.60", ta[1].Amount.String())
+		assert.NoError(t, inv.Validate())
+	})
+}
+
+func TestLineBreakdownValidation(t *testing.T) {
+	t.Run("price mismatch", func(t *testing.T) {
+		inv := baseInvoice(t, bundleLine())
+		require.NoError(t, inv.Calculate())
+		inv.Lines[0].Item.Price = num.MakeAmount(100000, 2)
+		assert.ErrorContains(t, inv.Validate(), "breakdown: sum of totals 990.00 must match item price 1000.00")
+	})
+	t.Run("informative with taxes", func(t *testing.T) {
+		l := bundleLine()
+		l.Breakdown[2].Taxes = tax.Set{
+			{
+				Category: tax.CategoryVAT,
+				Rate:     tax.RateStandard,
+			},
+		}
+		inv := baseInvoice(t, l)
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "breakdown: (2: (taxes: must be blank when informative.).)")
+	})
+	t.Run("missing item", func(t *testing.T) {
+		l := bundleLine()
+		l.Breakdown[0].Item = nil
+		inv := baseInvoice(t, l)
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "breakdown: (0: (item: cannot be blank")
+	})
+}
+
+func TestLineBreakdownConvertInto(t *testing.T) {
+	inv := baseInvoice(t, bundleLine())
+	inv.Tax = nil
+	inv.ExchangeRates = []*currency.ExchangeRate{
+		{
+			From:   currency.EUR,
+			To:     currency.USD,
+			Amount: num.MakeAmount(112, 2),
+		},
+	}
+	require.NoError(t, inv.Calculate())
+
+	i2, err := inv.ConvertInto(currency.USD)
+	require.NoError(t, err)
+	l0 := i2.Lines[0]
+	assert.Equal(t, "1008.0000", l0.Breakdown[0].Item.Price.String())
+	assert.Equal(t, "EUR", l0.Breakdown[0].Item.AltPrices[0].Currency.String())
+	assert.Equal(t, "900.00", l0.Breakdown[0].Item.AltPrices[0].Value.String())
+	assert.Equal(t, "1108.8000", l0.Item.Price.String())
+	assert.Equal(t, "2217.60", i2.Totals.Sum.String())
+
+	// original left untouched
+	assert.Equal(t, "900.00", inv.Lines[0].Breakdown[0].Item.Price.String())
+	assert.Equal(t, "990.00", inv.Lines[0].Item.Price.String())
+}
+
+func TestLineBreakdownInvert(t *testing.T) {
+	inv := baseInvoice(t, bundleLine())
+	inv.Tax = nil
+	require.NoError(t, inv.Calculate())
+	require.NoError(t, inv.Invert())
+	l0 := inv.Lines[0]
+	assert.Equal(t, "-2", l0.Quantity.String())
+	assert.Equal(t, "1", l0.Breakdown[0].Quantity.String())
+	assert.Equal(t, "990.00", l0.Item.Price.String())
+	assert.Equal(t, "-1980.00", inv.Totals.Sum.String())
+	assert.Equal(t, "-2395.80", inv.Totals.Payable.String())
+}
+
+func TestLineBreakdownRemoveIncludedTaxes(t *testing.T) {
+	l := bundleLine()
+	l.Quantity = num.MakeAmount(1, 0)
+	l.Breakdown[1].Taxes = tax.Set{
+		{
+			Category: tax.CategoryVAT,
+			Rate:     tax.RateSuperReduced,
+		},
+	}
+	inv := baseInvoice(t, l)
+	require.NoError(t, inv.Calculate())
+	assert.Equal(t, "990.00", inv.Totals.Sum.String())
+
+	i2, err := inv.RemoveIncludedTaxes()
+	require.NoError(t, err)
+	l0 := i2.Lines[0]
+	assert.Equal(t, "743.8017", l0.Breakdown[0].Item.Price.String())
+	assert.Equal(t, "96.1538", l0.Breakdown[1].Item.Price.String())
+	assert.Equal(t, "16.5289", l0.Breakdown[2].Item.Price.String())
+	assert.Equal(t, "830.3401", l0.Item.Price.String())
+	assert.Equal(t, inv.Totals.Total.String(), i2.Totals.Total.String())
+	assert.Equal(t, inv.Totals.Tax.String(), i2.Totals.Tax.String())
+	assert.Equal(t, inv.Totals.Payable.String(), i2.Totals.Payable.String())
+
+	// original left untouched
+	assert.Equal(t, "900.00", inv.Lines[0].Breakdown[0].Item.Price.String())
+}
@@ -3,18 +3,26 @@ package bill
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/tax"
 	"github.com/invopop/validation"
 )
 
 // Tax defines a summary of the taxes which may be applied to an invoice.
 type Tax struct {
-	// Category of the tax already included in the line item prices, especially
+	// Categories of the taxes already included in the line item prices, especially
 	// useful for B2C retailers with customers who prefer final prices inclusive of
-	// tax.
-	PricesInclude cbc.Code `json:"prices_include,omitempty" jsonschema:"title=Prices Include"`
+	// tax. Multiple categories, such as federal and provincial taxes, are removed
+	// together.
+	PricesInclude []cbc.Code `json:"prices_include,omitempty" jsonschema:"title=Prices Include"`
+
+	// Rounding rule to use when calculating the tax totals instead of the regime's
+	// default, such as `round-per-line` when the taxes of each line must be
+	// reported individually.
+	Rounding tax.CalculatorRoundingRule `json:"rounding,omitempty" jsonschema:"title=Rounding"`
 
 	// Additional extensions that are applied to the invoice as a whole as opposed to specific
 	// sections.
@@ -53,17 +61,49 @@ func (t *Tax) Normalize(normalizers tax.Normalizers) {
 // ValidateWithContext ensures the tax details look valid.
 func (t *Tax) ValidateWithContext(ctx context.Context) error {
 	return tax.ValidateStructWithContext(ctx, t,
-		validation.Field(&t.PricesInclude),
+		validation.Field(&t.PricesInclude,
+			validation.By(checkUniqueCodes),
+		),
+		validation.Field(&t.Rounding),
 		validation.Field(&t.Ext),
 		validation.Field(&t.Meta),
 	)
 }
 
-// UnmarshalJSON helps migrate the desc field to description.
+// includedTaxDivisor provides the amount that the taxable line's total, and
+// the amounts it is composed of, should be divided by in order to remove the
+// taxes of the provided categories, or nil if none are included. The same
+// approach as the totals calculator is used so that compound taxes and
+// fixed amounts are removed consistently.
+func includedTaxDivisor(tl tax.TaxableLine, cats []cbc.Code) *num.Amount {
+	it := tax.IncludedTaxesFor(tl, cats)
+	if it == nil {
+		return nil
+	}
+	d := it.Divisor(tl.GetTotal())
+	return &d
+}
+
+func checkUniqueCodes(value any) error {
+	codes, ok := value.([]cbc.Code)
+	if !ok {
+		return nil
+	}
+	for i, c := range codes {
+		if c.In(codes[:i]...) {
+			return fmt.Errorf("duplicate category '%s'", c)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON helps migrate the tags and the single prices include
+// category used in previous versions.
 func (t *Tax) UnmarshalJSON(data []byte) error {
 	type Alias Tax
 	aux := struct {
-		Tags []cbc.Key `json:"tags,omitempty"`
+		Tags          []cbc.Key       `json:"tags,omitempty"`
+		PricesInclude json.RawMessage `json:"prices_include,omitempty"`
 		*Alias
 	}{
 		Alias: (*Alias)(t),
@@ -72,5 +112,19 @@ func (t *Tax) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	t.tags = aux.Tags
+	t.PricesInclude = nil
+	if len(aux.PricesInclude) > 0 && aux.PricesInclude[0] == '"' {
+		var code cbc.Code
+		if err := json.Unmarshal(aux.PricesInclude, &code); err != nil {
+			return err
+		}
+		if code != cbc.CodeEmpty {
+			t.PricesInclude = []cbc.Code{code}
+		}
+	} else if len(aux.PricesInclude) > 0 {
+		if err := json.Unmarshal(aux.PricesInclude, &t.PricesInclude); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -1,10 +1,12 @@
 package bill_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
 	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/tax"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -158,3 +160,49 @@ func TestTaxMergeExtensions(t *testing.T) {
 		assert.Equal(t, "bar", tx.Ext["vat-test"].String())
 	})
 }
+
+func TestTaxPricesInclude(t *testing.T) {
+	ctx := context.Background()
+	t.Run("valid", func(t *testing.T) {
+		tx := &bill.Tax{
+			PricesInclude: []cbc.Code{tax.CategoryGST, "PST"},
+		}
+		assert.NoError(t, tx.ValidateWithContext(ctx))
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		tx := &bill.Tax{
+			PricesInclude: []cbc.Code{"PST", "PST"},
+		}
+		assert.ErrorContains(t, tx.ValidateWithContext(ctx), "duplicate category 'PST'")
+	})
+	t.Run("invalid code", func(t *testing.T) {
+		tx := &bill.Tax{
+			PricesInclude: []cbc.Code{"VAT ", "$"},
+		}
+		assert.ErrorContains(t, tx.ValidateWithContext(ctx), "prices_include: (")
+	})
+}
+
+func TestTaxPricesIncludeMigration(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		out  []cbc.Code
+	}{
+		{"single code", `{"prices_include":"VAT"}`, []cbc.Code{"VAT"}},
+		{"empty code", `{"prices_include":""}`, nil},
+		{"list", `{"prices_include":["GST","PST"]}`, []cbc.Code{"GST", "PST"}},
+		{"missing", `{}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := new(bill.Tax)
+			require.NoError(t, json.Unmarshal([]byte(tt.in), tx))
+			assert.Equal(t, tt.out, tx.PricesInclude)
+		})
+	}
+	t.Run("invalid", func(t *testing.T) {
+		tx := new(bill.Tax)
+		assert.Error(t, json.Unmarshal([]byte(`{"prices_include":12}`), tx))
+	})
+}
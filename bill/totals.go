@@ -27,7 +27,8 @@ type Totals struct {
 	// Grand total after all taxes have been applied.
 	TotalWithTax num.Amount `json:"total_with_tax" jsonschema:"title=Total with Tax"`
 	// Rounding amount to apply to the invoice in case the total and payable
-	// amounts don't quite match.
+	// amounts don't quite match. Calculated automatically when the currency or
+	// regime defines a cash rounding rule for the payment means.
 	Rounding *num.Amount `json:"rounding,omitempty" jsonschema:"title=Rounding"`
 	// Total amount to be paid after applying taxes and outlays.
 	Payable num.Amount `json:"payable" jsonschema:"title=Payable"`
@@ -79,27 +80,27 @@ func (t *Totals) Paid() bool {
 }
 
 // round goes through each value that is set and rescales to match
-// the zero's exponent
-func (t *Totals) round(zero num.Amount) {
+// the zero's exponent using the rounding mode provided.
+func (t *Totals) round(zero num.Amount, mode num.RoundingMode) {
 	e := zero.Exp()
-	t.Sum = t.Sum.Rescale(e)
+	t.Sum = t.Sum.RescaleWith(e, mode)
 	if t.Discount != nil {
-		*t.Discount = t.Discount.Rescale(e)
+		*t.Discount = t.Discount.RescaleWith(e, mode)
 	}
 	if t.Charge != nil {
-		*t.Charge = t.Charge.Rescale(e)
+		*t.Charge = t.Charge.RescaleWith(e, mode)
 	}
 	if t.TaxIncluded != nil {
-		*t.TaxIncluded = t.TaxIncluded.Rescale(e)
+		*t.TaxIncluded = t.TaxIncluded.RescaleWith(e, mode)
 	}
-	t.Total = t.Total.Rescale(e)
-	t.Tax = t.Tax.Rescale(e)
-	t.TotalWithTax = t.TotalWithTax.Rescale(e)
-	t.Payable = t.Payable.Rescale(e)
+	t.Total = t.Total.RescaleWith(e, mode)
+	t.Tax = t.Tax.RescaleWith(e, mode)
+	t.TotalWithTax = t.TotalWithTax.RescaleWith(e, mode)
+	t.Payable = t.Payable.RescaleWith(e, mode)
 	if t.Advances != nil {
-		*t.Advances = t.Advances.Rescale(e)
+		*t.Advances = t.Advances.RescaleWith(e, mode)
 	}
 	if t.Due != nil {
-		*t.Due = t.Due.Rescale(e)
+		*t.Due = t.Due.RescaleWith(e, mode)
 	}
 }
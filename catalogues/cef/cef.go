@@ -15,3 +15,44 @@ const (
 	// ExtKeyVATEX is used for the CEF VATEX exemption codes.
 	ExtKeyVATEX cbc.Key = "cef-vatex"
 )
+
+// NormalizeVATEX uses the code maps defined in the values of the local
+// exemption extension identified by the key to set the VATEX code when
+// missing, or the local code from the VATEX code when only that is
+// available. The local code is only set when a single value maps to the
+// VATEX code, as choosing between several would be a guess. Extensions
+// that already contain both codes are returned untouched.
+func NormalizeVATEX(ext tax.Extensions, key cbc.Key) tax.Extensions {
+	kd := tax.ExtensionForKey(key)
+	if kd == nil {
+		return ext
+	}
+	if code := ext.Get(key); code != cbc.CodeEmpty {
+		if ext.Has(ExtKeyVATEX) {
+			return ext
+		}
+		cd := kd.CodeDef(code)
+		if cd == nil || cd.Map[ExtKeyVATEX] == cbc.CodeEmpty {
+			return ext
+		}
+		return ext.Merge(tax.Extensions{ExtKeyVATEX: cd.Map[ExtKeyVATEX]})
+	}
+	vatex := ext.Get(ExtKeyVATEX)
+	if vatex == cbc.CodeEmpty {
+		return ext
+	}
+	var match *cbc.Definition
+	for _, cd := range kd.Values {
+		if cd.Map[ExtKeyVATEX] != vatex {
+			continue
+		}
+		if match != nil {
+			return ext // ambiguous
+		}
+		match = cd
+	}
+	if match == nil {
+		return ext
+	}
+	return ext.Merge(tax.Extensions{key: match.Code})
+}
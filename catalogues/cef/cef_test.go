@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	_ "github.com/invopop/gobl"
+	"github.com/invopop/gobl/catalogues/cef"
 	"github.com/invopop/gobl/tax"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,3 +15,49 @@ func TestInit(t *testing.T) {
 	assert.NotNil(t, ed)
 	assert.Equal(t, "cef-vatex", ed.Key.String())
 }
+
+func TestNormalizeVATEX(t *testing.T) {
+	t.Run("local to VATEX", func(t *testing.T) {
+		ext := tax.Extensions{"es-tbai-exemption": "E5"}
+		ext = cef.NormalizeVATEX(ext, "es-tbai-exemption")
+		assert.Equal(t, "VATEX-EU-IC", ext.Get(cef.ExtKeyVATEX).String())
+		assert.Equal(t, "E5", ext.Get("es-tbai-exemption").String())
+	})
+	t.Run("VATEX to local", func(t *testing.T) {
+		ext := tax.Extensions{cef.ExtKeyVATEX: "VATEX-EU-IC"}
+		ext = cef.NormalizeVATEX(ext, "es-tbai-exemption")
+		assert.Equal(t, "E5", ext.Get("es-tbai-exemption").String())
+	})
+	t.Run("VATEX to ambiguous local", func(t *testing.T) {
+		ext := tax.Extensions{cef.ExtKeyVATEX: "VATEX-EU-O"}
+		ext = cef.NormalizeVATEX(ext, "es-tbai-exemption")
+		assert.False(t, ext.Has("es-tbai-exemption"))
+	})
+	t.Run("local without mapping", func(t *testing.T) {
+		ext := tax.Extensions{"es-tbai-exemption": "E6"}
+		ext = cef.NormalizeVATEX(ext, "es-tbai-exemption")
+		assert.False(t, ext.Has(cef.ExtKeyVATEX))
+	})
+	t.Run("both present", func(t *testing.T) {
+		ext := tax.Extensions{
+			"es-tbai-exemption": "E1",
+			cef.ExtKeyVATEX:     "VATEX-EU-132-1A",
+		}
+		ext = cef.NormalizeVATEX(ext, "es-tbai-exemption")
+		assert.Equal(t, "E1", ext.Get("es-tbai-exemption").String())
+		assert.Equal(t, "VATEX-EU-132-1A", ext.Get(cef.ExtKeyVATEX).String())
+	})
+	t.Run("untdid tax category", func(t *testing.T) {
+		ext := tax.Extensions{"untdid-tax-category": "K"}
+		ext = cef.NormalizeVATEX(ext, "untdid-tax-category")
+		assert.Equal(t, "VATEX-EU-IC", ext.Get(cef.ExtKeyVATEX).String())
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		ext := tax.Extensions{cef.ExtKeyVATEX: "VATEX-EU-IC"}
+		ext = cef.NormalizeVATEX(ext, "unknown")
+		assert.Len(t, ext, 1)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, cef.NormalizeVATEX(nil, "es-tbai-exemption"))
+	})
+}
@@ -33,6 +33,8 @@ func (o *rootOpts) cmd() *cobra.Command {
 	cmd.AddCommand(sign(o).cmd())
 	cmd.AddCommand(correct(o).cmd())
 	cmd.AddCommand(replicate(o).cmd())
+	cmd.AddCommand(vatReturn(o).cmd())
+	cmd.AddCommand(saftExport(o).cmd())
 	cmd.AddCommand(versionCmd())
 	cmd.AddCommand(serve().cmd())
 	cmd.AddCommand(keygen(o).cmd())
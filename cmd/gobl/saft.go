@@ -0,0 +1,154 @@
+package main
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/invopop/gobl/addons/pt/saft"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/internal/cli"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/tax"
+	"github.com/spf13/cobra"
+)
+
+type saftOpts struct {
+	*rootOpts
+	taxID          string
+	from           string
+	to             string
+	keyFile        string
+	certificate    string
+	productID      string
+	productVersion string
+	productTaxID   string
+	previousHashes map[string]string
+	seriesCodes    map[string]string
+}
+
+func saftExport(root *rootOpts) *saftOpts {
+	return &saftOpts{
+		rootOpts: root,
+	}
+}
+
+func (o *saftOpts) cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  o.runE,
+		Use:   "saft [dir] [outfile]",
+		Short: "Build a Portuguese SAF-T audit file from a directory of invoice envelopes",
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&o.taxID, "tax-id", "", "NIF of the company, e.g. 545259045")
+	f.StringVar(&o.from, "from", "", "first date of the period, e.g. 2024-01-01")
+	f.StringVar(&o.to, "to", "", "last date of the period, e.g. 2024-01-31")
+	f.StringVar(&o.keyFile, "key", "", "PEM encoded RSA private key used to sign hashes missing from envelopes")
+	f.StringVar(&o.certificate, "cert", "0", "software certificate number assigned by the AT")
+	f.StringVar(&o.productID, "product-id", "GOBL/Invopop", "software product ID, e.g. Product/Company")
+	f.StringVar(&o.productVersion, "product-version", "1.0", "software product version")
+	f.StringVar(&o.productTaxID, "product-tax-id", "", "NIF of the software producer")
+	f.StringToStringVar(&o.previousHashes, "previous-hash", nil, "hash of the last invoice before the period per series, e.g. \"FT SERIES-A=<hash>\"")
+	f.StringToStringVar(&o.seriesCodes, "series-code", nil, "AT validation code per series, e.g. \"FT SERIES-A=CSDF7T5H\"")
+
+	return cmd
+}
+
+func (o *saftOpts) runE(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	if o.inPlace {
+		return errors.New("cannot overwrite input directory")
+	}
+	opts, err := o.options(args[0])
+	if err != nil {
+		return err
+	}
+
+	out, err := o.openOutput(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer out.Close() // nolint:errcheck
+
+	af, err := cli.SAFT(ctx, opts)
+	if err != nil {
+		return err
+	}
+	data, err := af.Bytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(data)
+	return err
+}
+
+func (o *saftOpts) options(dir string) (*cli.SAFTOptions, error) {
+	if o.taxID == "" {
+		return nil, errors.New("tax-id is required")
+	}
+	start, err := parseDate(o.from)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	end, err := parseDate(o.to)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+	opts := &cli.SAFTOptions{
+		Dir: dir,
+		TaxID: &tax.Identity{
+			Country: l10n.PT.Tax(),
+			Code:    cbc.Code(o.taxID),
+		},
+		Period:         cal.Period{Start: start, End: end},
+		PreviousHashes: o.previousHashes,
+		SeriesCodes:    o.seriesCodes,
+		Software: saft.Software{
+			CompanyTaxID:      o.productTaxID,
+			CertificateNumber: o.certificate,
+			ProductID:         o.productID,
+			ProductVersion:    o.productVersion,
+		},
+	}
+	if opts.Software.CompanyTaxID == "" {
+		opts.Software.CompanyTaxID = o.taxID
+	}
+	if o.keyFile != "" {
+		opts.Key, err = readRSAKey(o.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("key: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+func readRSAKey(name string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return k, nil
+	}
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rk, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+	return rk, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_saft_options(t *testing.T) {
+	o := saftExport(root())
+	o.taxID = "545259045"
+	o.from = "2024-03-01"
+	o.to = "2024-03-31"
+
+	opts, err := o.options("invoices")
+	require.NoError(t, err)
+	assert.Equal(t, "invoices", opts.Dir)
+	assert.Equal(t, cbc.Code("545259045"), opts.TaxID.Code)
+	assert.Equal(t, "545259045", opts.Software.CompanyTaxID)
+	assert.Equal(t, "2024-03-31", opts.Period.End.String())
+	assert.Nil(t, opts.Key)
+
+	o.previousHashes = map[string]string{"FT SERIES-A": "hash-ft-1"}
+	o.seriesCodes = map[string]string{"FT SERIES-A": "CSDF7T5H"}
+	opts, err = o.options("invoices")
+	require.NoError(t, err)
+	assert.Equal(t, "hash-ft-1", opts.PreviousHashes["FT SERIES-A"])
+	assert.Equal(t, "CSDF7T5H", opts.SeriesCodes["FT SERIES-A"])
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	o.keyFile = filepath.Join(t.TempDir(), "key.pem")
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	require.NoError(t, os.WriteFile(o.keyFile, data, 0600))
+	opts, err = o.options("invoices")
+	require.NoError(t, err)
+	assert.True(t, key.Equal(opts.Key))
+
+	o.keyFile = "missing.pem"
+	_, err = o.options("invoices")
+	assert.ErrorContains(t, err, "key: ")
+
+	o.taxID = ""
+	_, err = o.options("invoices")
+	assert.EqualError(t, err, "tax-id is required")
+}
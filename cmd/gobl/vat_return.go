@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/internal/cli"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/tax"
+	"github.com/spf13/cobra"
+)
+
+type vatReturnOpts struct {
+	*rootOpts
+	taxID string
+	from  string
+	to    string
+}
+
+func vatReturn(root *rootOpts) *vatReturnOpts {
+	return &vatReturnOpts{
+		rootOpts: root,
+	}
+}
+
+func (o *vatReturnOpts) cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  o.runE,
+		Use:   "vat-return [dir] [outfile]",
+		Short: "Build a UK MTD VAT return from a directory of invoice envelopes",
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&o.taxID, "tax-id", "", "VAT registration number of the business, e.g. GB123456789")
+	f.StringVar(&o.from, "from", "", "first date of the period, e.g. 2024-01-01")
+	f.StringVar(&o.to, "to", "", "last date of the period, e.g. 2024-03-31")
+
+	return cmd
+}
+
+func (o *vatReturnOpts) runE(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	if o.inPlace {
+		return errors.New("cannot overwrite input directory")
+	}
+	opts, err := o.options(args[0])
+	if err != nil {
+		return err
+	}
+
+	out, err := o.openOutput(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer out.Close() // nolint:errcheck
+
+	vr, err := cli.VATReturn(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(out)
+	if o.indent {
+		enc.SetIndent("", "\t")
+	}
+
+	return enc.Encode(vr)
+}
+
+func (o *vatReturnOpts) options(dir string) (*cli.VATReturnOptions, error) {
+	if o.taxID == "" {
+		return nil, errors.New("tax-id is required")
+	}
+	start, err := parseDate(o.from)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	end, err := parseDate(o.to)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+	tID := &tax.Identity{
+		Country: l10n.GB.Tax(),
+		Code:    cbc.Code(o.taxID),
+	}
+	return &cli.VATReturnOptions{
+		Dir:    dir,
+		TaxID:  tID,
+		Period: cal.Period{Start: start, End: end},
+	}, nil
+}
+
+func parseDate(s string) (cal.Date, error) {
+	var d cal.Date
+	if err := d.UnmarshalText([]byte(s)); err != nil {
+		return d, err
+	}
+	return d, nil
+}
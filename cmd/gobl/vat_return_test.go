@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_vatReturn_options(t *testing.T) {
+	o := vatReturn(root())
+	o.taxID = "GB844281425"
+	o.from = "2024-01-01"
+	o.to = "2024-03-31"
+
+	opts, err := o.options("invoices")
+	require.NoError(t, err)
+	assert.Equal(t, "invoices", opts.Dir)
+	assert.Equal(t, cbc.Code("GB844281425"), opts.TaxID.Code)
+	assert.Equal(t, "2024-01-01", opts.Period.Start.String())
+	assert.Equal(t, "2024-03-31", opts.Period.End.String())
+
+	o.to = "31/03/2024"
+	_, err = o.options("invoices")
+	assert.ErrorContains(t, err, "to: ")
+
+	o.taxID = ""
+	_, err = o.options("invoices")
+	assert.EqualError(t, err, "tax-id is required")
+}
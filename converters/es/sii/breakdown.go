@@ -0,0 +1,216 @@
+package sii
+
+import (
+	"github.com/invopop/gobl/addons/es/verifactu"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+// ItemKeyGoods is used in items to identify deliveries of goods, which must
+// be separated from services in the breakdown of invoices issued to foreign
+// customers. Items with any other key are considered services.
+const ItemKeyGoods cbc.Key = "goods"
+
+// tipoDesglose prepares the tax breakdown of issued invoices, which must be
+// split by type of operation when the customer is not Spanish.
+func (b *builder) tipoDesglose() *TipoDesglose {
+	c := b.inv.Customer
+	if c == nil || c.TaxID.Country.In(l10n.ES.Tax()) {
+		return &TipoDesglose{
+			DesgloseFactura: b.desglose(b.inv.Totals.Taxes),
+		}
+	}
+	goods, services := b.splitTotals()
+	return &TipoDesglose{
+		DesgloseTipoOperacion: &DesgloseTipoOperacion{
+			PrestacionServicios: b.desglose(services),
+			Entrega:             b.desglose(goods),
+		},
+	}
+}
+
+// splitTotals calculates the tax totals for goods and services separately.
+// Invoice level discounts and charges are considered services.
+func (b *builder) splitTotals() (*tax.Total, *tax.Total) {
+	var goods, services []tax.TaxableLine
+	for _, l := range b.inv.Lines {
+		if l.Item != nil && l.Item.Key == ItemKeyGoods {
+			goods = append(goods, l.TaxableLines()...)
+		} else {
+			services = append(services, l.TaxableLines()...)
+		}
+	}
+	for _, l := range b.inv.Discounts {
+		services = append(services, l)
+	}
+	for _, l := range b.inv.Charges {
+		services = append(services, l)
+	}
+	return b.calculateTotal(goods), b.calculateTotal(services)
+}
+
+func (b *builder) calculateTotal(lines []tax.TaxableLine) *tax.Total {
+	if len(lines) == 0 {
+		return nil
+	}
+	inv := b.inv
+	date := inv.IssueDate
+	if inv.ValueDate != nil {
+		date = *inv.ValueDate
+	}
+	tc := &tax.TotalCalculator{
+		Zero:     inv.Currency.Def().Zero(),
+		Country:  inv.Regime.Country,
+		Tags:     inv.GetTags(),
+		Date:     date,
+		Lines:    lines,
+		Includes: inv.Tax.PricesInclude,
+	}
+	t := new(tax.Total)
+	if err := tc.Calculate(t); err != nil {
+		// the invoice has already been calculated successfully
+		return nil
+	}
+	return t
+}
+
+// desglose prepares the breakdown of the VAT rates of issued invoices.
+func (b *builder) desglose(t *tax.Total) *Desglose {
+	if t == nil {
+		return nil
+	}
+	ct := t.Category(tax.CategoryVAT)
+	if ct == nil {
+		return nil
+	}
+	d := new(Desglose)
+	var s1, s2 bool
+	for _, rt := range ct.Rates {
+		switch op := rt.Ext.Get(verifactu.ExtKeyOpClass); op {
+		case "S1", "S2":
+			s1 = s1 || op == "S1"
+			s2 = s2 || op == "S2"
+			ne := d.sujeta().noExenta()
+			ne.DesgloseIVA.DetalleIVA = append(ne.DesgloseIVA.DetalleIVA, b.detalleIVA(rt, true))
+		case "N1":
+			ns := d.noSujeta()
+			ns.ImportePorArticulos7_14_Otros = b.addAmount(ns.ImportePorArticulos7_14_Otros, rt.Base)
+		case "N2":
+			ns := d.noSujeta()
+			ns.ImporteTAIReglasLocalizacion = b.addAmount(ns.ImporteTAIReglasLocalizacion, rt.Base)
+		default:
+			d.sujeta().exenta().add(rt.Ext.Get(verifactu.ExtKeyExempt), b.amount(rt.Base))
+		}
+	}
+	if d.Sujeta != nil && d.Sujeta.NoExenta != nil {
+		switch {
+		case s1 && s2:
+			d.Sujeta.NoExenta.TipoNoExenta = "S3"
+		case s2:
+			d.Sujeta.NoExenta.TipoNoExenta = "S2"
+		default:
+			d.Sujeta.NoExenta.TipoNoExenta = "S1"
+		}
+	}
+	return d
+}
+
+// desgloseRecibida prepares the breakdown of the VAT rates of received
+// invoices alongside the deductible amount.
+func (b *builder) desgloseRecibida() (*DesgloseRecibida, num.Amount) {
+	d := new(DesgloseRecibida)
+	deducible := num.MakeAmount(0, 2)
+	ct := b.inv.Totals.Taxes.Category(tax.CategoryVAT)
+	for _, rt := range ct.Rates {
+		di := b.detalleIVA(rt, false)
+		if di.CuotaSoportada != nil {
+			deducible = deducible.Add(*di.CuotaSoportada)
+		}
+		if rt.Ext.Get(verifactu.ExtKeyOpClass) == "S2" {
+			if d.InversionSujetoPasivo == nil {
+				d.InversionSujetoPasivo = new(DesgloseIVA)
+			}
+			d.InversionSujetoPasivo.DetalleIVA = append(d.InversionSujetoPasivo.DetalleIVA, di)
+			continue
+		}
+		if d.DesgloseIVA == nil {
+			d.DesgloseIVA = new(DesgloseIVA)
+		}
+		d.DesgloseIVA.DetalleIVA = append(d.DesgloseIVA.DetalleIVA, di)
+	}
+	return d, deducible
+}
+
+func (b *builder) detalleIVA(rt *tax.RateTotal, issued bool) *DetalleIVA {
+	di := &DetalleIVA{
+		BaseImponible: b.amount(rt.Base),
+	}
+	if rt.Percent != nil {
+		di.TipoImpositivo = percentRef(rt.Percent)
+		if issued {
+			di.CuotaRepercutida = b.amountRef(rt.Amount)
+		} else {
+			di.CuotaSoportada = b.amountRef(rt.Amount)
+		}
+	}
+	if rt.Surcharge != nil {
+		di.TipoRecargoEquivalencia = percentRef(&rt.Surcharge.Percent)
+		di.CuotaRecargoEquivalencia = b.amountRef(rt.Surcharge.Amount)
+	}
+	return di
+}
+
+func (b *builder) addAmount(a *num.Amount, x num.Amount) *num.Amount {
+	x = b.amount(x)
+	if a != nil {
+		x = a.Add(x)
+	}
+	return &x
+}
+
+func (d *Desglose) sujeta() *Sujeta {
+	if d.Sujeta == nil {
+		d.Sujeta = new(Sujeta)
+	}
+	return d.Sujeta
+}
+
+func (d *Desglose) noSujeta() *NoSujeta {
+	if d.NoSujeta == nil {
+		d.NoSujeta = new(NoSujeta)
+	}
+	return d.NoSujeta
+}
+
+func (s *Sujeta) noExenta() *NoExenta {
+	if s.NoExenta == nil {
+		s.NoExenta = &NoExenta{
+			DesgloseIVA: new(DesgloseIVA),
+		}
+	}
+	return s.NoExenta
+}
+
+func (s *Sujeta) exenta() *Exenta {
+	if s.Exenta == nil {
+		s.Exenta = new(Exenta)
+	}
+	return s.Exenta
+}
+
+// add includes the base for the exemption cause, grouping amounts with the
+// same cause.
+func (e *Exenta) add(cause cbc.Code, base num.Amount) {
+	for _, de := range e.DetalleExenta {
+		if de.CausaExencion == cause.String() {
+			de.BaseImponible = de.BaseImponible.Add(base)
+			return
+		}
+	}
+	e.DetalleExenta = append(e.DetalleExenta, &DetalleExenta{
+		CausaExencion: cause.String(),
+		BaseImponible: base,
+	})
+}
@@ -0,0 +1,288 @@
+package sii
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/invopop/gobl/addons/es/verifactu"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+)
+
+// descriptionMaxLength is the maximum number of characters accepted in
+// the operation description.
+const descriptionMaxLength = 500
+
+// euCountries contains the tax country codes of the EU member states other
+// than Spain, whose VAT numbers are identified with the "02" (NIF-IVA) type.
+var euCountries = []l10n.TaxCountryCode{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "EL", "FI", "FR", "HR", "HU",
+	"IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK",
+}
+
+// Identity types used for foreign parties.
+const (
+	idTypeVAT   = "02" // NIF-IVA
+	idTypeOther = "06" // Otro documento probatorio
+)
+
+// builder holds the state shared while preparing a record.
+type builder struct {
+	inv    *bill.Invoice
+	negate bool
+}
+
+// NewIssuedRecord builds the SII record for an invoice issued by the
+// supplier, which must be identified with a Spanish NIF. Amounts are
+// converted into EUR using the invoice's exchange rates if needed, and
+// credit notes of the differences ("I") type are reported with negative
+// amounts.
+//
+// Any data missing from the invoice is reported as validation errors.
+func NewIssuedRecord(inv *bill.Invoice) (*IssuedRecord, error) {
+	b, err := newBuilder(inv, true)
+	if err != nil {
+		return nil, err
+	}
+	inv = b.inv
+	rec := &IssuedRecord{
+		PeriodoLiquidacion: newPeriodoLiquidacion(inv.IssueDate),
+		IDFactura:          b.idFactura(),
+		FacturaExpedida: &FacturaExpedida{
+			TipoFactura:          inv.Tax.Ext.Get(verifactu.ExtKeyDocType).String(),
+			TipoRectificativa:    b.tipoRectificativa(),
+			FacturasRectificadas: b.facturasRectificadas(),
+			ImporteRectificacion: b.importeRectificacion(),
+			FechaOperacion:       b.fechaOperacion(),
+			ImporteTotal:         b.importeTotal(),
+			DescripcionOperacion: b.descripcionOperacion(),
+			Contraparte:          newContraparte(inv.Customer),
+			TipoDesglose:         b.tipoDesglose(),
+		},
+	}
+	fe := rec.FacturaExpedida
+	fe.ClaveRegimenEspecialOTrascendencia,
+		fe.ClaveRegimenEspecialOTrascendenciaAdicional1,
+		fe.ClaveRegimenEspecialOTrascendenciaAdicional2 = b.clavesRegimen()
+	return rec, nil
+}
+
+// NewReceivedRecord builds the SII record for an invoice received by the
+// customer, which must be identified with a Spanish NIF, and registered in
+// its accounts on the provided date. The VAT borne is assumed to be fully
+// deductible.
+//
+// Any data missing from the invoice is reported as validation errors.
+func NewReceivedRecord(inv *bill.Invoice, registered cal.Date) (*ReceivedRecord, error) {
+	b, err := newBuilder(inv, false)
+	if err != nil {
+		return nil, err
+	}
+	inv = b.inv
+	desglose, deducible := b.desgloseRecibida()
+	rec := &ReceivedRecord{
+		PeriodoLiquidacion: newPeriodoLiquidacion(registered),
+		IDFactura:          b.idFactura(),
+		FacturaRecibida: &FacturaRecibida{
+			TipoFactura:          inv.Tax.Ext.Get(verifactu.ExtKeyDocType).String(),
+			TipoRectificativa:    b.tipoRectificativa(),
+			FacturasRectificadas: b.facturasRectificadas(),
+			ImporteRectificacion: b.importeRectificacion(),
+			FechaOperacion:       b.fechaOperacion(),
+			ImporteTotal:         b.importeTotal(),
+			DescripcionOperacion: b.descripcionOperacion(),
+			DesgloseFactura:      desglose,
+			Contraparte:          newContraparte(inv.Supplier),
+			FechaRegContable:     registered.Time().Format(dateFormat),
+			CuotaDeducible:       deducible,
+		},
+	}
+	fr := rec.FacturaRecibida
+	fr.ClaveRegimenEspecialOTrascendencia,
+		fr.ClaveRegimenEspecialOTrascendenciaAdicional1,
+		fr.ClaveRegimenEspecialOTrascendenciaAdicional2 = b.clavesRegimen()
+	return rec, nil
+}
+
+func newBuilder(inv *bill.Invoice, issued bool) (*builder, error) {
+	inv, err := inv.ConvertInto(currency.EUR)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateInvoice(inv, issued); err != nil {
+		return nil, err
+	}
+	b := &builder{
+		inv: inv,
+		negate: inv.Type == bill.InvoiceTypeCreditNote &&
+			inv.Tax.Ext.Get(verifactu.ExtKeyCorrectionType) == "I",
+	}
+	return b, nil
+}
+
+func newPeriodoLiquidacion(date cal.Date) *PeriodoLiquidacion {
+	return &PeriodoLiquidacion{
+		Ejercicio: fmt.Sprintf("%04d", date.Year),
+		Periodo:   fmt.Sprintf("%02d", int(date.Month)),
+	}
+}
+
+func (b *builder) idFactura() *IDFactura {
+	return &IDFactura{
+		IDEmisorFactura:              newIDEmisorFactura(b.inv.Supplier),
+		NumSerieFacturaEmisor:        b.inv.Series.Join(b.inv.Code).String(),
+		FechaExpedicionFacturaEmisor: b.inv.IssueDate.Time().Format(dateFormat),
+	}
+}
+
+func newIDEmisorFactura(p *org.Party) *IDEmisorFactura {
+	if p.TaxID.Country.In(l10n.ES.Tax()) {
+		return &IDEmisorFactura{NIF: p.TaxID.Code.String()}
+	}
+	return &IDEmisorFactura{IDOtro: newIDOtro(p.TaxID)}
+}
+
+func newContraparte(p *org.Party) *Contraparte {
+	if p == nil {
+		return nil
+	}
+	c := &Contraparte{NombreRazon: p.Name}
+	if p.TaxID.Country.In(l10n.ES.Tax()) {
+		c.NIF = p.TaxID.Code.String()
+	} else {
+		c.IDOtro = newIDOtro(p.TaxID)
+	}
+	return c
+}
+
+func newIDOtro(tID *tax.Identity) *IDOtro {
+	id := &IDOtro{
+		CodigoPais: tID.Country.String(),
+		IDType:     idTypeOther,
+		ID:         tID.Code.String(),
+	}
+	if tID.Country.In(euCountries...) {
+		// EU VAT numbers include the country prefix
+		id.IDType = idTypeVAT
+		id.ID = tID.Country.String() + id.ID
+	}
+	return id
+}
+
+func (b *builder) tipoRectificativa() string {
+	if !b.isCorrection() {
+		return ""
+	}
+	return b.inv.Tax.Ext.Get(verifactu.ExtKeyCorrectionType).String()
+}
+
+func (b *builder) isCorrection() bool {
+	return b.inv.Tax.Ext.Get(verifactu.ExtKeyDocType).In(docTypesCorrection...)
+}
+
+func (b *builder) facturasRectificadas() []*FacturaRectificada {
+	if !b.isCorrection() {
+		return nil
+	}
+	list := make([]*FacturaRectificada, len(b.inv.Preceding))
+	for i, p := range b.inv.Preceding {
+		list[i] = &FacturaRectificada{
+			NumSerieFacturaEmisor:        p.Series.Join(p.Code).String(),
+			FechaExpedicionFacturaEmisor: p.IssueDate.Time().Format(dateFormat),
+		}
+	}
+	return list
+}
+
+// importeRectificacion is required for substitutions, which are expected to
+// contain the full amounts of the new invoice, so the amounts of the
+// rectified invoice are reported as zero.
+func (b *builder) importeRectificacion() *ImporteRectificacion {
+	if !b.isCorrection() || b.tipoRectificativa() != "S" {
+		return nil
+	}
+	zero := num.MakeAmount(0, 2)
+	return &ImporteRectificacion{
+		BaseRectificada:  zero,
+		CuotaRectificada: zero,
+	}
+}
+
+func (b *builder) fechaOperacion() string {
+	od := b.inv.OperationDate
+	if od == nil || od.IsZero() || od.Date == b.inv.IssueDate.Date {
+		return ""
+	}
+	return od.Time().Format(dateFormat)
+}
+
+// importeTotal provides the invoice total including taxes, but without
+// subtracting any retained taxes.
+func (b *builder) importeTotal() num.Amount {
+	t := b.inv.Totals
+	total := t.Total
+	if t.Taxes != nil {
+		for _, ct := range t.Taxes.Categories {
+			if ct.Retained {
+				continue
+			}
+			total = total.Add(ct.Amount)
+			if ct.Surcharge != nil {
+				total = total.Add(*ct.Surcharge)
+			}
+		}
+	}
+	return b.amount(total)
+}
+
+func (b *builder) descripcionOperacion() string {
+	var desc string
+	for _, n := range b.inv.Notes {
+		if n.Key == cbc.NoteKeyGeneral {
+			desc = n.Text
+			break
+		}
+	}
+	if utf8.RuneCountInString(desc) > descriptionMaxLength {
+		desc = string([]rune(desc)[:descriptionMaxLength])
+	}
+	return desc
+}
+
+// clavesRegimen provides the main and additional regime keys.
+func (b *builder) clavesRegimen() (string, string, string) {
+	keys := make([]string, maxRegimeKeys)
+	for i, k := range regimeKeys(b.inv.Totals.Taxes.Category(tax.CategoryVAT)) {
+		keys[i] = k.String()
+	}
+	return keys[0], keys[1], keys[2]
+}
+
+// amount rescales the amount to the precision used by the SII, and inverts it
+// if required.
+func (b *builder) amount(a num.Amount) num.Amount {
+	a = a.Rescale(2)
+	if b.negate {
+		return a.Invert()
+	}
+	return a
+}
+
+func (b *builder) amountRef(a num.Amount) *num.Amount {
+	a = b.amount(a)
+	return &a
+}
+
+func percentRef(p *num.Percentage) *num.Amount {
+	if p == nil {
+		return nil
+	}
+	a := p.Amount().Rescale(2)
+	return &a
+}
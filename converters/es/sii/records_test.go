@@ -0,0 +1,407 @@
+package sii_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/invopop/gobl/converters/es/sii"
+	"github.com/invopop/gobl/addons/es/verifactu"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInvoice(t *testing.T) *bill.Invoice {
+	t.Helper()
+	inv := &bill.Invoice{
+		Addons:    tax.WithAddons(verifactu.V1),
+		Series:    "SAMPLE",
+		Code:      "004",
+		IssueDate: cal.MakeDate(2024, 12, 4),
+		Supplier: &org.Party{
+			Name: "Provide One S.L.",
+			TaxID: &tax.Identity{
+				Country: "ES",
+				Code:    "B98602642",
+			},
+		},
+		Customer: &org.Party{
+			Name: "Sample Consumer",
+			TaxID: &tax.Identity{
+				Country: "ES",
+				Code:    "54387763P",
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(20, 0),
+				Item: &org.Item{
+					Name:  "Development services",
+					Price: num.MakeAmount(9000, 2),
+				},
+				Taxes: tax.Set{
+					{
+						Category: tax.CategoryVAT,
+						Rate:     tax.RateStandard,
+					},
+				},
+			},
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Training course",
+					Price: num.MakeAmount(30000, 2),
+				},
+				Taxes: tax.Set{
+					{
+						Category: tax.CategoryVAT,
+						Rate:     tax.RateExempt,
+						Ext: tax.Extensions{
+							verifactu.ExtKeyExempt: "E1",
+						},
+					},
+				},
+			},
+		},
+		Notes: []*cbc.Note{
+			{
+				Key:  cbc.NoteKeyGeneral,
+				Text: "Development and training services",
+			},
+		},
+	}
+	require.NoError(t, inv.Calculate())
+	return inv
+}
+
+func TestNewIssuedRecord(t *testing.T) {
+	t.Run("domestic invoice", func(t *testing.T) {
+		inv := testInvoice(t)
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+
+		assert.Equal(t, "2024", rec.PeriodoLiquidacion.Ejercicio)
+		assert.Equal(t, "12", rec.PeriodoLiquidacion.Periodo)
+		assert.Equal(t, "B98602642", rec.IDFactura.IDEmisorFactura.NIF)
+		assert.Equal(t, "SAMPLE-004", rec.IDFactura.NumSerieFacturaEmisor)
+		assert.Equal(t, "04-12-2024", rec.IDFactura.FechaExpedicionFacturaEmisor)
+
+		fe := rec.FacturaExpedida
+		assert.Equal(t, "F1", fe.TipoFactura)
+		assert.Empty(t, fe.TipoRectificativa)
+		assert.Equal(t, "01", fe.ClaveRegimenEspecialOTrascendencia)
+		assert.Empty(t, fe.ClaveRegimenEspecialOTrascendenciaAdicional1)
+		assert.Equal(t, "2478.00", fe.ImporteTotal.String())
+		assert.Equal(t, "Development and training services", fe.DescripcionOperacion)
+		assert.Equal(t, "Sample Consumer", fe.Contraparte.NombreRazon)
+		assert.Equal(t, "54387763P", fe.Contraparte.NIF)
+
+		d := fe.TipoDesglose.DesgloseFactura
+		require.NotNil(t, d)
+		assert.Nil(t, fe.TipoDesglose.DesgloseTipoOperacion)
+		assert.Nil(t, d.NoSujeta)
+		require.NotNil(t, d.Sujeta.NoExenta)
+		assert.Equal(t, "S1", d.Sujeta.NoExenta.TipoNoExenta)
+		di := d.Sujeta.NoExenta.DesgloseIVA.DetalleIVA
+		require.Len(t, di, 1)
+		assert.Equal(t, "21.00", di[0].TipoImpositivo.String())
+		assert.Equal(t, "1800.00", di[0].BaseImponible.String())
+		assert.Equal(t, "378.00", di[0].CuotaRepercutida.String())
+		assert.Nil(t, di[0].CuotaSoportada)
+		de := d.Sujeta.Exenta.DetalleExenta
+		require.Len(t, de, 1)
+		assert.Equal(t, "E1", de[0].CausaExencion)
+		assert.Equal(t, "300.00", de[0].BaseImponible.String())
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		inv := testInvoice(t)
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+		data, err := json.Marshal(rec.FacturaExpedida.TipoDesglose.DesgloseFactura.Sujeta.Exenta)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"DetalleExenta":[{"CausaExencion":"E1","BaseImponible":"300.00"}]}`, string(data))
+	})
+
+	t.Run("foreign customer with goods and services", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer.TaxID = &tax.Identity{
+			Country: "NL",
+			Code:    "000099995B57",
+		}
+		inv.Lines[1].Item.Key = sii.ItemKeyGoods
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+
+		fe := rec.FacturaExpedida
+		assert.Empty(t, fe.Contraparte.NIF)
+		assert.Equal(t, &sii.IDOtro{CodigoPais: "NL", IDType: "02", ID: "NL000099995B57"}, fe.Contraparte.IDOtro)
+		assert.Nil(t, fe.TipoDesglose.DesgloseFactura)
+		dto := fe.TipoDesglose.DesgloseTipoOperacion
+		require.NotNil(t, dto)
+		assert.Equal(t, "1800.00", dto.PrestacionServicios.Sujeta.NoExenta.DesgloseIVA.DetalleIVA[0].BaseImponible.String())
+		assert.Nil(t, dto.PrestacionServicios.Sujeta.Exenta)
+		assert.Equal(t, "300.00", dto.Entrega.Sujeta.Exenta.DetalleExenta[0].BaseImponible.String())
+		assert.Nil(t, dto.Entrega.Sujeta.NoExenta)
+	})
+
+	t.Run("non-EU customer", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer.TaxID = &tax.Identity{
+			Country: "US",
+			Code:    "123456789",
+		}
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+		assert.Equal(t, &sii.IDOtro{CodigoPais: "US", IDType: "06", ID: "123456789"}, rec.FacturaExpedida.Contraparte.IDOtro)
+	})
+
+	t.Run("not subject operations", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Lines[1].Taxes[0].Rate = cbc.KeyEmpty
+		inv.Lines[1].Taxes[0].Ext = tax.Extensions{
+			verifactu.ExtKeyOpClass: "N2",
+		}
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+		d := rec.FacturaExpedida.TipoDesglose.DesgloseFactura
+		assert.Nil(t, d.Sujeta.Exenta)
+		assert.Nil(t, d.NoSujeta.ImportePorArticulos7_14_Otros)
+		assert.Equal(t, "300.00", d.NoSujeta.ImporteTAIReglasLocalizacion.String())
+	})
+
+	t.Run("simplified invoice", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.SetTags(tax.TagSimplified)
+		inv.Customer = nil
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+		assert.Equal(t, "F2", rec.FacturaExpedida.TipoFactura)
+		assert.Nil(t, rec.FacturaExpedida.Contraparte)
+		assert.NotNil(t, rec.FacturaExpedida.TipoDesglose.DesgloseFactura)
+	})
+
+	t.Run("credit note", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Type = bill.InvoiceTypeCreditNote
+		inv.Code = "005"
+		inv.Preceding = []*org.DocumentRef{
+			{
+				Series:    "SAMPLE",
+				Code:      "001",
+				IssueDate: cal.NewDate(2024, 11, 20),
+			},
+		}
+		inv.Tax = &bill.Tax{
+			Ext: tax.Extensions{
+				verifactu.ExtKeyDocType: "R1",
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+
+		fe := rec.FacturaExpedida
+		assert.Equal(t, "R1", fe.TipoFactura)
+		assert.Equal(t, "I", fe.TipoRectificativa)
+		assert.Nil(t, fe.ImporteRectificacion)
+		require.Len(t, fe.FacturasRectificadas, 1)
+		assert.Equal(t, "SAMPLE-001", fe.FacturasRectificadas[0].NumSerieFacturaEmisor)
+		assert.Equal(t, "20-11-2024", fe.FacturasRectificadas[0].FechaExpedicionFacturaEmisor)
+		assert.Equal(t, "-2478.00", fe.ImporteTotal.String())
+		di := fe.TipoDesglose.DesgloseFactura.Sujeta.NoExenta.DesgloseIVA.DetalleIVA[0]
+		assert.Equal(t, "-1800.00", di.BaseImponible.String())
+		assert.Equal(t, "-378.00", di.CuotaRepercutida.String())
+		assert.Equal(t, "21.00", di.TipoImpositivo.String())
+	})
+
+	t.Run("corrective invoice", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Type = bill.InvoiceTypeCorrective
+		inv.Preceding = []*org.DocumentRef{
+			{
+				Series:    "SAMPLE",
+				Code:      "001",
+				IssueDate: cal.NewDate(2024, 11, 20),
+			},
+		}
+		inv.Tax = &bill.Tax{
+			Ext: tax.Extensions{
+				verifactu.ExtKeyDocType: "R1",
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+		fe := rec.FacturaExpedida
+		assert.Equal(t, "S", fe.TipoRectificativa)
+		require.NotNil(t, fe.ImporteRectificacion)
+		assert.Equal(t, "0.00", fe.ImporteRectificacion.BaseRectificada.String())
+		assert.Equal(t, "2478.00", fe.ImporteTotal.String())
+	})
+
+	t.Run("operation date", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.OperationDate = cal.NewDate(2024, 11, 30)
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+		assert.Equal(t, "30-11-2024", rec.FacturaExpedida.FechaOperacion)
+	})
+
+	t.Run("foreign currency", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Currency = currency.USD
+		inv.ExchangeRates = []*currency.ExchangeRate{
+			{
+				From:   currency.USD,
+				To:     currency.EUR,
+				Amount: num.MakeAmount(5, 1),
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+		assert.Equal(t, "1239.00", rec.FacturaExpedida.ImporteTotal.String())
+	})
+
+	t.Run("retained taxes not subtracted", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Lines[0].Taxes = append(inv.Lines[0].Taxes, &tax.Combo{
+			Category: "IRPF",
+			Rate:     "pro",
+		})
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewIssuedRecord(inv)
+		require.NoError(t, err)
+		assert.Equal(t, "2478.00", rec.FacturaExpedida.ImporteTotal.String())
+	})
+}
+
+func TestNewReceivedRecord(t *testing.T) {
+	t.Run("domestic invoice", func(t *testing.T) {
+		inv := testInvoice(t)
+		rec, err := sii.NewReceivedRecord(inv, cal.MakeDate(2025, 1, 3))
+		require.NoError(t, err)
+
+		assert.Equal(t, "2025", rec.PeriodoLiquidacion.Ejercicio)
+		assert.Equal(t, "01", rec.PeriodoLiquidacion.Periodo)
+		assert.Equal(t, "B98602642", rec.IDFactura.IDEmisorFactura.NIF)
+
+		fr := rec.FacturaRecibida
+		assert.Equal(t, "F1", fr.TipoFactura)
+		assert.Equal(t, "03-01-2025", fr.FechaRegContable)
+		assert.Equal(t, "Provide One S.L.", fr.Contraparte.NombreRazon)
+		assert.Equal(t, "B98602642", fr.Contraparte.NIF)
+		assert.Equal(t, "378.00", fr.CuotaDeducible.String())
+		assert.Nil(t, fr.DesgloseFactura.InversionSujetoPasivo)
+		di := fr.DesgloseFactura.DesgloseIVA.DetalleIVA
+		require.Len(t, di, 2)
+		assert.Equal(t, "378.00", di[0].CuotaSoportada.String())
+		assert.Nil(t, di[0].CuotaRepercutida)
+		assert.Nil(t, di[1].TipoImpositivo)
+		assert.Equal(t, "300.00", di[1].BaseImponible.String())
+	})
+
+	t.Run("foreign supplier", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.TaxID = &tax.Identity{
+			Country: "PT",
+			Code:    "545259045",
+		}
+		inv.Lines = inv.Lines[:1]
+		inv.Lines[0].Taxes[0].Rate = cbc.KeyEmpty
+		inv.Lines[0].Taxes[0].Ext = tax.Extensions{
+			verifactu.ExtKeyOpClass: "S2",
+		}
+		require.NoError(t, inv.Calculate())
+		rec, err := sii.NewReceivedRecord(inv, cal.MakeDate(2024, 12, 10))
+		require.NoError(t, err)
+		assert.Equal(t, &sii.IDOtro{CodigoPais: "PT", IDType: "02", ID: "PT545259045"}, rec.IDFactura.IDEmisorFactura.IDOtro)
+		fr := rec.FacturaRecibida
+		assert.Nil(t, fr.DesgloseFactura.DesgloseIVA)
+		require.NotNil(t, fr.DesgloseFactura.InversionSujetoPasivo)
+		assert.Equal(t, "378.00", fr.CuotaDeducible.String())
+	})
+
+	t.Run("customer must be Spanish", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer.TaxID = &tax.Identity{
+			Country: "NL",
+			Code:    "000099995B57",
+		}
+		_, err := sii.NewReceivedRecord(inv, cal.MakeDate(2024, 12, 10))
+		assert.ErrorContains(t, err, "customer: (tax_id: must be Spanish.)")
+	})
+}
+
+func TestRecordValidation(t *testing.T) {
+	t.Run("supplier must be Spanish", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Supplier.TaxID = &tax.Identity{
+			Country: "PT",
+			Code:    "545259045",
+		}
+		_, err := sii.NewIssuedRecord(inv)
+		assert.ErrorContains(t, err, "supplier: (tax_id: must be Spanish.)")
+	})
+
+	t.Run("missing customer tax ID code", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer.TaxID.Code = ""
+		_, err := sii.NewIssuedRecord(inv)
+		assert.ErrorContains(t, err, "customer: (tax_id: (code: cannot be blank.).)")
+	})
+
+	t.Run("missing customer", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Customer = nil
+		_, err := sii.NewIssuedRecord(inv)
+		assert.ErrorContains(t, err, "customer: cannot be blank")
+	})
+
+	t.Run("missing description", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Notes = nil
+		_, err := sii.NewIssuedRecord(inv)
+		assert.ErrorContains(t, err, "notes: with key 'general' missing")
+	})
+
+	t.Run("missing doc type", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Addons = tax.Addons{}
+		inv.Tax = nil
+		_, err := sii.NewIssuedRecord(inv)
+		assert.ErrorContains(t, err, "tax: cannot be blank")
+	})
+
+	t.Run("missing regime key", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Addons = tax.Addons{}
+		inv.Lines[0].Taxes[0].Ext = nil
+		require.NoError(t, inv.Calculate())
+		_, err := sii.NewIssuedRecord(inv)
+		assert.ErrorContains(t, err, "totals: (taxes: (categories: (0: (rates: (0: (ext: (es-verifactu-regime: required.)")
+	})
+
+	t.Run("missing preceding", func(t *testing.T) {
+		inv := testInvoice(t)
+		inv.Type = bill.InvoiceTypeCreditNote
+		inv.Tax = &bill.Tax{
+			Ext: tax.Extensions{
+				verifactu.ExtKeyDocType: "R1",
+			},
+		}
+		_, err := sii.NewIssuedRecord(inv)
+		assert.ErrorContains(t, err, "preceding: cannot be blank")
+	})
+}
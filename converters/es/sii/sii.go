@@ -0,0 +1,188 @@
+// Package sii provides a builder to convert GOBL invoices into the ledger
+// records expected by the Spanish SII (Suministro Inmediato de Información),
+// used by companies to report their issued and received invoice books to the
+// AEAT within four days.
+//
+// The SII shares most of its code lists with Verifactu, so invoices are
+// expected to have been calculated with the `es-verifactu-v1` addon, whose
+// extensions provide the document types, operation classes, exemption causes
+// and regime keys used in the records.
+package sii
+
+import (
+	"github.com/invopop/gobl/num"
+)
+
+// dateFormat is the date layout used by the SII.
+const dateFormat = "02-01-2006"
+
+// IssuedRecord represents the "RegistroLRFacturasEmitidas" entry of the
+// issued invoices book.
+type IssuedRecord struct {
+	PeriodoLiquidacion *PeriodoLiquidacion `json:"PeriodoLiquidacion"`
+	IDFactura          *IDFactura          `json:"IDFactura"`
+	FacturaExpedida    *FacturaExpedida    `json:"FacturaExpedida"`
+}
+
+// ReceivedRecord represents the "RegistroLRFacturasRecibidas" entry of the
+// received invoices book.
+type ReceivedRecord struct {
+	PeriodoLiquidacion *PeriodoLiquidacion `json:"PeriodoLiquidacion"`
+	IDFactura          *IDFactura          `json:"IDFactura"`
+	FacturaRecibida    *FacturaRecibida    `json:"FacturaRecibida"`
+}
+
+// PeriodoLiquidacion defines the tax period the record belongs to.
+type PeriodoLiquidacion struct {
+	Ejercicio string `json:"Ejercicio"`
+	Periodo   string `json:"Periodo"`
+}
+
+// IDFactura identifies the invoice by its issuer, number and date.
+type IDFactura struct {
+	IDEmisorFactura              *IDEmisorFactura `json:"IDEmisorFactura"`
+	NumSerieFacturaEmisor        string           `json:"NumSerieFacturaEmisor"`
+	FechaExpedicionFacturaEmisor string           `json:"FechaExpedicionFacturaEmisor"`
+}
+
+// IDEmisorFactura identifies the issuer of the invoice.
+type IDEmisorFactura struct {
+	NIF    string  `json:"NIF,omitempty"`
+	IDOtro *IDOtro `json:"IDOtro,omitempty"`
+}
+
+// IDOtro identifies foreign parties without a Spanish NIF.
+type IDOtro struct {
+	CodigoPais string `json:"CodigoPais"`
+	IDType     string `json:"IDType"`
+	ID         string `json:"ID"`
+}
+
+// Contraparte is the other party of the operation: the customer in issued
+// invoices, or the supplier in received invoices.
+type Contraparte struct {
+	NombreRazon string  `json:"NombreRazon"`
+	NIF         string  `json:"NIF,omitempty"`
+	IDOtro      *IDOtro `json:"IDOtro,omitempty"`
+}
+
+// FacturaRectificada identifies a corrected invoice.
+type FacturaRectificada struct {
+	NumSerieFacturaEmisor        string `json:"NumSerieFacturaEmisor"`
+	FechaExpedicionFacturaEmisor string `json:"FechaExpedicionFacturaEmisor"`
+}
+
+// ImporteRectificacion contains the amounts of the original invoice replaced
+// by a corrective invoice of the substitution ("S") type.
+type ImporteRectificacion struct {
+	BaseRectificada  num.Amount `json:"BaseRectificada"`
+	CuotaRectificada num.Amount `json:"CuotaRectificada"`
+}
+
+// FacturaExpedida contains the details of an issued invoice.
+type FacturaExpedida struct {
+	TipoFactura                                  string                `json:"TipoFactura"`
+	TipoRectificativa                            string                `json:"TipoRectificativa,omitempty"`
+	FacturasRectificadas                         []*FacturaRectificada `json:"FacturasRectificadas,omitempty"`
+	ImporteRectificacion                         *ImporteRectificacion `json:"ImporteRectificacion,omitempty"`
+	FechaOperacion                               string                `json:"FechaOperacion,omitempty"`
+	ClaveRegimenEspecialOTrascendencia           string                `json:"ClaveRegimenEspecialOTrascendencia"`
+	ClaveRegimenEspecialOTrascendenciaAdicional1 string                `json:"ClaveRegimenEspecialOTrascendenciaAdicional1,omitempty"`
+	ClaveRegimenEspecialOTrascendenciaAdicional2 string                `json:"ClaveRegimenEspecialOTrascendenciaAdicional2,omitempty"`
+	ImporteTotal                                 num.Amount            `json:"ImporteTotal"`
+	DescripcionOperacion                         string                `json:"DescripcionOperacion"`
+	Contraparte                                  *Contraparte          `json:"Contraparte,omitempty"`
+	TipoDesglose                                 *TipoDesglose         `json:"TipoDesglose"`
+}
+
+// FacturaRecibida contains the details of a received invoice.
+type FacturaRecibida struct {
+	TipoFactura                                  string                `json:"TipoFactura"`
+	TipoRectificativa                            string                `json:"TipoRectificativa,omitempty"`
+	FacturasRectificadas                         []*FacturaRectificada `json:"FacturasRectificadas,omitempty"`
+	ImporteRectificacion                         *ImporteRectificacion `json:"ImporteRectificacion,omitempty"`
+	FechaOperacion                               string                `json:"FechaOperacion,omitempty"`
+	ClaveRegimenEspecialOTrascendencia           string                `json:"ClaveRegimenEspecialOTrascendencia"`
+	ClaveRegimenEspecialOTrascendenciaAdicional1 string                `json:"ClaveRegimenEspecialOTrascendenciaAdicional1,omitempty"`
+	ClaveRegimenEspecialOTrascendenciaAdicional2 string                `json:"ClaveRegimenEspecialOTrascendenciaAdicional2,omitempty"`
+	ImporteTotal                                 num.Amount            `json:"ImporteTotal"`
+	DescripcionOperacion                         string                `json:"DescripcionOperacion"`
+	DesgloseFactura                              *DesgloseRecibida     `json:"DesgloseFactura"`
+	Contraparte                                  *Contraparte          `json:"Contraparte"`
+	FechaRegContable                             string                `json:"FechaRegContable"`
+	CuotaDeducible                               num.Amount            `json:"CuotaDeducible"`
+}
+
+// TipoDesglose contains the tax breakdown of an issued invoice, either for
+// the whole invoice when the customer is Spanish or not identified, or by
+// type of operation otherwise.
+type TipoDesglose struct {
+	DesgloseFactura       *Desglose              `json:"DesgloseFactura,omitempty"`
+	DesgloseTipoOperacion *DesgloseTipoOperacion `json:"DesgloseTipoOperacion,omitempty"`
+}
+
+// DesgloseTipoOperacion splits the tax breakdown into services and goods.
+type DesgloseTipoOperacion struct {
+	PrestacionServicios *Desglose `json:"PrestacionServicios,omitempty"`
+	Entrega             *Desglose `json:"Entrega,omitempty"`
+}
+
+// Desglose contains the subject and not subject amounts of issued invoices.
+type Desglose struct {
+	Sujeta   *Sujeta   `json:"Sujeta,omitempty"`
+	NoSujeta *NoSujeta `json:"NoSujeta,omitempty"`
+}
+
+// Sujeta contains the exempt and not exempt amounts subject to VAT.
+type Sujeta struct {
+	Exenta   *Exenta   `json:"Exenta,omitempty"`
+	NoExenta *NoExenta `json:"NoExenta,omitempty"`
+}
+
+// Exenta lists the exempt bases by exemption cause.
+type Exenta struct {
+	DetalleExenta []*DetalleExenta `json:"DetalleExenta"`
+}
+
+// DetalleExenta contains the exempt base for an exemption cause.
+type DetalleExenta struct {
+	CausaExencion string     `json:"CausaExencion"`
+	BaseImponible num.Amount `json:"BaseImponible"`
+}
+
+// NoExenta contains the taxed amounts, with the type of not exempt
+// operation: "S1" without reverse charge, "S2" with reverse charge, or
+// "S3" for both.
+type NoExenta struct {
+	TipoNoExenta string       `json:"TipoNoExenta"`
+	DesgloseIVA  *DesgloseIVA `json:"DesgloseIVA"`
+}
+
+// NoSujeta contains the amounts not subject to VAT.
+type NoSujeta struct {
+	ImportePorArticulos7_14_Otros *num.Amount `json:"ImportePorArticulos7_14_Otros,omitempty"` //nolint:revive
+	ImporteTAIReglasLocalizacion  *num.Amount `json:"ImporteTAIReglasLocalizacion,omitempty"`
+}
+
+// DesgloseRecibida contains the tax breakdown of received invoices.
+type DesgloseRecibida struct {
+	InversionSujetoPasivo *DesgloseIVA `json:"InversionSujetoPasivo,omitempty"`
+	DesgloseIVA           *DesgloseIVA `json:"DesgloseIVA,omitempty"`
+}
+
+// DesgloseIVA lists the VAT details by rate.
+type DesgloseIVA struct {
+	DetalleIVA []*DetalleIVA `json:"DetalleIVA"`
+}
+
+// DetalleIVA contains the base and tax amounts for a rate. Issued invoices
+// use the "CuotaRepercutida" field, while received invoices use
+// "CuotaSoportada".
+type DetalleIVA struct {
+	TipoImpositivo           *num.Amount `json:"TipoImpositivo,omitempty"`
+	BaseImponible            num.Amount  `json:"BaseImponible"`
+	CuotaRepercutida         *num.Amount `json:"CuotaRepercutida,omitempty"`
+	CuotaSoportada           *num.Amount `json:"CuotaSoportada,omitempty"`
+	TipoRecargoEquivalencia  *num.Amount `json:"TipoRecargoEquivalencia,omitempty"`
+	CuotaRecargoEquivalencia *num.Amount `json:"CuotaRecargoEquivalencia,omitempty"`
+}
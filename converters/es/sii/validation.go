@@ -0,0 +1,216 @@
+package sii
+
+import (
+	"errors"
+
+	"github.com/invopop/gobl/addons/es/verifactu"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// maxRegimeKeys is the maximum number of regime keys that may be reported
+// for a single invoice: the main one and two additional.
+const maxRegimeKeys = 3
+
+var docTypesCorrection = []cbc.Code{
+	"R1", "R2", "R3", "R4", "R5",
+}
+
+// validateInvoice checks that the invoice contains all the data required to
+// build the record. The reporting party is the supplier for issued invoices,
+// and the customer for received invoices.
+func validateInvoice(inv *bill.Invoice, issued bool) error {
+	var docType cbc.Code
+	if inv.Tax != nil {
+		docType = inv.Tax.Ext.Get(verifactu.ExtKeyDocType)
+	}
+	return validation.ValidateStruct(inv,
+		validation.Field(&inv.Supplier,
+			validation.Required,
+			validation.By(validateParty(issued)),
+			validation.Skip,
+		),
+		validation.Field(&inv.Customer,
+			validation.When(
+				!issued || docType != "F2",
+				validation.Required,
+			),
+			validation.By(validateParty(!issued)),
+			validation.Skip,
+		),
+		validation.Field(&inv.Tax,
+			validation.Required,
+			validation.By(validateTax),
+			validation.Skip,
+		),
+		validation.Field(&inv.Preceding,
+			validation.When(
+				docType.In(docTypesCorrection...),
+				validation.Required,
+			),
+			validation.Each(
+				validation.By(validatePreceding),
+			),
+			validation.Skip,
+		),
+		validation.Field(&inv.Notes,
+			cbc.ValidateNotesHasKey(cbc.NoteKeyGeneral),
+			validation.Skip,
+		),
+		validation.Field(&inv.Totals,
+			validation.Required,
+			validation.By(validateTotals),
+			validation.Skip,
+		),
+	)
+}
+
+// validateParty checks the party's identification. The reporting party must
+// always have a Spanish NIF.
+func validateParty(reporter bool) func(value any) error {
+	return func(value any) error {
+		p, _ := value.(*org.Party)
+		if p == nil {
+			return nil
+		}
+		return validation.ValidateStruct(p,
+			validation.Field(&p.Name, validation.Required),
+			validation.Field(&p.TaxID,
+				validation.Required,
+				tax.RequireIdentityCode,
+				validation.When(
+					reporter,
+					validation.By(validateSpanishTaxID),
+				),
+				validation.Skip,
+			),
+		)
+	}
+}
+
+func validateSpanishTaxID(value any) error {
+	tID, _ := value.(*tax.Identity)
+	if tID == nil || tID.Country.In(l10n.ES.Tax()) {
+		return nil
+	}
+	return errors.New("must be Spanish")
+}
+
+func validateTax(value any) error {
+	t, _ := value.(*bill.Tax)
+	if t == nil {
+		return nil
+	}
+	return validation.ValidateStruct(t,
+		validation.Field(&t.Ext,
+			tax.ExtensionsRequire(verifactu.ExtKeyDocType),
+			validation.When(
+				t.Ext.Get(verifactu.ExtKeyDocType).In(docTypesCorrection...),
+				tax.ExtensionsRequire(verifactu.ExtKeyCorrectionType),
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validatePreceding(value any) error {
+	p, _ := value.(*org.DocumentRef)
+	if p == nil {
+		return nil
+	}
+	return validation.ValidateStruct(p,
+		validation.Field(&p.Code, validation.Required),
+		validation.Field(&p.IssueDate, validation.Required),
+	)
+}
+
+func validateTotals(value any) error {
+	t, _ := value.(*bill.Totals)
+	if t == nil {
+		return nil
+	}
+	return validation.ValidateStruct(t,
+		validation.Field(&t.Taxes,
+			validation.Required,
+			validation.By(validateTaxTotal),
+			validation.Skip,
+		),
+	)
+}
+
+func validateTaxTotal(value any) error {
+	tt, _ := value.(*tax.Total)
+	if tt == nil {
+		return nil
+	}
+	ct := tt.Category(tax.CategoryVAT)
+	if ct == nil {
+		return errors.New("missing category VAT")
+	}
+	if len(regimeKeys(ct)) > maxRegimeKeys {
+		return errors.New("too many VAT regime keys")
+	}
+	return validation.ValidateStruct(tt,
+		validation.Field(&tt.Categories,
+			validation.Each(
+				validation.By(validateCategoryTotal),
+				validation.Skip,
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateCategoryTotal(value any) error {
+	ct, _ := value.(*tax.CategoryTotal)
+	if ct == nil || ct.Code != tax.CategoryVAT {
+		return nil
+	}
+	return validation.ValidateStruct(ct,
+		validation.Field(&ct.Rates,
+			validation.Each(
+				validation.By(validateRateTotal),
+				validation.Skip,
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateRateTotal(value any) error {
+	rt, _ := value.(*tax.RateTotal)
+	if rt == nil {
+		return nil
+	}
+	return validation.ValidateStruct(rt,
+		validation.Field(&rt.Ext,
+			tax.ExtensionsRequire(verifactu.ExtKeyRegime),
+			validation.When(
+				rt.Percent != nil,
+				tax.ExtensionsRequire(verifactu.ExtKeyOpClass),
+			),
+			validation.When(
+				rt.Percent == nil && !rt.Ext.Has(verifactu.ExtKeyOpClass),
+				tax.ExtensionsRequire(verifactu.ExtKeyExempt),
+			),
+			validation.Skip,
+		),
+	)
+}
+
+// regimeKeys provides the distinct regime keys used in the category's rates,
+// in order of appearance.
+func regimeKeys(ct *tax.CategoryTotal) []cbc.Code {
+	keys := make([]cbc.Code, 0, 1)
+	for _, rt := range ct.Rates {
+		k := rt.Ext.Get(verifactu.ExtKeyRegime)
+		if k != cbc.CodeEmpty && !k.In(keys...) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
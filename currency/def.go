@@ -59,6 +59,9 @@ type Def struct {
 	// NumeralSystem defines how numbers should be printed out, by default this
 	// is 'western'.
 	NumeralSystem num.NumeralSystem `json:"numeral_system"`
+	// CashRounding defines how payable amounts should be rounded when paying
+	// in cash, only set for currencies where this is common practice.
+	CashRounding *RoundingRule `json:"cash_rounding,omitempty"`
 }
 
 // FormatOption defines how to configure the formatter for common
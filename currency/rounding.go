@@ -0,0 +1,58 @@
+package currency
+
+import (
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/validation"
+)
+
+// RoundingRule defines how payable amounts should be rounded when the smallest
+// coins of a currency are no longer in circulation, typically when paying in
+// cash. For example, Swiss francs are rounded to 0.05 and Swedish kronor to
+// whole units.
+type RoundingRule struct {
+	// Increment the amount should be rounded to, e.g. "0.05".
+	Increment num.Amount `json:"increment" jsonschema:"title=Increment"`
+	// Mode used for rounding, "half-up" by default.
+	Mode num.RoundingMode `json:"mode,omitempty" jsonschema:"title=Mode"`
+	// Means contains the payment means keys the rule applies to. When empty,
+	// the rule will only apply to cash payments.
+	Means []cbc.Key `json:"means,omitempty" jsonschema:"title=Means"`
+}
+
+// meansKeyCash is copied from the pay package to avoid import loops.
+const meansKeyCash cbc.Key = "cash"
+
+// AppliesTo returns true if the rule should be used with the provided payment
+// means key.
+func (rr *RoundingRule) AppliesTo(key cbc.Key) bool {
+	if rr == nil || key == cbc.KeyEmpty {
+		return false
+	}
+	if len(rr.Means) == 0 {
+		return key == meansKeyCash || key.Has(meansKeyCash)
+	}
+	for _, m := range rr.Means {
+		if key == m || key.Has(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Round provides the amount rounded to the rule's increment, maintaining the
+// original amount's precision.
+func (rr *RoundingRule) Round(a num.Amount) num.Amount {
+	if rr == nil {
+		return a
+	}
+	return a.RoundTo(rr.Increment, rr.Mode)
+}
+
+// Validate ensures the rounding rule is complete.
+func (rr *RoundingRule) Validate() error {
+	return validation.ValidateStruct(rr,
+		validation.Field(&rr.Increment, num.Positive),
+		validation.Field(&rr.Mode),
+	)
+}
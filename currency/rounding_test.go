@@ -0,0 +1,93 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefCashRounding(t *testing.T) {
+	assert.Nil(t, currency.EUR.Def().CashRounding)
+	assert.Nil(t, currency.USD.Def().CashRounding)
+	rr := currency.CHF.Def().CashRounding
+	require.NotNil(t, rr)
+	assert.Equal(t, "0.05", rr.Increment.String())
+	assert.NoError(t, rr.Validate())
+	rr = currency.SEK.Def().CashRounding
+	require.NotNil(t, rr)
+	assert.Equal(t, "1.00", rr.Increment.String())
+	rr = currency.CAD.Def().CashRounding
+	require.NotNil(t, rr)
+	assert.Equal(t, "0.05", rr.Increment.String())
+	rr = currency.DKK.Def().CashRounding
+	require.NotNil(t, rr)
+	assert.Equal(t, "0.50", rr.Increment.String())
+}
+
+func TestRoundingRuleRound(t *testing.T) {
+	tests := []struct {
+		inc  string
+		mode num.RoundingMode
+		in   string
+		out  string
+	}{
+		{"0.05", "", "10.02", "10.00"},
+		{"0.05", "", "10.03", "10.05"},
+		{"0.05", "", "10.025", "10.050"},
+		{"0.05", "", "-10.03", "-10.05"},
+		{"0.05", num.RoundingModeUp, "10.01", "10.05"},
+		{"0.05", num.RoundingModeDown, "10.04", "10.00"},
+		{"0.50", "", "99.74", "99.50"},
+		{"0.50", "", "99.75", "100.00"},
+		{"1.00", num.RoundingModeDown, "-3.60", "-3.00"},
+		{"5", "", "1232", "1230"},
+		{"0.05", num.RoundingModeHalfEven, "10.025", "10.000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			inc, err := num.AmountFromString(tt.inc)
+			require.NoError(t, err)
+			in, err := num.AmountFromString(tt.in)
+			require.NoError(t, err)
+			rr := &currency.RoundingRule{
+				Increment: inc,
+				Mode:      tt.mode,
+			}
+			assert.Equal(t, tt.out, rr.Round(in).String())
+		})
+	}
+}
+
+func TestRoundingRuleAppliesTo(t *testing.T) {
+	rr := &currency.RoundingRule{
+		Increment: num.MakeAmount(5, 2),
+	}
+	assert.True(t, rr.AppliesTo("cash"))
+	assert.False(t, rr.AppliesTo("card"))
+	assert.False(t, rr.AppliesTo(cbc.KeyEmpty))
+
+	rr.Means = []cbc.Key{"card", "credit-transfer"}
+	assert.False(t, rr.AppliesTo("cash"))
+	assert.True(t, rr.AppliesTo("card"))
+	assert.True(t, rr.AppliesTo("credit-transfer+sepa"))
+
+	var nr *currency.RoundingRule
+	assert.False(t, nr.AppliesTo("cash"))
+}
+
+func TestRoundingRuleValidate(t *testing.T) {
+	rr := &currency.RoundingRule{
+		Increment: num.MakeAmount(5, 2),
+		Mode:      num.RoundingModeUp,
+	}
+	assert.NoError(t, rr.Validate())
+	rr.Increment = num.MakeAmount(0, 2)
+	rr.Mode = "ceiling"
+	err := rr.Validate()
+	assert.ErrorContains(t, err, "increment: must be greater than 0")
+	assert.ErrorContains(t, err, "mode: must be a valid value")
+}
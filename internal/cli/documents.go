@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/invopop/gobl"
+	"github.com/invopop/gobl/head"
+	"github.com/invopop/gobl/schema"
+)
+
+// walkDocuments parses each JSON file in the directory, sorted by name, and
+// calls fn with the envelope's header, if any, and the document it contains.
+func walkDocuments(ctx context.Context, dir string, fn func(hdr *head.Header, doc any)) error {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".json") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		obj, err := gobl.Parse(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f, err)
+		}
+		switch o := obj.(type) {
+		case *gobl.Envelope:
+			fn(o.Head, o.Extract())
+		case *schema.Object:
+			fn(nil, o.Instance())
+		}
+	}
+	return nil
+}
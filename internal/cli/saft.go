@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"crypto/rsa"
+
+	"github.com/invopop/gobl/addons/pt/saft"
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/head"
+	"github.com/invopop/gobl/tax"
+)
+
+// SAFTOptions define the options required to build a Portuguese SAF-T
+// audit file from a directory of GOBL envelopes.
+type SAFTOptions struct {
+	// Dir contains the JSON envelopes or documents to read.
+	Dir string
+	// TaxID of the company whose invoices should be exported.
+	TaxID *tax.Identity
+	// Period covered by the audit file.
+	Period cal.Period
+	// Software details of the certified invoicing program.
+	Software saft.Software
+	// Key used to sign the hash of invoices without an "at-hash" stamp.
+	Key *rsa.PrivateKey
+	// PreviousHashes contains the hash of the last invoice issued before the
+	// period for each series, e.g. "FT SERIES-A", required to chain the hashes
+	// signed with the key to those exported in earlier periods.
+	PreviousHashes map[string]string
+	// SeriesCodes contains the validation codes assigned by the AT to each
+	// series, used for invoices without an "at-atcud" stamp.
+	SeriesCodes map[string]string
+}
+
+// SAFT reads all the invoices from the directory, alongside the stamps in
+// their envelope headers, and builds the SAF-T (PT) audit file for the period.
+// Documents that are not invoices are ignored.
+func SAFT(ctx context.Context, opts *SAFTOptions) (*saft.AuditFile, error) {
+	var docs []*saft.Document
+	err := walkDocuments(ctx, opts.Dir, func(hdr *head.Header, doc any) {
+		inv, ok := doc.(*bill.Invoice)
+		if !ok {
+			return
+		}
+		d := &saft.Document{Invoice: inv}
+		if hdr != nil {
+			d.Stamps = hdr.Stamps
+		}
+		docs = append(docs, d)
+	})
+	if err != nil {
+		return nil, wrapError(StatusBadRequest, err)
+	}
+	af, err := saft.NewAuditFile(&saft.ExportOptions{
+		TaxID:          opts.TaxID,
+		Period:         opts.Period,
+		Software:       opts.Software,
+		Key:            opts.Key,
+		PreviousHashes: opts.PreviousHashes,
+		SeriesCodes:    opts.SeriesCodes,
+	}, docs)
+	if err != nil {
+		return nil, wrapError(StatusUnprocessableEntity, err)
+	}
+	return af, nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" // nolint:gosec
+	"encoding/base64"
+	"testing"
+
+	"github.com/invopop/gobl/addons/pt/saft"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSAFT(t *testing.T) {
+	opts := &SAFTOptions{
+		Dir:   "testdata/saft",
+		TaxID: &tax.Identity{Country: "PT", Code: "545259045"},
+		Period: cal.Period{
+			Start: cal.MakeDate(2024, 3, 1),
+			End:   cal.MakeDate(2024, 3, 31),
+		},
+	}
+	af, err := SAFT(context.Background(), opts)
+	require.NoError(t, err)
+	si := af.SourceDocuments.SalesInvoices
+	require.Len(t, si.Invoice, 1)
+	assert.Equal(t, "FT SERIES-A/1", si.Invoice[0].InvoiceNo)
+	assert.Equal(t, "CSDF7T5H-1", si.Invoice[0].ATCUD)
+	assert.Equal(t, "hash-ft-1", si.Invoice[0].Hash)
+
+	opts.TaxID = &tax.Identity{Country: "PT", Code: "503504564"}
+	_, err = SAFT(context.Background(), opts)
+	assert.ErrorContains(t, err, "no invoices found for tax ID in period")
+
+	opts.Dir = "testdata/missing"
+	_, err = SAFT(context.Background(), opts)
+	assert.ErrorContains(t, err, "no such file or directory")
+}
+
+func TestSAFTNextPeriod(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	opts := &SAFTOptions{
+		Dir:   "testdata/saft",
+		TaxID: &tax.Identity{Country: "PT", Code: "545259045"},
+		Period: cal.Period{
+			Start: cal.MakeDate(2024, 4, 1),
+			End:   cal.MakeDate(2024, 4, 30),
+		},
+		Key:            key,
+		PreviousHashes: map[string]string{"FT SERIES-A": "hash-ft-1"},
+		SeriesCodes:    map[string]string{"FT SERIES-A": "CSDF7T5H"},
+	}
+	af, err := SAFT(context.Background(), opts)
+	require.NoError(t, err)
+	si := af.SourceDocuments.SalesInvoices
+	require.Len(t, si.Invoice, 1)
+	doc := si.Invoice[0]
+	assert.Equal(t, "FT SERIES-A/2", doc.InvoiceNo)
+	assert.Equal(t, "CSDF7T5H-2", doc.ATCUD)
+
+	msg := saft.HashMessage(doc.InvoiceDate, doc.SystemEntryDate, doc.InvoiceNo, num.MakeAmount(12300, 2), "hash-ft-1")
+	sig, err := base64.StdEncoding.DecodeString(doc.Hash)
+	require.NoError(t, err)
+	sum := sha1.Sum([]byte(msg)) // nolint:gosec
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, sum[:], sig), "chained to previous period")
+
+	opts.SeriesCodes = nil
+	_, err = SAFT(context.Background(), opts)
+	assert.ErrorContains(t, err, "ATCUD stamp or validation code for series 'FT SERIES-A' required")
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/head"
+	"github.com/invopop/gobl/regimes/gb"
+	"github.com/invopop/gobl/tax"
+)
+
+// VATReturnOptions define the options required to build a UK Making Tax
+// Digital VAT return from a directory of GOBL documents.
+type VATReturnOptions struct {
+	// Dir contains the JSON envelopes or documents to read.
+	Dir string
+	// TaxID of the business filing the return.
+	TaxID *tax.Identity
+	// Period covered by the return.
+	Period cal.Period
+}
+
+// VATReturn reads all the invoices from the directory and builds the UK VAT
+// return for the period. Documents that are not invoices are ignored.
+func VATReturn(ctx context.Context, opts *VATReturnOptions) (*gb.VATReturn, error) {
+	invs, err := readInvoices(ctx, opts.Dir)
+	if err != nil {
+		return nil, wrapError(StatusBadRequest, err)
+	}
+	vr, err := gb.NewVATReturn(opts.TaxID, opts.Period, invs)
+	if err != nil {
+		return nil, wrapError(StatusUnprocessableEntity, err)
+	}
+	return vr, nil
+}
+
+func readInvoices(ctx context.Context, dir string) ([]*bill.Invoice, error) {
+	var invs []*bill.Invoice
+	err := walkDocuments(ctx, dir, func(_ *head.Header, doc any) {
+		if inv, ok := doc.(*bill.Invoice); ok {
+			invs = append(invs, inv)
+		}
+	})
+	return invs, err
+}
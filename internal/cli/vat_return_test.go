@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVATReturn(t *testing.T) {
+	opts := &VATReturnOptions{
+		Dir:   "testdata/vat-return",
+		TaxID: &tax.Identity{Country: "GB", Code: "844281425"},
+		Period: cal.Period{
+			Start: cal.MakeDate(2024, 1, 1),
+			End:   cal.MakeDate(2024, 3, 31),
+		},
+	}
+	vr, err := VATReturn(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "200.00", vr.VATDueSales.String())
+	assert.Equal(t, "50.10", vr.VATReclaimed.String())
+	assert.Equal(t, "149.90", vr.NetVATDue.String())
+	assert.Equal(t, "1000", vr.TotalValueSales.String())
+	assert.Equal(t, "250", vr.TotalValuePurchases.String())
+
+	opts.TaxID = &tax.Identity{Country: "GB", Code: "000472631"}
+	_, err = VATReturn(context.Background(), opts)
+	assert.ErrorContains(t, err, "supplier or customer must match tax ID")
+
+	opts.Dir = "testdata/missing"
+	_, err = VATReturn(context.Background(), opts)
+	assert.ErrorContains(t, err, "no such file or directory")
+}
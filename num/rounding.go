@@ -0,0 +1,96 @@
+package num
+
+import "github.com/invopop/validation"
+
+// RoundingMode defines how amounts should be rounded when their precision
+// is reduced.
+type RoundingMode string
+
+// Supported rounding modes.
+const (
+	// RoundingModeHalfUp rounds to the nearest value, with halves rounded away
+	// from zero. This is the default used by Rescale.
+	RoundingModeHalfUp RoundingMode = "half-up"
+	// RoundingModeHalfEven rounds to the nearest value, with halves rounded
+	// to the nearest even value, also known as banker's rounding.
+	RoundingModeHalfEven RoundingMode = "half-even"
+	// RoundingModeUp always rounds away from zero.
+	RoundingModeUp RoundingMode = "up"
+	// RoundingModeDown always rounds towards zero, truncating the value.
+	RoundingModeDown RoundingMode = "down"
+)
+
+// RoundingModes contains the list of all supported rounding modes.
+var RoundingModes = []RoundingMode{
+	RoundingModeHalfUp,
+	RoundingModeHalfEven,
+	RoundingModeUp,
+	RoundingModeDown,
+}
+
+// Validate ensures the rounding mode is one of those supported, or empty.
+func (m RoundingMode) Validate() error {
+	modes := make([]any, len(RoundingModes))
+	for i, v := range RoundingModes {
+		modes[i] = string(v)
+	}
+	return validation.Validate(string(m), validation.In(modes...))
+}
+
+// RescaleWith behaves like Rescale, but uses the provided rounding mode when
+// the exponent is reduced. An empty mode implies half-up.
+func (a Amount) RescaleWith(exp uint32, mode RoundingMode) Amount {
+	if a.exp <= exp {
+		return a.Rescale(exp)
+	}
+	d := intPow(10, a.exp-exp)
+	return Amount{roundDiv(a.value, d, mode), exp}
+}
+
+// RoundTo provides the amount rounded to a multiple of the increment using
+// the rounding mode, while maintaining the original amount's exponent. This
+// is useful for cases like cash payments where the smallest coins are no
+// longer in circulation.
+func (a Amount) RoundTo(increment Amount, mode RoundingMode) Amount {
+	if !increment.IsPositive() {
+		return a
+	}
+	exp := a.exp
+	if increment.exp > exp {
+		exp = increment.exp
+	}
+	v := a.Rescale(exp).value
+	inc := increment.Rescale(exp).value
+	v = roundDiv(v, inc, mode) * inc
+	return Amount{v, exp}.Rescale(a.exp)
+}
+
+// roundDiv divides the value by the divisor, rounding the result according
+// to the mode.
+func roundDiv(v, d int64, mode RoundingMode) int64 {
+	q := v / d // towards zero
+	r := v % d
+	if r == 0 {
+		return q
+	}
+	sign := int64(1)
+	if r < 0 {
+		sign = -1
+		r = -r
+	}
+	switch mode {
+	case RoundingModeUp:
+		q += sign
+	case RoundingModeDown:
+		// nothing to do
+	case RoundingModeHalfEven:
+		if 2*r > d || (2*r == d && q%2 != 0) {
+			q += sign
+		}
+	default:
+		if 2*r >= d {
+			q += sign
+		}
+	}
+	return q
+}
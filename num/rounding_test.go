@@ -0,0 +1,53 @@
+package num_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/num"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmountRescaleWith(t *testing.T) {
+	tests := []struct {
+		in   num.Amount
+		mode num.RoundingMode
+		exp  uint32
+		out  string
+	}{
+		{num.MakeAmount(12345, 3), "", 2, "12.35"},
+		{num.MakeAmount(12345, 3), num.RoundingModeHalfUp, 2, "12.35"},
+		{num.MakeAmount(-12345, 3), num.RoundingModeHalfUp, 2, "-12.35"},
+		{num.MakeAmount(12345, 3), num.RoundingModeHalfEven, 2, "12.34"},
+		{num.MakeAmount(12355, 3), num.RoundingModeHalfEven, 2, "12.36"},
+		{num.MakeAmount(-12345, 3), num.RoundingModeHalfEven, 2, "-12.34"},
+		{num.MakeAmount(123451, 4), num.RoundingModeHalfEven, 2, "12.35"},
+		{num.MakeAmount(12341, 3), num.RoundingModeUp, 2, "12.35"},
+		{num.MakeAmount(-12341, 3), num.RoundingModeUp, 2, "-12.35"},
+		{num.MakeAmount(12349, 3), num.RoundingModeDown, 2, "12.34"},
+		{num.MakeAmount(-12349, 3), num.RoundingModeDown, 2, "-12.34"},
+		{num.MakeAmount(12340, 3), num.RoundingModeUp, 2, "12.34"},
+		{num.MakeAmount(1234, 2), num.RoundingModeDown, 4, "12.3400"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in.String()+" "+string(tt.mode), func(t *testing.T) {
+			assert.Equal(t, tt.out, tt.in.RescaleWith(tt.exp, tt.mode).String())
+		})
+	}
+}
+
+func TestAmountRoundTo(t *testing.T) {
+	inc := num.MakeAmount(5, 2)
+	a := num.MakeAmount(1012, 2)
+	assert.Equal(t, "10.10", a.RoundTo(inc, num.RoundingModeHalfUp).String())
+	assert.Equal(t, "10.15", a.RoundTo(inc, num.RoundingModeUp).String())
+	assert.Equal(t, "10.10", a.RoundTo(inc, num.RoundingModeDown).String())
+	assert.Equal(t, "10.12", a.RoundTo(num.MakeAmount(0, 2), "").String())
+	a = num.MakeAmount(-1013, 2)
+	assert.Equal(t, "-10.15", a.RoundTo(inc, "").String())
+}
+
+func TestRoundingModeValidate(t *testing.T) {
+	assert.NoError(t, num.RoundingMode("").Validate())
+	assert.NoError(t, num.RoundingModeHalfEven.Validate())
+	assert.ErrorContains(t, num.RoundingMode("ceiling").Validate(), "must be a valid value")
+}
@@ -38,11 +38,17 @@ type Item struct {
 	Currency currency.Code `json:"currency,omitempty" jsonschema:"title=Currency"`
 	// Base price of a single unit to be sold.
 	Price num.Amount `json:"price" jsonschema:"title=Price"`
+	// Number of units the price applies to, such as "12.50 per 1000 units". When
+	// empty, the price is for a single unit.
+	BaseQuantity *num.Amount `json:"base_quantity,omitempty" jsonschema:"title=Base Quantity"`
 	// AltPrices defines a list of prices with their currencies that may be used
 	// as an alternative to the item's base price.
 	AltPrices []*currency.Amount `json:"alt_prices,omitempty" jsonschema:"title=Alternative Prices"`
 	// Unit of measure.
 	Unit Unit `json:"unit,omitempty" jsonschema:"title=Unit"`
+	// Unit of measure of the base quantity, which must match the item's unit
+	// when both are provided.
+	BaseUnit Unit `json:"base_unit,omitempty" jsonschema:"title=Base Unit"`
 	// Country code of where this item was from originally.
 	Origin l10n.ISOCountryCode `json:"origin,omitempty" jsonschema:"title=Country of Origin"`
 	// Extension code map for any additional regime specific codes that may be required.
@@ -66,6 +72,15 @@ func (i *Item) Normalize(normalizers tax.Normalizers) {
 	tax.Normalize(normalizers, i.Identities)
 }
 
+// UnitPrice provides the price of a single unit of the item, taking into
+// account the base quantity the price may apply to.
+func (i *Item) UnitPrice() num.Amount {
+	if i.BaseQuantity == nil || i.BaseQuantity.IsZero() {
+		return i.Price
+	}
+	return i.Price.Upscale(4).Divide(*i.BaseQuantity)
+}
+
 // ValidateWithContext checks that the Item looks okay inside the provided context.
 func (i *Item) ValidateWithContext(ctx context.Context) error {
 	return tax.ValidateStructWithContext(ctx, i,
@@ -75,8 +90,19 @@ func (i *Item) ValidateWithContext(ctx context.Context) error {
 		validation.Field(&i.Identities),
 		validation.Field(&i.Currency),
 		validation.Field(&i.Price, validation.Required),
+		validation.Field(&i.BaseQuantity, num.Positive),
 		validation.Field(&i.AltPrices),
 		validation.Field(&i.Unit),
+		validation.Field(&i.BaseUnit,
+			validation.When(
+				i.BaseQuantity == nil,
+				validation.Empty.Error("must be blank without base quantity"),
+			),
+			validation.When(
+				i.Unit != "",
+				validation.In(i.Unit).Error("must match unit"),
+			),
+		),
 		validation.Field(&i.Origin),
 		validation.Field(&i.Ext),
 		validation.Field(&i.Meta),
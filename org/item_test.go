@@ -0,0 +1,58 @@
+package org_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemBaseQuantityValidation(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		i := &org.Item{
+			Name:         "Flour",
+			Price:        num.MakeAmount(4599, 2),
+			Unit:         org.UnitKilogram,
+			BaseQuantity: num.NewAmount(100, 0),
+			BaseUnit:     org.UnitKilogram,
+		}
+		assert.NoError(t, i.Validate())
+	})
+	t.Run("zero base quantity", func(t *testing.T) {
+		i := &org.Item{
+			Name:         "Flour",
+			Price:        num.MakeAmount(4599, 2),
+			BaseQuantity: num.NewAmount(0, 2),
+		}
+		assert.ErrorContains(t, i.Validate(), "base_quantity: must be greater than 0")
+	})
+	t.Run("base unit without base quantity", func(t *testing.T) {
+		i := &org.Item{
+			Name:     "Flour",
+			Price:    num.MakeAmount(4599, 2),
+			BaseUnit: org.UnitKilogram,
+		}
+		assert.ErrorContains(t, i.Validate(), "base_unit: must be blank without base quantity")
+	})
+	t.Run("base unit mismatch", func(t *testing.T) {
+		i := &org.Item{
+			Name:         "Flour",
+			Price:        num.MakeAmount(4599, 2),
+			Unit:         org.UnitKilogram,
+			BaseQuantity: num.NewAmount(1, 0),
+			BaseUnit:     org.UnitGram,
+		}
+		assert.ErrorContains(t, i.Validate(), "base_unit: must match unit")
+	})
+}
+
+func TestItemUnitPrice(t *testing.T) {
+	i := &org.Item{
+		Name:  "Screws",
+		Price: num.MakeAmount(1250, 2),
+	}
+	assert.Equal(t, "12.50", i.UnitPrice().String())
+	i.BaseQuantity = num.NewAmount(1000, 0)
+	assert.Equal(t, "0.012500", i.UnitPrice().String())
+}
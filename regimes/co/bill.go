@@ -0,0 +1,230 @@
+package co
+
+import (
+	"errors"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Item keys used to determine the withholding concept of each line.
+const (
+	ItemKeyGoods    cbc.Key = "goods"
+	ItemKeyServices cbc.Key = "services"
+)
+
+// withholdingConcept defines the minimum base in UVT from which taxes must be
+// withheld, and the ReteRenta rate to apply, for purchases of goods or
+// services.
+type withholdingConcept struct {
+	threshold num.Amount
+	rate      num.Percentage
+}
+
+var withholdingConcepts = map[cbc.Key]*withholdingConcept{
+	ItemKeyGoods: {
+		threshold: num.MakeAmount(27, 0),
+		rate:      num.MakePercentage(25, 3),
+	},
+	ItemKeyServices: {
+		threshold: num.MakeAmount(4, 0),
+		rate:      num.MakePercentage(40, 3),
+	},
+}
+
+// reteIVAPortion is the proportion of VAT withheld by agents.
+var reteIVAPortion = num.MakeAmount(15, 2)
+
+// normalizeInvoice proposes ReteRenta and ReteIVA combos on lines according
+// to the customer's withholding agent status and the supplier's fiscal
+// responsibility. Existing retention combos are never modified.
+func normalizeInvoice(inv *bill.Invoice) {
+	if !withholdingExpected(inv) {
+		return
+	}
+	agent := inv.Customer.Ext.Get(ExtKeyWithholdingAgent)
+	date := inv.IssueDate
+	if date.IsZero() {
+		date = cal.TodayIn(New().TimeLocation())
+	}
+	uvt, err := UVTFor(date.Year)
+	if err != nil {
+		// reported during validation
+		return
+	}
+
+	resp := inv.Supplier.Ext.Get(ExtKeyFiscalResponsibility)
+	applyRenta := reteRentaApplies(resp)
+	applyIVA := agent == WithholdingAgentIncomeVAT && reteIVAApplies(resp)
+	if !applyRenta && !applyIVA {
+		return
+	}
+
+	bases := withholdingBases(inv.Lines)
+	for _, line := range inv.Lines {
+		if line == nil || line.Item == nil {
+			continue
+		}
+		wc := withholdingConcepts[line.Item.Key]
+		if wc == nil || bases[line.Item.Key].Compare(wc.threshold.Multiply(uvt)) < 0 {
+			continue
+		}
+		if applyRenta && line.Taxes.Get(TaxCategoryReteRenta) == nil {
+			p := wc.rate
+			line.Taxes = append(line.Taxes, &tax.Combo{
+				Category: TaxCategoryReteRenta,
+				Percent:  &p,
+			})
+		}
+		if applyIVA && line.Taxes.Get(TaxCategoryReteIVA) == nil {
+			if p := reteIVAPercent(line.Taxes.Get(tax.CategoryVAT), date); p != nil {
+				line.Taxes = append(line.Taxes, &tax.Combo{
+					Category: TaxCategoryReteIVA,
+					Percent:  p,
+				})
+			}
+		}
+	}
+}
+
+// withholdingExpected returns true when the customer is a withholding agent
+// and the invoice is issued in COP, so retentions may need to be applied.
+func withholdingExpected(inv *bill.Invoice) bool {
+	if inv.Supplier == nil || inv.Customer == nil {
+		return false
+	}
+	if inv.Customer.Ext.Get(ExtKeyWithholdingAgent) == cbc.CodeEmpty {
+		return false
+	}
+	return inv.Currency == currency.CodeEmpty || inv.Currency == currency.COP
+}
+
+// withholdingBases sums the line amounts before discounts for each of the
+// withholding concepts, as thresholds apply to the whole transaction.
+func withholdingBases(lines []*bill.Line) map[cbc.Key]num.Amount {
+	bases := make(map[cbc.Key]num.Amount)
+	for _, line := range lines {
+		if line == nil || line.Item == nil {
+			continue
+		}
+		if _, ok := withholdingConcepts[line.Item.Key]; !ok {
+			continue
+		}
+		sum := line.Item.UnitPrice().Multiply(line.Quantity)
+		bases[line.Item.Key] = bases[line.Item.Key].Add(sum)
+	}
+	return bases
+}
+
+// reteIVAPercent determines the percentage to withhold over the line's base
+// from the VAT combo, or nil if no VAT is charged.
+func reteIVAPercent(vat *tax.Combo, date cal.Date) *num.Percentage {
+	if vat == nil {
+		return nil
+	}
+	pc := vat.Percent
+	if pc == nil && vat.Rate != cbc.KeyEmpty {
+		if rd := New().RateDef(tax.CategoryVAT, vat.Rate); rd != nil {
+			if rv := rd.Value(date, nil, nil); rv != nil {
+				pc = &rv.Percent
+			}
+		}
+	}
+	if pc == nil || !pc.IsPositive() {
+		return nil
+	}
+	p := num.PercentageFromAmount(pc.Amount().Rescale(2).Multiply(reteIVAPortion))
+	return &p
+}
+
+func reteRentaApplies(resp cbc.Code) bool {
+	return !resp.In(FiscalResponsibilitySelfWithholding, FiscalResponsibilitySimpleRegime)
+}
+
+func reteIVAApplies(resp cbc.Code) bool {
+	return resp != FiscalResponsibilityLargeTaxpayer
+}
+
+// validateInvoice ensures that the retained taxes included in lines are
+// consistent with the parties' fiscal responsibilities.
+func validateInvoice(inv *bill.Invoice) error {
+	return validation.ValidateStruct(inv,
+		validation.Field(&inv.IssueDate,
+			validation.When(
+				withholdingExpected(inv),
+				validation.By(validateUVTYear),
+			),
+			validation.Skip,
+		),
+		validation.Field(&inv.Lines,
+			validation.Each(
+				validation.By(validateInvoiceLine(inv.Supplier, inv.Customer)),
+				validation.Skip,
+			),
+			validation.Skip,
+		),
+	)
+}
+
+// validateUVTYear ensures the UVT value is available for the year of the
+// issue date, as withholding thresholds could not be determined otherwise.
+func validateUVTYear(value any) error {
+	date, ok := value.(cal.Date)
+	if !ok || date.IsZero() {
+		return nil
+	}
+	_, err := UVTFor(date.Year)
+	return err
+}
+
+func validateInvoiceLine(supplier, customer *org.Party) func(value any) error {
+	var resp, agent cbc.Code
+	if supplier != nil {
+		resp = supplier.Ext.Get(ExtKeyFiscalResponsibility)
+	}
+	if customer != nil {
+		agent = customer.Ext.Get(ExtKeyWithholdingAgent)
+	}
+	return func(value any) error {
+		line, _ := value.(*bill.Line)
+		if line == nil {
+			return nil
+		}
+		return validation.ValidateStruct(line,
+			validation.Field(&line.Taxes,
+				validation.By(validateLineRetentions(resp, agent)),
+				validation.Skip,
+			),
+		)
+	}
+}
+
+func validateLineRetentions(resp, agent cbc.Code) func(value any) error {
+	return func(value any) error {
+		ts, _ := value.(tax.Set)
+		errs := make(validation.Errors)
+		if ts.Get(TaxCategoryReteRenta) != nil && !reteRentaApplies(resp) {
+			errs[TaxCategoryReteRenta.String()] = errors.New("not applicable to self-withholding or simple regime suppliers")
+		}
+		if ts.Get(TaxCategoryReteIVA) != nil {
+			if !reteIVAApplies(resp) {
+				errs[TaxCategoryReteIVA.String()] = errors.New("not applicable to large taxpayer suppliers")
+			} else if agent == WithholdingAgentIncome {
+				errs[TaxCategoryReteIVA.String()] = errors.New("customer is not a VAT withholding agent")
+			}
+		}
+		if ts.Get(TaxCategoryReteICA) != nil && resp == FiscalResponsibilitySimpleRegime {
+			errs[TaxCategoryReteICA.String()] = errors.New("not applicable to simple regime suppliers")
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		return nil
+	}
+}
@@ -0,0 +1,271 @@
+package co_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/co"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInvoiceWithholding(key cbc.Key, price int64) *bill.Invoice {
+	return &bill.Invoice{
+		Regime:    tax.WithRegime("CO"),
+		Series:    "SETT",
+		Code:      "1234",
+		IssueDate: cal.MakeDate(2024, 6, 1),
+		Currency:  "COP",
+		Supplier: &org.Party{
+			Name: "EXAMPLE SUPPLIER S.A.S.",
+			TaxID: &tax.Identity{
+				Country: "CO",
+				Code:    "9014514812",
+			},
+		},
+		Customer: &org.Party{
+			Name: "EXAMPLE CUSTOMER S.A.S.",
+			TaxID: &tax.Identity{
+				Country: "CO",
+				Code:    "9014514805",
+			},
+			Ext: tax.Extensions{
+				co.ExtKeyWithholdingAgent: co.WithholdingAgentIncomeVAT,
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Key:   key,
+					Name:  "Test item",
+					Price: num.MakeAmount(price, 0),
+				},
+				Taxes: tax.Set{
+					{
+						Category: tax.CategoryVAT,
+						Rate:     tax.RateStandard,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInvoiceWithholdingNormalization(t *testing.T) {
+	t.Run("services above threshold", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		require.NoError(t, inv.Calculate())
+		require.NoError(t, inv.Validate())
+		ts := inv.Lines[0].Taxes
+		require.Len(t, ts, 3)
+		assert.Equal(t, "4.0%", ts.Get(co.TaxCategoryReteRenta).Percent.String())
+		assert.Equal(t, "2.85%", ts.Get(co.TaxCategoryReteIVA).Percent.String())
+		assert.Equal(t, "24300.00", inv.Totals.Tax.String())
+		assert.Equal(t, "224300.00", inv.Totals.Payable.String())
+	})
+
+	t.Run("services below threshold", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 150000)
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 1)
+	})
+
+	t.Run("goods above threshold", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyGoods, 1000000)
+		inv.Lines = append(inv.Lines, &bill.Line{
+			Quantity: num.MakeAmount(2, 0),
+			Item: &org.Item{
+				Key:   co.ItemKeyGoods,
+				Name:  "Other item",
+				Price: num.MakeAmount(150000, 0),
+			},
+			Taxes: tax.Set{
+				{
+					Category: tax.CategoryVAT,
+					Percent:  num.NewPercentage(5, 2),
+				},
+			},
+		})
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "2.5%", inv.Lines[0].Taxes.Get(co.TaxCategoryReteRenta).Percent.String())
+		assert.Equal(t, "2.85%", inv.Lines[0].Taxes.Get(co.TaxCategoryReteIVA).Percent.String())
+		assert.Equal(t, "2.5%", inv.Lines[1].Taxes.Get(co.TaxCategoryReteRenta).Percent.String())
+		assert.Equal(t, "0.75%", inv.Lines[1].Taxes.Get(co.TaxCategoryReteIVA).Percent.String())
+	})
+
+	t.Run("goods below threshold", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyGoods, 1000000)
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 1)
+	})
+
+	t.Run("threshold uses year UVT", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 190000)
+		inv.IssueDate = cal.MakeDate(2025, 2, 1)
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 1)
+
+		inv = testInvoiceWithholding(co.ItemKeyServices, 190000)
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 3)
+	})
+
+	t.Run("unknown UVT year", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.IssueDate = cal.MakeDate(2018, 6, 1)
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 1)
+		assert.ErrorContains(t, inv.Validate(), "issue_date: no UVT value defined for 2018")
+	})
+
+	t.Run("registered UVT year", func(t *testing.T) {
+		co.RegisterUVT(2017, num.MakeAmount(31859, 0))
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.IssueDate = cal.MakeDate(2017, 6, 1)
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 3)
+		assert.NoError(t, inv.Validate())
+	})
+
+	t.Run("item without key", func(t *testing.T) {
+		inv := testInvoiceWithholding(cbc.KeyEmpty, 200000)
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 1)
+	})
+
+	t.Run("customer not an agent", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.Customer.Ext = nil
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 1)
+	})
+
+	t.Run("customer income tax agent only", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.Customer.Ext[co.ExtKeyWithholdingAgent] = co.WithholdingAgentIncome
+		require.NoError(t, inv.Calculate())
+		assert.NotNil(t, inv.Lines[0].Taxes.Get(co.TaxCategoryReteRenta))
+		assert.Nil(t, inv.Lines[0].Taxes.Get(co.TaxCategoryReteIVA))
+	})
+
+	t.Run("self-withholding supplier", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.Supplier.Ext = tax.Extensions{
+			co.ExtKeyFiscalResponsibility: co.FiscalResponsibilitySelfWithholding,
+		}
+		require.NoError(t, inv.Calculate())
+		assert.Nil(t, inv.Lines[0].Taxes.Get(co.TaxCategoryReteRenta))
+		assert.NotNil(t, inv.Lines[0].Taxes.Get(co.TaxCategoryReteIVA))
+	})
+
+	t.Run("large taxpayer supplier", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.Supplier.Ext = tax.Extensions{
+			co.ExtKeyFiscalResponsibility: co.FiscalResponsibilityLargeTaxpayer,
+		}
+		require.NoError(t, inv.Calculate())
+		assert.NotNil(t, inv.Lines[0].Taxes.Get(co.TaxCategoryReteRenta))
+		assert.Nil(t, inv.Lines[0].Taxes.Get(co.TaxCategoryReteIVA))
+	})
+
+	t.Run("existing retention kept", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.Lines[0].Taxes = append(inv.Lines[0].Taxes, &tax.Combo{
+			Category: co.TaxCategoryReteRenta,
+			Percent:  num.NewPercentage(110, 3),
+		})
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, "11.0%", inv.Lines[0].Taxes.Get(co.TaxCategoryReteRenta).Percent.String())
+	})
+
+	t.Run("foreign currency", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.Currency = "USD"
+		inv.ExchangeRates = []*currency.ExchangeRate{
+			{
+				From:   "USD",
+				To:     "COP",
+				Amount: num.MakeAmount(4000, 0),
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 1)
+	})
+}
+
+func TestInvoiceWithholdingValidation(t *testing.T) {
+	t.Run("ReteRenta with self-withholding supplier", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		require.NoError(t, inv.Calculate())
+		inv.Supplier.Ext = tax.Extensions{
+			co.ExtKeyFiscalResponsibility: co.FiscalResponsibilitySelfWithholding,
+		}
+		err := inv.Validate()
+		assert.ErrorContains(t, err, "lines: (0: (taxes: (RR: not applicable to self-withholding or simple regime suppliers.).).)")
+	})
+
+	t.Run("ReteIVA with large taxpayer supplier", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		require.NoError(t, inv.Calculate())
+		inv.Supplier.Ext = tax.Extensions{
+			co.ExtKeyFiscalResponsibility: co.FiscalResponsibilityLargeTaxpayer,
+		}
+		err := inv.Validate()
+		assert.ErrorContains(t, err, "RVAT: not applicable to large taxpayer suppliers")
+	})
+
+	t.Run("ReteIVA with income tax agent", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		require.NoError(t, inv.Calculate())
+		inv.Customer.Ext[co.ExtKeyWithholdingAgent] = co.WithholdingAgentIncome
+		err := inv.Validate()
+		assert.ErrorContains(t, err, "RVAT: customer is not a VAT withholding agent")
+	})
+
+	t.Run("ReteICA with simple regime supplier", func(t *testing.T) {
+		inv := testInvoiceWithholding(co.ItemKeyServices, 200000)
+		inv.Customer.Ext = nil
+		inv.Supplier.Ext = tax.Extensions{
+			co.ExtKeyFiscalResponsibility: co.FiscalResponsibilitySimpleRegime,
+		}
+		inv.Lines[0].Taxes = append(inv.Lines[0].Taxes, &tax.Combo{
+			Category: co.TaxCategoryReteICA,
+			Percent:  num.NewPercentage(966, 5),
+		})
+		require.NoError(t, inv.Calculate())
+		err := inv.Validate()
+		assert.ErrorContains(t, err, "RICA: not applicable to simple regime suppliers")
+	})
+
+	t.Run("retentions without party extensions", func(t *testing.T) {
+		inv := testInvoiceWithholding(cbc.KeyEmpty, 200000)
+		inv.Customer.Ext = nil
+		inv.Lines[0].Taxes = append(inv.Lines[0].Taxes, &tax.Combo{
+			Category: co.TaxCategoryReteRenta,
+			Percent:  num.NewPercentage(40, 3),
+		})
+		require.NoError(t, inv.Calculate())
+		assert.NoError(t, inv.Validate())
+	})
+}
+
+func TestUVTFor(t *testing.T) {
+	v, err := co.UVTFor(2024)
+	require.NoError(t, err)
+	assert.Equal(t, "47065", v.String())
+
+	_, err = co.UVTFor(2000)
+	assert.ErrorContains(t, err, "no UVT value defined for 2000")
+
+	co.RegisterUVT(2000, num.MakeAmount(2000, 0))
+	v, err = co.UVTFor(2000)
+	require.NoError(t, err)
+	assert.Equal(t, "2000", v.String())
+}
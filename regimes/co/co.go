@@ -38,6 +38,7 @@ func New() *tax.RegimeDef {
 				},
 			},
 		},
+		Extensions: extensions,
 		Categories: taxCategories,
 	}
 }
@@ -45,6 +46,8 @@ func New() *tax.RegimeDef {
 // Normalize will attempt to clean the object passed to it.
 func Normalize(doc any) {
 	switch obj := doc.(type) {
+	case *bill.Invoice:
+		normalizeInvoice(obj)
 	case *tax.Identity:
 		normalizeTaxIdentity(obj)
 	case *org.Party:
@@ -55,6 +58,8 @@ func Normalize(doc any) {
 // Validate checks the document type and determines if it can be validated.
 func Validate(doc interface{}) error {
 	switch obj := doc.(type) {
+	case *bill.Invoice:
+		return validateInvoice(obj)
 	case *tax.Identity:
 		return validateTaxIdentity(obj)
 	}
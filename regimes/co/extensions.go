@@ -0,0 +1,125 @@
+package co
+
+import (
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pkg/here"
+)
+
+// Extension keys used in parties to determine which withholding taxes
+// should be applied.
+const (
+	ExtKeyFiscalResponsibility cbc.Key = "co-fiscal-responsibility"
+	ExtKeyWithholdingAgent     cbc.Key = "co-withholding-agent"
+)
+
+// Fiscal responsibility codes registered in the RUT (Registro Único
+// Tributario) that affect withholding taxes.
+const (
+	FiscalResponsibilityLargeTaxpayer   cbc.Code = "O-13"
+	FiscalResponsibilitySelfWithholding cbc.Code = "O-15"
+	FiscalResponsibilityVATAgent        cbc.Code = "O-23"
+	FiscalResponsibilitySimpleRegime    cbc.Code = "O-47"
+	FiscalResponsibilityNone            cbc.Code = "R-99-PN"
+)
+
+// Withholding agent codes used to identify which retained taxes a customer
+// is obliged to withhold from its suppliers.
+const (
+	WithholdingAgentIncome    cbc.Code = "income"
+	WithholdingAgentIncomeVAT cbc.Code = "income-vat"
+)
+
+var extensions = []*cbc.Definition{
+	{
+		Key: ExtKeyFiscalResponsibility,
+		Name: i18n.String{
+			i18n.EN: "Fiscal Responsibility",
+			i18n.ES: "Responsabilidad Fiscal",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Fiscal responsibility of the party as registered in the RUT. Only the
+				responsibilities that affect withholding taxes are included. When a party has
+				more than one, the one that exempts it from withholding should be used.
+			`),
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: FiscalResponsibilityLargeTaxpayer,
+				Name: i18n.String{
+					i18n.EN: "Large taxpayer",
+					i18n.ES: "Gran contribuyente",
+				},
+				Desc: i18n.String{
+					i18n.EN: "Suppliers are not subject to VAT withholding.",
+				},
+			},
+			{
+				Code: FiscalResponsibilitySelfWithholding,
+				Name: i18n.String{
+					i18n.EN: "Self-withholder",
+					i18n.ES: "Autorretenedor",
+				},
+				Desc: i18n.String{
+					i18n.EN: "Suppliers withhold their own income tax, so customers must not.",
+				},
+			},
+			{
+				Code: FiscalResponsibilityVATAgent,
+				Name: i18n.String{
+					i18n.EN: "VAT withholding agent",
+					i18n.ES: "Agente de retención IVA",
+				},
+			},
+			{
+				Code: FiscalResponsibilitySimpleRegime,
+				Name: i18n.String{
+					i18n.EN: "Simple taxation regime",
+					i18n.ES: "Régimen simple de tributación",
+				},
+				Desc: i18n.String{
+					i18n.EN: "Suppliers are not subject to income tax or ICA withholding.",
+				},
+			},
+			{
+				Code: FiscalResponsibilityNone,
+				Name: i18n.String{
+					i18n.EN: "Not applicable",
+					i18n.ES: "No aplica - Otros",
+				},
+			},
+		},
+	},
+	{
+		Key: ExtKeyWithholdingAgent,
+		Name: i18n.String{
+			i18n.EN: "Withholding Agent",
+			i18n.ES: "Agente de Retención",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Used in customers to indicate which taxes they are obliged to withhold from
+				their suppliers. When set, ReteRenta and ReteIVA combos will be added
+				automatically to lines whose item key is "goods" or "services" and whose
+				invoice base exceeds the minimum thresholds in UVT.
+			`),
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: WithholdingAgentIncome,
+				Name: i18n.String{
+					i18n.EN: "Income tax",
+					i18n.ES: "Retención en la fuente a título de renta",
+				},
+			},
+			{
+				Code: WithholdingAgentIncomeVAT,
+				Name: i18n.String{
+					i18n.EN: "Income tax and VAT",
+					i18n.ES: "Retención en la fuente a título de renta e IVA",
+				},
+			},
+		},
+	},
+}
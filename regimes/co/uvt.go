@@ -0,0 +1,44 @@
+package co
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/invopop/gobl/num"
+)
+
+// uvtValues contains the value in COP of the Unidad de Valor Tributario (UVT)
+// for each year, as published by the DIAN.
+var uvtValues = map[int]num.Amount{
+	2019: num.MakeAmount(34270, 0),
+	2020: num.MakeAmount(35607, 0),
+	2021: num.MakeAmount(36308, 0),
+	2022: num.MakeAmount(38004, 0),
+	2023: num.MakeAmount(42412, 0),
+	2024: num.MakeAmount(47065, 0),
+	2025: num.MakeAmount(49799, 0),
+	2026: num.MakeAmount(52374, 0),
+}
+
+var uvtMutex sync.RWMutex
+
+// UVTFor provides the value of the UVT for the year, or an error if no value
+// has been defined.
+func UVTFor(year int) (num.Amount, error) {
+	uvtMutex.RLock()
+	defer uvtMutex.RUnlock()
+	v, ok := uvtValues[year]
+	if !ok {
+		return num.AmountZero, fmt.Errorf("no UVT value defined for %d", year)
+	}
+	return v, nil
+}
+
+// RegisterUVT sets the value of the UVT for the year, replacing any existing
+// value. Withholding thresholds are defined in UVT, so this may be used at
+// the start of each year if GOBL has not yet been updated.
+func RegisterUVT(year int, value num.Amount) {
+	uvtMutex.Lock()
+	defer uvtMutex.Unlock()
+	uvtValues[year] = value
+}
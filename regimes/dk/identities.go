@@ -0,0 +1,54 @@
+package dk
+
+import (
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/validation"
+)
+
+const (
+	// IdentityKeyCVR represents the Danish Central Business Register number
+	// (CVR-nummer) assigned to every business registered in Denmark.
+	IdentityKeyCVR cbc.Key = "dk-cvr"
+)
+
+var identityDefinitions = []*cbc.Definition{
+	{
+		Key: IdentityKeyCVR,
+		Name: i18n.String{
+			i18n.EN: "Central Business Register Number",
+			i18n.DA: "CVR-nummer",
+		},
+	},
+}
+
+// normalizeCVR removes any spaces or separators from the CVR number.
+func normalizeCVR(id *org.Identity) {
+	if id == nil || id.Key != IdentityKeyCVR {
+		return
+	}
+	id.Code = cbc.NormalizeNumericalCode(id.Code)
+}
+
+func validateCVR(id *org.Identity) error {
+	if id == nil || id.Key != IdentityKeyCVR {
+		return nil
+	}
+	return validation.ValidateStruct(id,
+		validation.Field(&id.Code,
+			validation.Required,
+			validation.Match(taxCodeRegexp),
+			validation.By(validateCVRCode),
+			validation.Skip,
+		),
+	)
+}
+
+func validateCVRCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	return checkCVR(code.String())
+}
@@ -0,0 +1,48 @@
+package dk_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/dk"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCVRNormalization(t *testing.T) {
+	r := tax.RegimeDefFor("DK")
+	id := &org.Identity{
+		Key:  dk.IdentityKeyCVR,
+		Code: "13 58 56 28",
+	}
+	r.NormalizeObject(id)
+	assert.Equal(t, "13585628", id.Code.String())
+}
+
+func TestCVRValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "valid 1", code: "13585628"},
+		{name: "valid 2", code: "88146328"},
+		{name: "missing", code: "", err: "code: cannot be blank."},
+		{name: "too short", code: "1358562", err: "code: must be in a valid format."},
+		{name: "bad checksum", code: "13585629", err: "code: checksum mismatch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := &org.Identity{Key: dk.IdentityKeyCVR, Code: tt.code}
+			err := dk.Validate(id)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
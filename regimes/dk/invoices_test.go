@@ -0,0 +1,75 @@
+package dk_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validInvoice() *bill.Invoice {
+	return &bill.Invoice{
+		Series: "TEST",
+		Code:   "0002",
+		Supplier: &org.Party{
+			Name: "Test Supplier",
+			TaxID: &tax.Identity{
+				Country: "DK",
+				Code:    "13585628",
+			},
+		},
+		Customer: &org.Party{
+			Name: "Test Customer",
+			TaxID: &tax.Identity{
+				Country: "DK",
+				Code:    "10403782",
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "bogus",
+					Price: num.MakeAmount(10000, 2),
+					Unit:  org.UnitPackage,
+				},
+				Taxes: tax.Set{
+					{
+						Category: "VAT",
+						Rate:     "standard",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInvoiceValidation(t *testing.T) {
+	inv := validInvoice()
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+	assert.Equal(t, "25.0%", inv.Lines[0].Taxes[0].Percent.String())
+
+	inv = validInvoice()
+	inv.Supplier.TaxID.Code = ""
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "supplier: (tax_id: (code: cannot be blank.).)")
+}
+
+func TestInvoiceReverseCharge(t *testing.T) {
+	inv := validInvoice()
+	inv.SetTags(tax.TagReverseCharge)
+	inv.Customer.TaxID = &tax.Identity{Country: "DE", Code: "111111125"}
+	inv.Lines[0].Taxes[0].Rate = ""
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+	if assert.Len(t, inv.Notes, 1) {
+		assert.Equal(t, cbc.NoteKeyLegal, inv.Notes[0].Key)
+		assert.Contains(t, inv.Notes[0].Text, "Omvendt betalingspligt")
+	}
+}
@@ -0,0 +1,73 @@
+package dk
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/gobl/regimes/common"
+	"github.com/invopop/validation"
+)
+
+// Regime specific payment means extension keys.
+const (
+	// MeansKeyFIK identifies payments made using the FIK (Fælles
+	// Indbetalingskort) payment slip system, where the payment
+	// identification is provided in the instructions' reference.
+	MeansKeyFIK cbc.Key = "fik"
+)
+
+// FIK payment identifications for card types 71 and 75 contain
+// 15 or 16 digits respectively, with a modulus 10 check digit.
+var fikRefRegexp = regexp.MustCompile(`^\d{15,16}$`)
+
+var paymentMeansKeyDefinitions = []*cbc.Definition{
+	{
+		Key: pay.MeansKeyCreditTransfer.With(MeansKeyFIK),
+		Name: i18n.String{
+			i18n.EN: "Credit Transfer with FIK",
+			i18n.DA: "Betaling med FIK",
+		},
+		Desc: i18n.String{
+			i18n.EN: "Payment using a FIK (Fælles Indbetalingskort) payment identification in the remittance information.",
+		},
+	},
+}
+
+func normalizePayInstructions(instr *pay.Instructions) {
+	if instr == nil || !instr.Key.Has(MeansKeyFIK) {
+		return
+	}
+	instr.Ref = cbc.NormalizeNumericalCode(instr.Ref)
+}
+
+func validatePayInstructions(instr *pay.Instructions) error {
+	return validation.ValidateStruct(instr,
+		validation.Field(&instr.Ref,
+			validation.When(
+				instr.Key.Has(MeansKeyFIK),
+				validation.Required,
+				validation.By(validateFIKRef),
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateFIKRef(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+	if !fikRefRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+	n := len(val) - 1
+	if common.ComputeLuhnCheckDigit(val[:n]) != val[n:] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package dk_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/gobl/regimes/dk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayInstructionsNormalization(t *testing.T) {
+	instr := &pay.Instructions{
+		Key: "credit-transfer+fik",
+		Ref: "1234 5678 9012 347",
+	}
+	dk.Normalize(instr)
+	assert.Equal(t, cbc.Code("123456789012347"), instr.Ref)
+
+	instr = &pay.Instructions{
+		Key: "credit-transfer",
+		Ref: "INV-1234",
+	}
+	dk.Normalize(instr)
+	assert.Equal(t, cbc.Code("INV-1234"), instr.Ref)
+}
+
+func TestPayInstructionsValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		key  cbc.Key
+		ref  cbc.Code
+		err  string
+	}{
+		{name: "valid FIK 71", key: "credit-transfer+fik", ref: "123456789012347"},
+		{name: "valid FIK 75", key: "credit-transfer+fik", ref: "1234567890123452"},
+		{name: "no OCR required", key: "credit-transfer", ref: "INV-1234"},
+		{name: "missing FIK", key: "credit-transfer+fik", err: "ref: cannot be blank."},
+		{name: "too short", key: "credit-transfer+fik", ref: "12345674", err: "ref: invalid format."},
+		{name: "not numeric", key: "credit-transfer+fik", ref: "A23456789012347", err: "ref: invalid format."},
+		{name: "bad checksum", key: "credit-transfer+fik", ref: "123456789012348", err: "ref: checksum mismatch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instr := &pay.Instructions{Key: tt.key, Ref: tt.ref}
+			err := dk.Validate(instr)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
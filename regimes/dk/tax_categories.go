@@ -0,0 +1,76 @@
+package dk
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.DA: "Moms",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.DA: "Merværdiafgift",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Skattestyrelsen - VAT",
+					i18n.DA: "Skattestyrelsen - Moms",
+				},
+				URL: "https://skat.dk/erhverv/moms",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+					i18n.DA: "Nulsats",
+				},
+				Description: i18n.String{
+					i18n.EN: "Denmark applies a single standard rate, with zero-rating limited to a few supplies such as newspapers.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.DA: "Standardsats",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(1992, 1, 1),
+						Percent: num.MakePercentage(250, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.DA: "Momsfritaget",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Supplies exempt from VAT under the Danish VAT Act, including healthcare, education, and passenger transport.",
+				},
+			},
+		},
+	},
+}
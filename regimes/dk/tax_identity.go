@@ -0,0 +1,50 @@
+package dk
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Danish VAT numbers are the 8 digit CVR number with the "DK" prefix.
+var (
+	taxCodeMultipliers = []int{2, 7, 6, 5, 4, 3, 2, 1}
+	taxCodeRegexp      = regexp.MustCompile(`^[1-9]\d{7}$`)
+)
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	if !taxCodeRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+
+	return checkCVR(val)
+}
+
+// checkCVR performs the modulus 11 check on the complete CVR number,
+// whose weighted sum must be divisible by 11.
+func checkCVR(val string) error {
+	sum := 0
+	for i, m := range taxCodeMultipliers {
+		sum += int(val[i]-'0') * m
+	}
+	if sum%11 != 0 {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package dk_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/dk"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "DK13585628", expected: "13585628"},
+		{code: "DK 13 58 56 28", expected: "13585628"},
+		{code: "13-58-56-28", expected: "13585628"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "DK", Code: ts.code}
+		dk.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good 1", code: "13585628"},
+		{name: "good 2", code: "10403782"},
+		{name: "good 3", code: "25313763"},
+		{name: "empty", code: ""},
+		{
+			name: "leading zero",
+			code: "03585628",
+			err:  "invalid format",
+		},
+		{
+			name: "too short",
+			code: "1358562",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "135856281",
+			err:  "invalid format",
+		},
+		{
+			name: "bad checksum",
+			code: "12345678",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "DK", Code: tt.code}
+			err := dk.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
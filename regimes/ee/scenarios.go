@@ -0,0 +1,22 @@
+package ee
+
+import (
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+)
+
+var invoiceScenarios = &tax.ScenarioSet{
+	Schema: bill.ShortSchemaInvoice,
+	List: []*tax.Scenario{
+		// Reverse Charges
+		{
+			Tags: []cbc.Key{tax.TagReverseCharge},
+			Note: &cbc.Note{
+				Key:  cbc.NoteKeyLegal,
+				Src:  tax.TagReverseCharge,
+				Text: "Pöördmaksustamine / Reverse charge",
+			},
+		},
+	},
+}
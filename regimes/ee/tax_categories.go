@@ -0,0 +1,116 @@
+package ee
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.ET: "KM",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.ET: "Käibemaks",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Estonian Tax and Customs Board - VAT rates",
+				},
+				URL: "https://www.emta.ee/en/business-client/taxes-and-payment/value-added-tax/value-added-tax-rates",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+					i18n.ET: "Nullmäär",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.ET: "Standardmäär",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2025, 7, 1),
+						Percent: num.MakePercentage(240, 3),
+					},
+					{
+						Since:   cal.NewDate(2024, 1, 1),
+						Percent: num.MakePercentage(220, 3),
+					},
+					{
+						Since:   cal.NewDate(2009, 7, 1),
+						Percent: num.MakePercentage(200, 3),
+					},
+					{
+						Since:   cal.NewDate(2000, 1, 1),
+						Percent: num.MakePercentage(180, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateIntermediate,
+				Name: i18n.String{
+					i18n.EN: "Intermediate Rate",
+					i18n.ET: "Vahemäär",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to accommodation services.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2025, 1, 1),
+						Percent: num.MakePercentage(130, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.ET: "Vähendatud määr",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to books, press publications, and medicines.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2009, 1, 1),
+						Percent: num.MakePercentage(90, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.ET: "Maksuvaba",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Supplies exempt from VAT such as healthcare, education, and insurance services.",
+				},
+			},
+		},
+	},
+}
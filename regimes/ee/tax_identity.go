@@ -0,0 +1,50 @@
+package ee
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Estonian VAT numbers (KMKR number) contain 9 digits starting with "10",
+// with the last acting as a check digit.
+var (
+	taxCodeMultipliers = []int{3, 7, 1, 3, 7, 1, 3, 7}
+	taxCodeRegexp      = regexp.MustCompile(`^10\d{7}$`)
+)
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	if !taxCodeRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+
+	return commercialCheck(val)
+}
+
+func commercialCheck(val string) error {
+	sum := 0
+	for i, m := range taxCodeMultipliers {
+		sum += int(val[i]-'0') * m
+	}
+	check := (10 - sum%10) % 10
+	if check != int(val[8]-'0') {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
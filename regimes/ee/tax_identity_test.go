@@ -0,0 +1,70 @@
+package ee_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/ee"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "EE100931558", expected: "100931558"},
+		{code: "ee 100 931 558", expected: "100931558"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "EE", Code: ts.code}
+		ee.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good 1", code: "100931558"},
+		{name: "good 2", code: "100594102"},
+		{name: "empty", code: ""},
+		{
+			name: "bad prefix",
+			code: "200931558",
+			err:  "invalid format",
+		},
+		{
+			name: "too short",
+			code: "10093155",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "1009315589",
+			err:  "invalid format",
+		},
+		{
+			name: "bad checksum",
+			code: "100931559",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "EE", Code: tt.code}
+			err := ee.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
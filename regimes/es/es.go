@@ -19,6 +19,7 @@ const (
 	TaxCategoryIRPF cbc.Code = "IRPF"
 	TaxCategoryIGIC cbc.Code = "IGIC"
 	TaxCategoryIPSI cbc.Code = "IPSI"
+	TaxCategoryAIEM cbc.Code = "AIEM"
 )
 
 // Specific tax rate codes.
@@ -5,6 +5,7 @@ import (
 
 	"github.com/invopop/gobl/bill"
 	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/org"
 	_ "github.com/invopop/gobl/regimes"
@@ -21,7 +22,7 @@ func testInvoiceStandard(t *testing.T) *bill.Invoice {
 		Code:     "123TEST",
 		Currency: "EUR",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			Name: "Test Supplier",
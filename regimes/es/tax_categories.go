@@ -2,6 +2,7 @@ package es
 
 import (
 	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/i18n"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/pkg/here"
@@ -189,6 +190,10 @@ var taxCategories = []*tax.CategoryDef{
 			i18n.EN: "Canary Island General Indirect Tax",
 			i18n.ES: "Impuesto General Indirecto Canario",
 		},
+		// The AIEM charged on the same supply forms part of the IGIC base.
+		BaseIncludes: []cbc.Code{
+			TaxCategoryAIEM,
+		},
 		// This is a subset of the possible rates.
 		Rates: []*tax.RateDef{
 			{
@@ -230,6 +235,25 @@ var taxCategories = []*tax.CategoryDef{
 		},
 	},
 
+	//
+	// AIEM
+	//
+	{
+		Code:     TaxCategoryAIEM,
+		Retained: false,
+		Name: i18n.String{
+			i18n.EN: "AIEM",
+			i18n.ES: "AIEM",
+		},
+		Title: i18n.String{
+			i18n.EN: "Canary Islands Tax on Imports and Deliveries of Goods",
+			i18n.ES: "Arbitrio sobre Importaciones y Entregas de Mercancías en Canarias",
+		},
+		// AIEM rates depend on the type of goods, so the percentage that
+		// applies should be included directly in the invoice.
+		Rates: []*tax.RateDef{},
+	},
+
 	//
 	// IPSI
 	//
@@ -0,0 +1,77 @@
+// Package se provides the tax region definition for Finland.
+package fi
+
+import (
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/gobl/regimes/common"
+	"github.com/invopop/gobl/tax"
+)
+
+func init() {
+	tax.RegisterRegimeDef(New())
+}
+
+// New provides the tax region definition
+func New() *tax.RegimeDef {
+	return &tax.RegimeDef{
+		Country:  "FI",
+		Currency: currency.EUR,
+		Name: i18n.String{
+			i18n.EN: "Finland",
+			i18n.FI: "Suomi",
+			i18n.SV: "Finland",
+		},
+		TimeZone: "Europe/Helsinki",
+		Tags: []*tax.TagSet{
+			common.InvoiceTags(),
+		},
+		Scenarios: []*tax.ScenarioSet{
+			invoiceScenarios, // scenarios.go
+		},
+		Identities:       identityDefinitions,        // identities.go
+		PaymentMeansKeys: paymentMeansKeyDefinitions, // pay.go
+		Corrections: []*tax.CorrectionDefinition{
+			{
+				Schema: bill.ShortSchemaInvoice,
+				Types: []cbc.Key{
+					bill.InvoiceTypeCreditNote,
+				},
+			},
+		},
+		Validator:  Validate,
+		Normalizer: Normalize,
+		Categories: taxCategories, // tax_categories.go
+	}
+}
+
+// Validate checks the document type and determines if it can be validated.
+func Validate(doc any) error {
+	switch obj := doc.(type) {
+	case *bill.Invoice:
+		return validateInvoice(obj)
+	case *tax.Identity:
+		return validateTaxIdentity(obj)
+	case *org.Identity:
+		return validateBusinessID(obj)
+	case *pay.Instructions:
+		return validatePayInstructions(obj)
+	}
+	return nil
+}
+
+// Normalize will attempt to clean the object passed to it.
+func Normalize(doc any) {
+	switch obj := doc.(type) {
+	case *tax.Identity:
+		tax.NormalizeIdentity(obj)
+	case *org.Identity:
+		normalizeBusinessID(obj)
+	case *pay.Instructions:
+		normalizePayInstructions(obj)
+	}
+}
@@ -0,0 +1,67 @@
+package fi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/validation"
+)
+
+const (
+	// IdentityKeyBusinessID represents the Finnish business ID (Y-tunnus)
+	// assigned by the Finnish Patent and Registration Office and the
+	// Tax Administration.
+	IdentityKeyBusinessID cbc.Key = "fi-business-id"
+)
+
+var businessIDRegexp = regexp.MustCompile(`^\d{7}-\d$`)
+
+var identityDefinitions = []*cbc.Definition{
+	{
+		Key: IdentityKeyBusinessID,
+		Name: i18n.String{
+			i18n.EN: "Business ID",
+			i18n.FI: "Y-tunnus",
+			i18n.SV: "FO-nummer",
+		},
+	},
+}
+
+// normalizeBusinessID will try to format the business ID as it is
+// typically presented: NNNNNNN-N.
+func normalizeBusinessID(id *org.Identity) {
+	if id == nil || id.Key != IdentityKeyBusinessID {
+		return
+	}
+	code := cbc.NormalizeNumericalCode(id.Code).String()
+	if len(code) == 8 {
+		code = fmt.Sprintf("%s-%s", code[:7], code[7:])
+	}
+	id.Code = cbc.Code(code)
+}
+
+func validateBusinessID(id *org.Identity) error {
+	if id == nil || id.Key != IdentityKeyBusinessID {
+		return nil
+	}
+	return validation.ValidateStruct(id,
+		validation.Field(&id.Code,
+			validation.Required,
+			validation.Match(businessIDRegexp),
+			validation.By(validateBusinessIDCode),
+			validation.Skip,
+		),
+	)
+}
+
+func validateBusinessIDCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	return checkBusinessID(strings.Replace(code.String(), "-", "", 1))
+}
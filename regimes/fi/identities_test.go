@@ -0,0 +1,62 @@
+package fi_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/fi"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusinessIDNormalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "already formatted", input: "0112038-9", expected: "0112038-9"},
+		{name: "without separators", input: "01120389", expected: "0112038-9"},
+		{name: "with spaces", input: "0112038 9", expected: "0112038-9"},
+		{name: "too short", input: "112038", expected: "112038"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tax.RegimeDefFor("FI")
+			id := &org.Identity{
+				Key:  fi.IdentityKeyBusinessID,
+				Code: cbc.Code(tt.input),
+			}
+			r.NormalizeObject(id)
+			assert.Equal(t, tt.expected, id.Code.String())
+		})
+	}
+}
+
+func TestBusinessIDValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "valid 1", code: "0112038-9"},
+		{name: "valid 2", code: "2077474-0"},
+		{name: "missing", code: "", err: "code: cannot be blank."},
+		{name: "no separator", code: "01120389", err: "code: must be in a valid format."},
+		{name: "bad checksum", code: "0112038-8", err: "code: checksum mismatch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := &org.Identity{Key: fi.IdentityKeyBusinessID, Code: tt.code}
+			err := fi.Validate(id)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package fi
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/validation"
+)
+
+// Regime specific payment means extension keys.
+const (
+	// MeansKeyReference identifies bank transfers that must include a
+	// Finnish reference number (viitenumero), or its international RF
+	// creditor reference equivalent, so that the payment can be matched
+	// automatically by the recipient.
+	MeansKeyReference cbc.Key = "reference"
+)
+
+var (
+	// National reference numbers contain between 4 and 20 digits, with
+	// the last digit acting as a check digit.
+	nationalRefRegexp = regexp.MustCompile(`^\d{4,20}$`)
+	// RF creditor references (ISO 11649) start with "RF" followed by two
+	// check digits and up to 21 alphanumerical characters.
+	creditorRefRegexp = regexp.MustCompile(`^RF\d{2}[0-9A-Z]{1,21}$`)
+
+	nationalRefMultipliers = []int{7, 3, 1}
+)
+
+var paymentMeansKeyDefinitions = []*cbc.Definition{
+	{
+		Key: pay.MeansKeyCreditTransfer.With(MeansKeyReference),
+		Name: i18n.String{
+			i18n.EN: "Credit Transfer with Reference Number",
+			i18n.FI: "Tilisiirto viitenumerolla",
+		},
+		Desc: i18n.String{
+			i18n.EN: "Bank transfer using a Finnish or RF creditor reference number in the remittance information.",
+		},
+	},
+}
+
+func normalizePayInstructions(instr *pay.Instructions) {
+	if instr == nil || !instr.Key.Has(MeansKeyReference) {
+		return
+	}
+	instr.Ref = cbc.NormalizeAlphanumericalCode(instr.Ref)
+}
+
+func validatePayInstructions(instr *pay.Instructions) error {
+	return validation.ValidateStruct(instr,
+		validation.Field(&instr.Ref,
+			validation.When(
+				instr.Key.Has(MeansKeyReference),
+				validation.Required,
+				validation.By(validateReference),
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateReference(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+	switch {
+	case nationalRefRegexp.MatchString(val):
+		return checkNationalReference(val)
+	case creditorRefRegexp.MatchString(val):
+		return checkCreditorReference(val)
+	}
+	return errors.New("invalid format")
+}
+
+// checkNationalReference uses the 7-3-1 weighting method applied from right
+// to left over the base number to determine the check digit.
+func checkNationalReference(val string) error {
+	n := len(val) - 1
+	sum := 0
+	for i := n - 1; i >= 0; i-- {
+		sum += int(val[i]-'0') * nationalRefMultipliers[(n-1-i)%3]
+	}
+	if strconv.Itoa((10-sum%10)%10) != val[n:] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+// checkCreditorReference validates ISO 11649 references by moving the first
+// four characters to the end, converting letters to numbers, and ensuring
+// the result modulo 97 is 1.
+func checkCreditorReference(val string) error {
+	var sb strings.Builder
+	for _, r := range val[4:] + val[:4] {
+		if r >= 'A' && r <= 'Z' {
+			sb.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	n, _ := new(big.Int).SetString(sb.String(), 10)
+	if new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package fi_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/gobl/regimes/fi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayInstructionsNormalization(t *testing.T) {
+	instr := &pay.Instructions{
+		Key: "credit-transfer+reference",
+		Ref: "rf18 5390 0754 7034",
+	}
+	fi.Normalize(instr)
+	assert.Equal(t, cbc.Code("RF18539007547034"), instr.Ref)
+
+	instr = &pay.Instructions{
+		Key: "credit-transfer",
+		Ref: "INV-1234",
+	}
+	fi.Normalize(instr)
+	assert.Equal(t, cbc.Code("INV-1234"), instr.Ref)
+}
+
+func TestPayInstructionsValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		key  cbc.Key
+		ref  cbc.Code
+		err  string
+	}{
+		{name: "valid national 1", key: "credit-transfer+reference", ref: "1232"},
+		{name: "valid national 2", key: "credit-transfer+reference", ref: "12345614"},
+		{name: "valid creditor 1", key: "credit-transfer+reference", ref: "RF18539007547034"},
+		{name: "valid creditor 2", key: "credit-transfer+reference", ref: "RF341234561"},
+		{name: "no OCR required", key: "credit-transfer", ref: "INV-1234"},
+		{name: "missing reference", key: "credit-transfer+reference", err: "ref: cannot be blank."},
+		{name: "too short", key: "credit-transfer+reference", ref: "123", err: "ref: invalid format."},
+		{name: "not numeric", key: "credit-transfer+reference", ref: "XX18539007547034", err: "ref: invalid format."},
+		{name: "bad national checksum", key: "credit-transfer+reference", ref: "12345615", err: "ref: checksum mismatch."},
+		{name: "bad creditor checksum", key: "credit-transfer+reference", ref: "RF19539007547034", err: "ref: checksum mismatch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instr := &pay.Instructions{Key: tt.key, Ref: tt.ref}
+			err := fi.Validate(instr)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package fi
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.FI: "ALV",
+			i18n.SV: "Moms",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.FI: "Arvonlisävero",
+			i18n.SV: "Mervärdesskatt",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Vero - VAT rates",
+					i18n.FI: "Vero - Arvonlisäverokannat",
+				},
+				URL: "https://www.vero.fi/en/businesses-and-corporations/taxes-and-charges/vat/rates-of-vat/",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+					i18n.FI: "Nollaverokanta",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.FI: "Yleinen verokanta",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2024, 9, 1),
+						Percent: num.MakePercentage(255, 3),
+					},
+					{
+						Since:   cal.NewDate(2013, 1, 1),
+						Percent: num.MakePercentage(240, 3),
+					},
+					{
+						Since:   cal.NewDate(2010, 7, 1),
+						Percent: num.MakePercentage(230, 3),
+					},
+					{
+						Since:   cal.NewDate(1994, 6, 1),
+						Percent: num.MakePercentage(220, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.FI: "Alennettu verokanta",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies mainly to food and restaurant services.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2026, 1, 1),
+						Percent: num.MakePercentage(135, 3),
+					},
+					{
+						Since:   cal.NewDate(2013, 1, 1),
+						Percent: num.MakePercentage(140, 3),
+					},
+					{
+						Since:   cal.NewDate(2010, 7, 1),
+						Percent: num.MakePercentage(130, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateSuperReduced,
+				Name: i18n.String{
+					i18n.EN: "Super-Reduced Rate",
+					i18n.FI: "Toinen alennettu verokanta",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies mainly to passenger transport, accommodation, and newspapers and periodicals.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2013, 1, 1),
+						Percent: num.MakePercentage(100, 3),
+					},
+					{
+						Since:   cal.NewDate(2010, 7, 1),
+						Percent: num.MakePercentage(90, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.FI: "Veroton",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Tax-free supplies such as healthcare, social welfare, education, and financial services.",
+				},
+			},
+		},
+	},
+}
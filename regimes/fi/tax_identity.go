@@ -0,0 +1,59 @@
+package fi
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Finnish VAT numbers are the 8 digit business ID (Y-tunnus) without
+// the hyphen and with the "FI" prefix.
+var (
+	taxCodeMultipliers = []int{7, 9, 10, 5, 8, 4, 2}
+	taxCodeRegexp      = regexp.MustCompile(`^\d{8}$`)
+)
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	if !taxCodeRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+
+	return checkBusinessID(val)
+}
+
+// checkBusinessID validates the modulus 11 check digit in the last position
+// of the 8 digit business ID. Numbers whose remainder is 1 are never issued.
+func checkBusinessID(val string) error {
+	sum := 0
+	for i, m := range taxCodeMultipliers {
+		sum += int(val[i]-'0') * m
+	}
+	r := sum % 11
+	if r == 1 {
+		return errors.New("invalid code")
+	}
+	if r > 1 {
+		r = 11 - r
+	}
+	if strconv.Itoa(r) != val[7:] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package fi_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/fi"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "FI01120389", expected: "01120389"},
+		{code: "0112038-9", expected: "01120389"},
+		{code: "fi 0112 0389", expected: "01120389"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "FI", Code: ts.code}
+		fi.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good 1", code: "01120389"},
+		{name: "good 2", code: "20774740"},
+		{name: "empty", code: ""},
+		{
+			name: "too short",
+			code: "0112038",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "011203891",
+			err:  "invalid format",
+		},
+		{
+			name: "remainder one",
+			code: "11111111",
+			err:  "invalid code",
+		},
+		{
+			name: "bad checksum",
+			code: "01120388",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "FI", Code: tt.code}
+			err := fi.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
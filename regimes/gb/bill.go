@@ -0,0 +1,63 @@
+package gb
+
+import (
+	"errors"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/validation"
+)
+
+// euTaxCountries contains the tax country codes of the EU member states,
+// which may trade goods with Northern Ireland under the Windsor Framework.
+var euTaxCountries = []l10n.TaxCountryCode{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "EL", "ES", "FI", "FR", "HR", "HU",
+	"IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK",
+}
+
+// validateInvoice ensures that invoices tagged for the movement of goods between
+// Northern Ireland and the EU have an XI party on one side and an EU party on
+// the other.
+func validateInvoice(inv *bill.Invoice) error {
+	if !inv.HasTags(TagNIGoods) {
+		return nil
+	}
+	niSupplier := partyTaxCountryIn(inv.Supplier, l10n.XI.Tax())
+	return validation.ValidateStruct(inv,
+		validation.Field(&inv.Supplier,
+			validation.By(validateNIParty(niSupplier)),
+			validation.Skip,
+		),
+		validation.Field(&inv.Customer,
+			validation.Required,
+			validation.By(validateNIParty(!niSupplier)),
+			validation.Skip,
+		),
+	)
+}
+
+// validateNIParty checks the party's tax ID is from Northern Ireland when ni is
+// true, or an EU member state otherwise.
+func validateNIParty(ni bool) validation.RuleFunc {
+	return func(value any) error {
+		p, ok := value.(*org.Party)
+		if !ok || p == nil {
+			return nil
+		}
+		if ni {
+			if !partyTaxCountryIn(p, l10n.XI.Tax()) {
+				return errors.New("tax ID must use the XI country code for Northern Ireland")
+			}
+			return nil
+		}
+		if !partyTaxCountryIn(p, euTaxCountries...) {
+			return errors.New("tax ID must be from an EU member state")
+		}
+		return nil
+	}
+}
+
+func partyTaxCountryIn(p *org.Party, countries ...l10n.TaxCountryCode) bool {
+	return p != nil && p.TaxID != nil && p.TaxID.Country.In(countries...)
+}
@@ -0,0 +1,102 @@
+package gb_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/gb"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInvoiceNI(t *testing.T) *bill.Invoice {
+	t.Helper()
+	return &bill.Invoice{
+		Regime:   tax.WithRegime("GB"),
+		Tags:     tax.WithTags(gb.TagNIGoods),
+		Code:     "0001",
+		Currency: "GBP",
+		Supplier: &org.Party{
+			Name: "Belfast Goods Ltd",
+			TaxID: &tax.Identity{
+				Country: "XI",
+				Code:    "844281425",
+			},
+		},
+		Customer: &org.Party{
+			Name: "Dublin Retail Ltd",
+			TaxID: &tax.Identity{
+				Country: "IE",
+				Code:    "6388047V",
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(10, 0),
+				Item: &org.Item{
+					Name:  "Widgets",
+					Price: num.MakeAmount(2500, 2),
+				},
+				Taxes: tax.Set{
+					{
+						Category: tax.CategoryVAT,
+						Rate:     tax.RateZero,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInvoiceNorthernIrelandGoods(t *testing.T) {
+	inv := testInvoiceNI(t)
+	require.NoError(t, inv.Calculate())
+	require.NoError(t, inv.Validate())
+	require.Len(t, inv.Notes, 1)
+	assert.Equal(t, cbc.NoteKeyLegal, inv.Notes[0].Key)
+	assert.Equal(t, gb.TagNIGoods, inv.Notes[0].Src)
+	assert.Contains(t, inv.Notes[0].Text, "Windsor Framework")
+	assert.Equal(t, "0.00", inv.Totals.Tax.String())
+}
+
+func TestInvoiceNorthernIrelandAcquisition(t *testing.T) {
+	inv := testInvoiceNI(t)
+	inv.Supplier, inv.Customer = inv.Customer, inv.Supplier
+	inv.Supplier.TaxID, inv.Customer.TaxID = inv.Customer.TaxID, inv.Supplier.TaxID
+	inv.Supplier.TaxID = &tax.Identity{Country: "GB", Code: "350983637"}
+	inv.Customer.TaxID = &tax.Identity{Country: "XI", Code: "844281425"}
+	require.NoError(t, inv.Calculate())
+	assert.Empty(t, inv.Notes)
+	assert.ErrorContains(t, inv.Validate(), "supplier: tax ID must be from an EU member state")
+}
+
+func TestInvoiceNorthernIrelandEUSupplier(t *testing.T) {
+	inv := testInvoiceNI(t)
+	inv.Supplier, inv.Customer = inv.Customer, inv.Supplier
+	require.NoError(t, inv.Calculate())
+	assert.Empty(t, inv.Notes)
+	assert.NoError(t, inv.Validate())
+}
+
+func TestInvoiceNorthernIrelandInvalid(t *testing.T) {
+	inv := testInvoiceNI(t)
+	inv.Supplier.TaxID.Country = "GB"
+	require.NoError(t, inv.Calculate())
+	err := inv.Validate()
+	assert.ErrorContains(t, err, "customer: tax ID must use the XI country code for Northern Ireland")
+	assert.ErrorContains(t, err, "supplier: tax ID must be from an EU member state")
+
+	inv = testInvoiceNI(t)
+	inv.Customer = nil
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "customer: cannot be blank")
+
+	inv = testInvoiceNI(t)
+	inv.Customer.TaxID = &tax.Identity{Country: "US"}
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "customer: tax ID must be from an EU member state")
+}
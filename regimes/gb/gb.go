@@ -40,10 +40,10 @@ func New() *tax.RegimeDef {
 		Validator:  Validate,
 		Normalizer: Normalize,
 		Scenarios: []*tax.ScenarioSet{
-			common.InvoiceScenarios(),
+			invoiceScenarios(),
 		},
 		Tags: []*tax.TagSet{
-			common.InvoiceTags(),
+			common.InvoiceTags().Merge(invoiceTags),
 		},
 		Categories: taxCategories,
 		Corrections: []*tax.CorrectionDefinition{
@@ -63,6 +63,8 @@ func Validate(doc interface{}) error {
 	switch obj := doc.(type) {
 	case *tax.Identity:
 		return validateTaxIdentity(obj)
+	case *bill.Invoice:
+		return validateInvoice(obj)
 	}
 	return nil
 }
@@ -0,0 +1,68 @@
+package gb
+
+import (
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/regimes/common"
+	"github.com/invopop/gobl/tax"
+)
+
+// Document tag keys
+const (
+	// TagNIGoods identifies the movement of goods between Northern Ireland
+	// and an EU member state under the Windsor Framework.
+	TagNIGoods cbc.Key = "ni-goods"
+)
+
+// invoiceTags extends the common tags with the tag used by Northern Ireland
+// businesses trading goods with the EU under the Windsor Framework.
+var invoiceTags = &tax.TagSet{
+	Schema: bill.ShortSchemaInvoice,
+	List: []*cbc.Definition{
+		{
+			Key: TagNIGoods,
+			Name: i18n.String{
+				i18n.EN: "Northern Ireland and EU goods",
+			},
+			Desc: i18n.String{
+				i18n.EN: "Movement of goods between Northern Ireland and an EU member state, where the supplier or customer must be identified with an XI VAT number.",
+			},
+		},
+	},
+}
+
+// invoiceScenarios combines the common scenarios with those specific to
+// Northern Ireland.
+func invoiceScenarios() *tax.ScenarioSet {
+	ss := tax.NewScenarioSet(bill.ShortSchemaInvoice)
+	ss.Merge([]*tax.ScenarioSet{
+		common.InvoiceScenarios(),
+		niInvoiceScenarios,
+	})
+	return ss
+}
+
+var niInvoiceScenarios = &tax.ScenarioSet{
+	Schema: bill.ShortSchemaInvoice,
+	List: []*tax.Scenario{
+		{
+			Tags:   []cbc.Key{TagNIGoods},
+			Filter: isNorthernIrelandSupplier,
+			Note: &cbc.Note{
+				Key:  cbc.NoteKeyLegal,
+				Src:  TagNIGoods,
+				Text: "Zero-rated supply of goods from Northern Ireland to an EU member state under the Windsor Framework.",
+			},
+		},
+	},
+}
+
+func isNorthernIrelandSupplier(doc any) bool {
+	inv, ok := doc.(*bill.Invoice)
+	if !ok || inv.Supplier == nil || inv.Supplier.TaxID == nil {
+		return false
+	}
+	return inv.Supplier.TaxID.Country == l10n.XI.Tax()
+}
@@ -31,7 +31,8 @@ var (
 	}
 )
 
-// validateTaxIdentity checks to ensure the NIT code looks okay.
+// validateTaxIdentity checks to ensure the VAT number looks okay. The same rules
+// apply to Northern Ireland VAT numbers issued with the XI country code.
 func validateTaxIdentity(tID *tax.Identity) error {
 	return validation.ValidateStruct(tID,
 		validation.Field(&tID.Code, validation.By(validateTaxCode)),
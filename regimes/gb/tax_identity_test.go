@@ -69,3 +69,16 @@ func TestValidateTaxIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestNorthernIrelandTaxIdentity(t *testing.T) {
+	tID := &tax.Identity{Country: "XI", Code: "XI 844 2814 25"}
+	gb.Normalize(tID)
+	assert.Equal(t, cbc.Code("844281425"), tID.Code)
+	assert.NoError(t, tID.Validate())
+
+	tID = &tax.Identity{Country: "XI", Code: "999999991"}
+	assert.ErrorContains(t, tID.Validate(), "checksum mismatch")
+
+	tID = &tax.Identity{Country: "XI", Code: "GD500"}
+	assert.ErrorContains(t, tID.Validate(), "invalid government department number")
+}
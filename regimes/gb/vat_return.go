@@ -0,0 +1,253 @@
+package gb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+)
+
+// VATReturn contains the nine boxes of the Making Tax Digital (MTD) VAT return
+// submitted to HMRC for a given period. Boxes 1 to 5 are provided in pounds
+// and pence, while boxes 6 to 9 are in whole pounds with the pence removed,
+// as required by HMRC.
+type VATReturn struct {
+	// VAT registration number of the business filing the return.
+	TaxID *tax.Identity `json:"tax_id" jsonschema:"title=Tax ID"`
+	// Period covered by the return.
+	Period cal.Period `json:"period" jsonschema:"title=Period"`
+
+	// Box 1: VAT due on sales and other outputs (vatDueSales).
+	VATDueSales num.Amount `json:"vat_due_sales" jsonschema:"title=VAT Due on Sales"`
+	// Box 2: VAT due on acquisitions of goods from EU member states by
+	// Northern Ireland businesses (vatDueAcquisitions).
+	VATDueAcquisitions num.Amount `json:"vat_due_acquisitions" jsonschema:"title=VAT Due on Acquisitions"`
+	// Box 3: Total VAT due, the sum of boxes 1 and 2 (totalVatDue).
+	TotalVATDue num.Amount `json:"total_vat_due" jsonschema:"title=Total VAT Due"`
+	// Box 4: VAT reclaimed on purchases and other inputs (vatReclaimedCurrPeriod).
+	VATReclaimed num.Amount `json:"vat_reclaimed" jsonschema:"title=VAT Reclaimed"`
+	// Box 5: Net VAT to pay or reclaim, the difference between boxes 3 and 4 (netVatDue).
+	NetVATDue num.Amount `json:"net_vat_due" jsonschema:"title=Net VAT Due"`
+	// Box 6: Total value of sales and other outputs excluding VAT (totalValueSalesExVAT).
+	TotalValueSales num.Amount `json:"total_value_sales" jsonschema:"title=Total Value of Sales"`
+	// Box 7: Total value of purchases and other inputs excluding VAT (totalValuePurchasesExVAT).
+	TotalValuePurchases num.Amount `json:"total_value_purchases" jsonschema:"title=Total Value of Purchases"`
+	// Box 8: Total value of goods supplied from Northern Ireland to EU member
+	// states excluding VAT (totalValueGoodsSuppliedExVAT).
+	TotalValueGoodsSupplied num.Amount `json:"total_value_goods_supplied" jsonschema:"title=Total Value of Goods Supplied"`
+	// Box 9: Total value of goods acquired in Northern Ireland from EU member
+	// states excluding VAT (totalAcquisitionsExVAT).
+	TotalAcquisitions num.Amount `json:"total_acquisitions" jsonschema:"title=Total Acquisitions"`
+}
+
+// NewVATReturn builds the MTD VAT return for the business with the provided
+// tax identity using the sales and purchase invoices issued during the period.
+// Invoices are classified as sales when the supplier matches the tax ID, or
+// purchases when the customer does. Invoices issued outside of the period are
+// ignored, credit notes are subtracted, and amounts in other currencies are
+// converted into pounds using the invoice's exchange rates.
+//
+// Purchases with the "reverse-charge" tag will have VAT self-accounted for at the
+// applicable UK rate in both boxes 1 and 4, with the value included in both
+// boxes 6 and 7. The invoices provided are not modified. Invoices with the "ni-goods" tag where the
+// business is identified with a Northern Ireland ("XI") tax ID will be included
+// in boxes 8 and 9 for goods supplied to or acquired from the EU.
+func NewVATReturn(tID *tax.Identity, period cal.Period, invoices []*bill.Invoice) (*VATReturn, error) {
+	if tID == nil || tID.Code == "" {
+		return nil, errors.New("tax ID required")
+	}
+	if err := period.Validate(); err != nil {
+		return nil, fmt.Errorf("period: %w", err)
+	}
+	id := *tID
+	tax.NormalizeIdentity(&id, altCountryCodes...)
+	vr := &VATReturn{
+		TaxID:                   &id,
+		Period:                  period,
+		VATDueSales:             currency.GBP.Def().Zero(),
+		VATDueAcquisitions:      currency.GBP.Def().Zero(),
+		VATReclaimed:            currency.GBP.Def().Zero(),
+		TotalValueSales:         currency.GBP.Def().Zero(),
+		TotalValuePurchases:     currency.GBP.Def().Zero(),
+		TotalValueGoodsSupplied: currency.GBP.Def().Zero(),
+		TotalAcquisitions:       currency.GBP.Def().Zero(),
+	}
+	for _, inv := range invoices {
+		if err := vr.add(inv); err != nil {
+			return nil, fmt.Errorf("invoice %s: %w", invoiceNumber(inv), err)
+		}
+	}
+	vr.calculate()
+	return vr, nil
+}
+
+func (vr *VATReturn) add(inv *bill.Invoice) error {
+	if inv == nil || inv.Type == bill.InvoiceTypeProforma {
+		return nil
+	}
+	if inv.IssueDate.Before(vr.Period.Start.Date) || inv.IssueDate.After(vr.Period.End.Date) {
+		return nil
+	}
+	inv, err := cloneInvoice(inv)
+	if err != nil {
+		return err
+	}
+	// conversion will also calculate and normalize the invoice
+	inv, err = inv.ConvertInto(currency.GBP)
+	if err != nil {
+		return err
+	}
+	sale := matchesTaxID(inv.Supplier, vr.TaxID)
+	purchase := matchesTaxID(inv.Customer, vr.TaxID)
+	if !sale && !purchase {
+		return errors.New("supplier or customer must match tax ID")
+	}
+
+	total := inv.Totals.Total
+	vat := num.AmountZero
+	if ct := vatTotal(inv); ct != nil {
+		vat = ct.Amount
+	}
+	if inv.Type == bill.InvoiceTypeCreditNote {
+		total = total.Invert()
+		vat = vat.Invert()
+	}
+	ni := inv.HasTags(TagNIGoods)
+	rc := inv.HasTags(tax.TagReverseCharge)
+
+	if sale {
+		vr.TotalValueSales = vr.TotalValueSales.Add(total)
+		if !rc {
+			vr.VATDueSales = vr.VATDueSales.Add(vat)
+		}
+		if ni && isNorthernIreland(inv.Supplier) {
+			vr.TotalValueGoodsSupplied = vr.TotalValueGoodsSupplied.Add(total)
+		}
+	}
+	if purchase {
+		vr.TotalValuePurchases = vr.TotalValuePurchases.Add(total)
+		switch {
+		case ni && isNorthernIreland(inv.Customer):
+			due := selfAccountedVAT(inv)
+			vr.TotalAcquisitions = vr.TotalAcquisitions.Add(total)
+			vr.VATDueAcquisitions = vr.VATDueAcquisitions.Add(due)
+			vr.VATReclaimed = vr.VATReclaimed.Add(due)
+		case rc:
+			due := selfAccountedVAT(inv)
+			vr.TotalValueSales = vr.TotalValueSales.Add(total)
+			vr.VATDueSales = vr.VATDueSales.Add(due)
+			vr.VATReclaimed = vr.VATReclaimed.Add(due)
+		default:
+			vr.VATReclaimed = vr.VATReclaimed.Add(vat)
+		}
+	}
+	return nil
+}
+
+func (vr *VATReturn) calculate() {
+	vr.TotalVATDue = vr.VATDueSales.Add(vr.VATDueAcquisitions)
+	vr.NetVATDue = vr.TotalVATDue.Subtract(vr.VATReclaimed).Abs()
+	vr.TotalValueSales = wholePounds(vr.TotalValueSales)
+	vr.TotalValuePurchases = wholePounds(vr.TotalValuePurchases)
+	vr.TotalValueGoodsSupplied = wholePounds(vr.TotalValueGoodsSupplied)
+	vr.TotalAcquisitions = wholePounds(vr.TotalAcquisitions)
+}
+
+// selfAccountedVAT determines the amount of VAT the customer must account for
+// on a reverse charge or acquisition invoice. The UK percentage for each rate
+// key is always used, as the supplier's percentages may belong to another
+// country, with the UK standard rate applied to any unknown or missing keys.
+func selfAccountedVAT(inv *bill.Invoice) num.Amount {
+	sum := currency.GBP.Def().Zero()
+	ct := vatTotal(inv)
+	if ct == nil {
+		p := ukRate(tax.RateStandard, inv.IssueDate)
+		sum = sum.Add(p.Of(inv.Totals.Total))
+	} else {
+		for _, rt := range ct.Rates {
+			p := ukRate(rt.Key, inv.IssueDate)
+			sum = sum.Add(p.Of(rt.Base))
+		}
+	}
+	sum = sum.Rescale(currency.GBP.Def().Subunits)
+	if inv.Type == bill.InvoiceTypeCreditNote {
+		sum = sum.Invert()
+	}
+	return sum
+}
+
+func vatTotal(inv *bill.Invoice) *tax.CategoryTotal {
+	if inv.Totals == nil || inv.Totals.Taxes == nil {
+		return nil
+	}
+	return inv.Totals.Taxes.Category(tax.CategoryVAT)
+}
+
+// ukRate provides the UK VAT percentage for the rate key on the date, or the
+// standard rate if the key does not have a percentage in the UK.
+func ukRate(key cbc.Key, date cal.Date) num.Percentage {
+	r := New()
+	for _, k := range []cbc.Key{key, tax.RateStandard} {
+		if rd := r.RateDef(tax.CategoryVAT, k); rd != nil {
+			if rv := rd.Value(date, nil, nil); rv != nil {
+				return rv.Percent
+			}
+		}
+	}
+	return num.MakePercentage(200, 3)
+}
+
+// cloneInvoice makes a deep copy of the invoice by serializing and
+// deserializing it, so that calculations will not modify the original.
+func cloneInvoice(inv *bill.Invoice) (*bill.Invoice, error) {
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return nil, err
+	}
+	inv2 := new(bill.Invoice)
+	if err := json.Unmarshal(data, inv2); err != nil {
+		return nil, err
+	}
+	return inv2, nil
+}
+
+// wholePounds removes the pence from the amount, as required by boxes 6 to 9.
+func wholePounds(a num.Amount) num.Amount {
+	v := a.Value()
+	for i := uint32(0); i < a.Exp(); i++ {
+		v /= 10
+	}
+	return num.MakeAmount(v, 0)
+}
+
+func matchesTaxID(p *org.Party, tID *tax.Identity) bool {
+	if p == nil || p.TaxID == nil {
+		return false
+	}
+	if p.TaxID.Country != l10n.GB.Tax() && !p.TaxID.Country.In(l10n.XI.Tax(), l10n.XU.Tax()) {
+		return false
+	}
+	return p.TaxID.Code == tID.Code
+}
+
+func isNorthernIreland(p *org.Party) bool {
+	return p != nil && p.TaxID != nil && p.TaxID.Country == l10n.XI.Tax()
+}
+
+func invoiceNumber(inv *bill.Invoice) string {
+	if inv == nil {
+		return ""
+	}
+	if inv.Series != "" {
+		return inv.Series.String() + "-" + inv.Code.String()
+	}
+	return inv.Code.String()
+}
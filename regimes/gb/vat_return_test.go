@@ -0,0 +1,165 @@
+package gb_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/currency"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/gb"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testVATReturnPeriod = cal.Period{
+	Start: cal.MakeDate(2024, 1, 1),
+	End:   cal.MakeDate(2024, 3, 31),
+}
+
+func testVATReturnInvoice(supplier, customer *tax.Identity, price num.Amount, taxes tax.Set) *bill.Invoice {
+	return &bill.Invoice{
+		Code:      "0001",
+		Currency:  currency.GBP,
+		IssueDate: cal.MakeDate(2024, 2, 1),
+		Supplier: &org.Party{
+			Name:  "Supplier",
+			TaxID: supplier,
+		},
+		Customer: &org.Party{
+			Name:  "Customer",
+			TaxID: customer,
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "Item",
+					Price: price,
+				},
+				Taxes: taxes,
+			},
+		},
+	}
+}
+
+func TestNewVATReturn(t *testing.T) {
+	own := &tax.Identity{Country: "GB", Code: "844281425"}
+	other := &tax.Identity{Country: "GB", Code: "350983637"}
+	std := tax.Set{{Category: tax.CategoryVAT, Rate: tax.RateStandard}}
+
+	sale := testVATReturnInvoice(own, other, num.MakeAmount(100000, 2), std)
+	purchase := testVATReturnInvoice(other, own, num.MakeAmount(50055, 2), std)
+	credit := testVATReturnInvoice(own, other, num.MakeAmount(10000, 2), std)
+	credit.Type = bill.InvoiceTypeCreditNote
+	rc := testVATReturnInvoice(&tax.Identity{Country: "DE", Code: "111111125"}, own, num.MakeAmount(30000, 2), nil)
+	rc.Currency = currency.EUR
+	rc.SetTags(tax.TagReverseCharge)
+	rc.ExchangeRates = []*currency.ExchangeRate{
+		{From: currency.EUR, To: currency.GBP, Amount: num.MakeAmount(85, 2)},
+	}
+	late := testVATReturnInvoice(own, other, num.MakeAmount(99900, 2), std)
+	late.IssueDate = cal.MakeDate(2024, 4, 1)
+
+	vr, err := gb.NewVATReturn(
+		&tax.Identity{Country: "GB", Code: "GB 844 2814 25"},
+		testVATReturnPeriod,
+		[]*bill.Invoice{sale, purchase, credit, rc, late},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cbc.Code("844281425"), vr.TaxID.Code)
+	assert.Equal(t, "231.00", vr.VATDueSales.String())
+	assert.Equal(t, "0.00", vr.VATDueAcquisitions.String())
+	assert.Equal(t, "231.00", vr.TotalVATDue.String())
+	assert.Equal(t, "151.11", vr.VATReclaimed.String())
+	assert.Equal(t, "79.89", vr.NetVATDue.String())
+	assert.Equal(t, "1155", vr.TotalValueSales.String()) // includes reverse charge
+	assert.Equal(t, "755", vr.TotalValuePurchases.String())
+	assert.Equal(t, "0", vr.TotalValueGoodsSupplied.String())
+	assert.Equal(t, "0", vr.TotalAcquisitions.String())
+}
+
+func TestNewVATReturnReverseCharge(t *testing.T) {
+	own := &tax.Identity{Country: "GB", Code: "844281425"}
+	rc := testVATReturnInvoice(
+		&tax.Identity{Country: "DE", Code: "111111125"},
+		own,
+		num.MakeAmount(100000, 2),
+		tax.Set{{Category: tax.CategoryVAT, Percent: num.NewPercentage(19, 2)}},
+	)
+	rc.Currency = currency.EUR
+	rc.SetTags(tax.TagReverseCharge)
+	rc.ExchangeRates = []*currency.ExchangeRate{
+		{From: currency.EUR, To: currency.GBP, Amount: num.MakeAmount(85, 2)},
+	}
+
+	vr, err := gb.NewVATReturn(own, testVATReturnPeriod, []*bill.Invoice{rc})
+	require.NoError(t, err)
+
+	// UK standard rate, not the supplier's 19%
+	assert.Equal(t, "170.00", vr.VATDueSales.String())
+	assert.Equal(t, "170.00", vr.VATReclaimed.String())
+	assert.Equal(t, "0.00", vr.NetVATDue.String())
+	assert.Equal(t, "850", vr.TotalValueSales.String())
+	assert.Equal(t, "850", vr.TotalValuePurchases.String())
+
+	t.Run("leaves invoices untouched", func(t *testing.T) {
+		assert.Nil(t, rc.Totals)
+		assert.Equal(t, currency.EUR, rc.Currency)
+		assert.True(t, rc.Lines[0].Sum.IsZero())
+	})
+}
+
+func TestNewVATReturnNorthernIreland(t *testing.T) {
+	own := &tax.Identity{Country: "XI", Code: "844281425"}
+	eu := &tax.Identity{Country: "IE", Code: "6388047V"}
+
+	sale := testVATReturnInvoice(own, eu, num.MakeAmount(200000, 2),
+		tax.Set{{Category: tax.CategoryVAT, Rate: tax.RateZero}},
+	)
+	sale.SetTags(gb.TagNIGoods)
+	acq := testVATReturnInvoice(eu, own, num.MakeAmount(50000, 2), nil)
+	acq.Currency = currency.EUR
+	acq.SetTags(gb.TagNIGoods)
+	acq.ExchangeRates = []*currency.ExchangeRate{
+		{From: currency.EUR, To: currency.GBP, Amount: num.MakeAmount(85, 2)},
+	}
+
+	vr, err := gb.NewVATReturn(
+		&tax.Identity{Country: "GB", Code: "844281425"},
+		testVATReturnPeriod,
+		[]*bill.Invoice{sale, acq},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.00", vr.VATDueSales.String())
+	assert.Equal(t, "85.00", vr.VATDueAcquisitions.String())
+	assert.Equal(t, "85.00", vr.VATReclaimed.String())
+	assert.Equal(t, "0.00", vr.NetVATDue.String())
+	assert.Equal(t, "2000", vr.TotalValueSales.String())
+	assert.Equal(t, "2000", vr.TotalValueGoodsSupplied.String())
+	assert.Equal(t, "425", vr.TotalAcquisitions.String())
+}
+
+func TestNewVATReturnErrors(t *testing.T) {
+	_, err := gb.NewVATReturn(nil, testVATReturnPeriod, nil)
+	assert.ErrorContains(t, err, "tax ID required")
+
+	own := &tax.Identity{Country: "GB", Code: "844281425"}
+	_, err = gb.NewVATReturn(own, cal.Period{}, nil)
+	assert.ErrorContains(t, err, "period: ")
+
+	other := &tax.Identity{Country: "GB", Code: "350983637"}
+	inv := testVATReturnInvoice(other, other, num.MakeAmount(10000, 2), nil)
+	_, err = gb.NewVATReturn(own, testVATReturnPeriod, []*bill.Invoice{inv})
+	assert.ErrorContains(t, err, "invoice 0001: supplier or customer must match tax ID")
+
+	inv = testVATReturnInvoice(own, other, num.MakeAmount(10000, 2), nil)
+	inv.Currency = currency.EUR
+	_, err = gb.NewVATReturn(own, testVATReturnPeriod, []*bill.Invoice{inv})
+	assert.ErrorContains(t, err, "no exchange rate defined for 'EUR' to 'GBP'")
+}
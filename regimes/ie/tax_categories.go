@@ -0,0 +1,131 @@
+package ie
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.GA: "CBL",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.GA: "Cáin Bhreisluacha",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Revenue - Current VAT rates",
+				},
+				URL: "https://www.revenue.ie/en/vat/vat-rates/search-vat-rates/current-vat-rates.aspx",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2021, 3, 1),
+						Percent: num.MakePercentage(230, 3),
+					},
+					{
+						// Temporary reduction during the COVID-19 pandemic.
+						Since:   cal.NewDate(2020, 9, 1),
+						Percent: num.MakePercentage(210, 3),
+					},
+					{
+						Since:   cal.NewDate(2012, 1, 1),
+						Percent: num.MakePercentage(230, 3),
+					},
+					{
+						Since:   cal.NewDate(2010, 1, 1),
+						Percent: num.MakePercentage(210, 3),
+					},
+					{
+						Since:   cal.NewDate(2008, 12, 1),
+						Percent: num.MakePercentage(215, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to fuel, electricity, building services and repairs, among others.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2003, 1, 1),
+						Percent: num.MakePercentage(135, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateSuperReduced,
+				Name: i18n.String{
+					i18n.EN: "Second Reduced Rate",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to newspapers, e-books, sporting facilities, and certain hospitality services.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2011, 7, 1),
+						Percent: num.MakePercentage(90, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateSpecial,
+				Name: i18n.String{
+					i18n.EN: "Livestock Rate",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to the supply of livestock, greyhounds, and the hire of horses.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2005, 1, 1),
+						Percent: num.MakePercentage(48, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Exempt activities such as financial, medical, and educational services.",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,68 @@
+package ie
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Irish VAT numbers come in two formats:
+//
+//   - current: seven digits followed by a check letter, and an optional
+//     second letter (A-I or W) for numbers issued since 2013, e.g. "6433435F"
+//     or "6433435OA".
+//   - legacy: a digit, a letter, five digits and a check letter, e.g. "8Z49289F".
+var (
+	taxCodeRegexp       = regexp.MustCompile(`^\d{7}[A-W][A-IW]?$`)
+	taxCodeLegacyRegexp = regexp.MustCompile(`^\d[A-Z]\d{5}[A-W]$`)
+)
+
+const (
+	taxCodeCheckLetters  = "WABCDEFGHIJKLMNOPQRSTUV"
+	taxCodeSecondLetters = "WABCDEFGHI"
+)
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	if taxCodeLegacyRegexp.MatchString(val) {
+		// Convert to the current format before checking, with the
+		// first digit moved to the end and padded with a zero.
+		val = "0" + val[2:7] + val[0:1] + val[7:]
+	} else if !taxCodeRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+
+	return commercialCheck(val)
+}
+
+// commercialCheck applies the weights 8 to 2 to the first seven digits, and 9
+// to the optional second letter, to determine the modulus 23 check letter.
+func commercialCheck(val string) error {
+	sum := 0
+	for i := 0; i < 7; i++ {
+		sum += int(val[i]-'0') * (8 - i)
+	}
+	if len(val) == 9 {
+		sum += strings.IndexByte(taxCodeSecondLetters, val[8]) * 9
+	}
+	if taxCodeCheckLetters[sum%23] != val[7] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/ie"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "IE6433435F", expected: "6433435F"},
+		{code: "ie 8Z49289F", expected: "8Z49289F"},
+		{code: "IE-6433435-OA", expected: "6433435OA"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "IE", Code: ts.code}
+		ie.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good current", code: "6433435F"},
+		{name: "good second letter", code: "6433435OA"},
+		{name: "good 3", code: "3628739L"},
+		{name: "good legacy", code: "8Z49289F"},
+		{name: "good legacy 2", code: "8D79739I"},
+		{name: "empty", code: ""},
+		{
+			name: "too short",
+			code: "643343F",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "64334355F",
+			err:  "invalid format",
+		},
+		{
+			name: "bad second letter",
+			code: "6433435FZ",
+			err:  "invalid format",
+		},
+		{
+			name: "bad checksum",
+			code: "6433435E",
+			err:  "checksum mismatch",
+		},
+		{
+			name: "bad legacy checksum",
+			code: "8Z49289G",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "IE", Code: tt.code}
+			err := ie.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
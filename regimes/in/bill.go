@@ -1,6 +1,18 @@
 package in
 
-import "github.com/invopop/gobl/cbc"
+import (
+	"errors"
+	"maps"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/l10n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
 
 const (
 	// ChargeKeyCompensationCess is used for addtional charges added to an invoice for the special
@@ -11,3 +23,245 @@ const (
 	// harmful to the environment or society.
 	ChargeKeyCompensationCess cbc.Key = "compensation-cess"
 )
+
+// normalizeInvoice determines the place of supply and replaces any generic GST
+// taxes with the CGST and SGST/UTGST, or IGST, combinations. Compensation cess
+// percentages are also set from the item's HSN code when not provided.
+func normalizeInvoice(inv *bill.Invoice) {
+	if inv == nil {
+		return
+	}
+	if supplier, pos := placeOfSupply(inv); supplier != nil {
+		intra := supplier == pos
+		for _, line := range inv.Lines {
+			if line != nil {
+				line.Taxes = splitGST(line.Taxes, intra, supplier.UT)
+			}
+		}
+		for _, dis := range inv.Discounts {
+			if dis != nil {
+				dis.Taxes = splitGST(dis.Taxes, intra, supplier.UT)
+			}
+		}
+		for _, chr := range inv.Charges {
+			if chr != nil {
+				chr.Taxes = splitGST(chr.Taxes, intra, supplier.UT)
+			}
+		}
+	}
+	for _, line := range inv.Lines {
+		if line != nil {
+			normalizeLineCess(line, inv.IssueDate)
+		}
+	}
+}
+
+// placeOfSupply provides the supplier's state and the state considered as the
+// place of supply, which will be nil for supplies outside India. The delivery
+// receiver's address takes priority, followed by the customer's GSTIN and then
+// their address. Without any customer details, the supply is assumed to take
+// place in the supplier's state.
+func placeOfSupply(inv *bill.Invoice) (*stateDef, *stateDef) {
+	supplier := partyState(inv.Supplier)
+	if supplier == nil {
+		return nil, nil
+	}
+	if inv.Delivery != nil && inv.Delivery.Receiver != nil {
+		if addr := partyAddress(inv.Delivery.Receiver); addr != nil {
+			if isForeignAddress(addr) {
+				return supplier, nil
+			}
+			if s := stateForAddress(addr); s != nil {
+				return supplier, s
+			}
+		}
+	}
+	cus := inv.Customer
+	if cus == nil {
+		return supplier, supplier
+	}
+	if cus.TaxID != nil && cus.TaxID.Country != "" && cus.TaxID.Country != l10n.IN.Tax() {
+		return supplier, nil
+	}
+	if s := stateForTaxIdentity(cus.TaxID); s != nil {
+		return supplier, s
+	}
+	if addr := partyAddress(cus); addr != nil {
+		if isForeignAddress(addr) {
+			return supplier, nil
+		}
+		if s := stateForAddress(addr); s != nil {
+			return supplier, s
+		}
+	}
+	return supplier, supplier
+}
+
+func partyState(party *org.Party) *stateDef {
+	if party == nil {
+		return nil
+	}
+	if s := stateForTaxIdentity(party.TaxID); s != nil {
+		return s
+	}
+	return stateForAddress(partyAddress(party))
+}
+
+func partyAddress(party *org.Party) *org.Address {
+	if party == nil || len(party.Addresses) == 0 {
+		return nil
+	}
+	return party.Addresses[0]
+}
+
+func isForeignAddress(addr *org.Address) bool {
+	return addr.Country != "" && addr.Country != l10n.IN.ISO()
+}
+
+// splitGST replaces GST combos with the equivalent CGST and SGST/UTGST halves
+// for intra-state supplies, or IGST for everything else.
+func splitGST(ts tax.Set, intra, ut bool) tax.Set {
+	if ts == nil {
+		return nil
+	}
+	out := make(tax.Set, 0, len(ts))
+	for _, c := range ts {
+		if c == nil || c.Category != tax.CategoryGST {
+			out = append(out, c)
+			continue
+		}
+		if !intra {
+			c.Category = TaxCategoryIGST
+			out = append(out, c)
+			continue
+		}
+		sc := &tax.Combo{
+			Category: TaxCategorySGST,
+			Country:  c.Country,
+			Ext:      maps.Clone(c.Ext),
+		}
+		if ut {
+			sc.Category = TaxCategoryUTGST
+		}
+		c.Category = TaxCategoryCGST
+		if c.Percent != nil {
+			p := halfPercentage(*c.Percent)
+			c.Percent = &p
+			sc.Percent = &p
+		}
+		out = append(out, c, sc)
+	}
+	return out
+}
+
+// halfPercentage divides the percentage in two, only adding precision when
+// required, so that 18% becomes 9% and 5% becomes 2.5%.
+func halfPercentage(p num.Percentage) num.Percentage {
+	v := p.Value() * 5
+	e := p.Exp() + 1
+	if v%10 == 0 {
+		v /= 10
+		e--
+	}
+	return num.MakePercentage(v, e)
+}
+
+func normalizeLineCess(line *bill.Line, date cal.Date) {
+	if line.Item == nil {
+		return
+	}
+	c := line.Taxes.Get(TaxCategoryCess)
+	if c == nil || c.Percent != nil || c.Rate != cbc.KeyEmpty {
+		return
+	}
+	id := org.IdentityForType(line.Item.Identities, IdentityTypeHSN)
+	if id == nil {
+		return
+	}
+	c.Percent = compensationCessPercent(cbc.NormalizeNumericalCode(id.Code).String(), date)
+}
+
+// validateInvoice ensures no generic GST taxes remain in the invoice, which
+// would imply the place of supply could not be determined, and that lines,
+// discounts, and charges use the GST categories expected for the place of
+// supply.
+func validateInvoice(inv *bill.Invoice) error {
+	v := gstValidator(inv)
+	return validation.ValidateStruct(inv,
+		validation.Field(&inv.Lines,
+			validation.Each(
+				validation.By(func(value any) error {
+					if line, ok := value.(*bill.Line); ok && line != nil {
+						return v(line.Taxes)
+					}
+					return nil
+				}),
+				validation.Skip,
+			),
+			validation.Skip,
+		),
+		validation.Field(&inv.Discounts,
+			validation.Each(
+				validation.By(func(value any) error {
+					if dis, ok := value.(*bill.Discount); ok && dis != nil {
+						return v(dis.Taxes)
+					}
+					return nil
+				}),
+				validation.Skip,
+			),
+			validation.Skip,
+		),
+		validation.Field(&inv.Charges,
+			validation.Each(
+				validation.By(func(value any) error {
+					if chr, ok := value.(*bill.Charge); ok && chr != nil {
+						return v(chr.Taxes)
+					}
+					return nil
+				}),
+				validation.Skip,
+			),
+			validation.Skip,
+		),
+	)
+}
+
+// gstValidator prepares a function that checks the GST categories of a
+// tax set according to the invoice's place of supply.
+func gstValidator(inv *bill.Invoice) func(tax.Set) error {
+	supplier, pos := placeOfSupply(inv)
+	return func(ts tax.Set) error {
+		if ts.Get(tax.CategoryGST) != nil {
+			return errors.New("GST must be split, unable to determine supplier state")
+		}
+		igst := ts.Get(TaxCategoryIGST) != nil
+		cgst := ts.Get(TaxCategoryCGST) != nil
+		sgst := ts.Get(TaxCategorySGST) != nil
+		utgst := ts.Get(TaxCategoryUTGST) != nil
+		if igst && (cgst || sgst || utgst) {
+			return errors.New("IGST cannot be combined with CGST, SGST, or UTGST")
+		}
+		if sgst && utgst {
+			return errors.New("SGST cannot be combined with UTGST")
+		}
+		if supplier == nil {
+			return nil
+		}
+		if supplier != pos {
+			if cgst || sgst || utgst {
+				return errors.New("inter-state supplies must use IGST")
+			}
+			return nil
+		}
+		switch {
+		case igst:
+			return errors.New("intra-state supplies must use CGST with SGST or UTGST")
+		case supplier.UT && sgst:
+			return errors.New("union territory supplies must use UTGST instead of SGST")
+		case !supplier.UT && utgst:
+			return errors.New("state supplies must use SGST instead of UTGST")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,221 @@
+package in_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/in"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInvoiceGST(t *testing.T) *bill.Invoice {
+	t.Helper()
+	inv := testInvoiceStandard(t)
+	inv.Lines[0].Taxes = tax.Set{
+		{
+			Category: tax.CategoryGST,
+			Percent:  num.NewPercentage(18, 2),
+		},
+	}
+	return inv
+}
+
+func TestInvoiceIntraStateGST(t *testing.T) {
+	inv := testInvoiceGST(t)
+	require.NoError(t, inv.Calculate())
+	require.NoError(t, inv.Validate())
+
+	ts := inv.Lines[0].Taxes
+	require.Len(t, ts, 2)
+	assert.Equal(t, in.TaxCategoryCGST, ts[0].Category)
+	assert.Equal(t, "9%", ts[0].Percent.String())
+	assert.Equal(t, in.TaxCategorySGST, ts[1].Category)
+	assert.Equal(t, "9%", ts[1].Percent.String())
+	assert.Equal(t, "18.00", inv.Totals.Tax.String())
+}
+
+func TestInvoiceIntraStateGSTExtensions(t *testing.T) {
+	inv := testInvoiceGST(t)
+	inv.Lines[0].Taxes[0].Ext = tax.Extensions{"test-key": "A"}
+	require.NoError(t, inv.Calculate())
+
+	ts := inv.Lines[0].Taxes
+	require.Len(t, ts, 2)
+	ts[0].Ext["test-key"] = "B"
+	assert.Equal(t, "A", ts[1].Ext["test-key"].String())
+}
+
+func TestInvoiceInterStateGST(t *testing.T) {
+	inv := testInvoiceGST(t)
+	inv.Customer.TaxID.Code = "29AAGCB7383J1Z4"
+	require.NoError(t, inv.Calculate())
+	require.NoError(t, inv.Validate())
+
+	ts := inv.Lines[0].Taxes
+	require.Len(t, ts, 1)
+	assert.Equal(t, in.TaxCategoryIGST, ts[0].Category)
+	assert.Equal(t, "18%", ts[0].Percent.String())
+}
+
+func TestInvoiceUnionTerritoryGST(t *testing.T) {
+	inv := testInvoiceGST(t)
+	inv.Supplier.TaxID.Code = "04AAACH1234K1Z0"
+	inv.Customer.TaxID.Code = "04AAACH1234K1Z0"
+	inv.Lines[0].Taxes[0].Percent = num.NewPercentage(5, 2)
+	require.NoError(t, inv.Calculate())
+
+	ts := inv.Lines[0].Taxes
+	require.Len(t, ts, 2)
+	assert.Equal(t, in.TaxCategoryCGST, ts[0].Category)
+	assert.Equal(t, "2.5%", ts[0].Percent.String())
+	assert.Equal(t, in.TaxCategoryUTGST, ts[1].Category)
+	assert.Equal(t, "2.5%", ts[1].Percent.String())
+}
+
+func TestInvoicePlaceOfSupply(t *testing.T) {
+	t.Run("delivery address", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Delivery = &bill.Delivery{
+			Receiver: &org.Party{
+				Name: "Warehouse",
+				Addresses: []*org.Address{
+					{Locality: "Bengaluru", State: "KA", Country: "IN"},
+				},
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, in.TaxCategoryIGST, inv.Lines[0].Taxes[0].Category)
+	})
+	t.Run("customer address region", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Customer.TaxID = nil
+		inv.Customer.Addresses = []*org.Address{
+			{Locality: "Mumbai", Region: "Maharashtra", Country: "IN"},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, in.TaxCategoryCGST, inv.Lines[0].Taxes[0].Category)
+	})
+	t.Run("export", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Customer.TaxID = &tax.Identity{Country: "GB", Code: "GB123456789"}
+		require.NoError(t, inv.Calculate())
+		assert.Equal(t, in.TaxCategoryIGST, inv.Lines[0].Taxes[0].Category)
+	})
+	t.Run("no customer", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.SetTags(tax.TagSimplified)
+		inv.Customer = nil
+		require.NoError(t, inv.Calculate())
+		assert.Len(t, inv.Lines[0].Taxes, 2)
+	})
+	t.Run("unknown supplier state", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Supplier.TaxID = &tax.Identity{Country: "IN"}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "lines: (0: GST must be split, unable to determine supplier state.)")
+	})
+}
+
+func TestInvoiceCompensationCess(t *testing.T) {
+	inv := testInvoiceGST(t)
+	inv.IssueDate = cal.MakeDate(2025, 1, 15)
+	inv.Lines[0].Item.Identities[0].Code = "22021010"
+	inv.Lines[0].Taxes = append(inv.Lines[0].Taxes, &tax.Combo{Category: in.TaxCategoryCess})
+	require.NoError(t, inv.Calculate())
+	require.NoError(t, inv.Validate())
+	c := inv.Lines[0].Taxes.Get(in.TaxCategoryCess)
+	require.NotNil(t, c.Percent)
+	assert.Equal(t, "12%", c.Percent.String())
+
+	inv = testInvoiceGST(t)
+	inv.IssueDate = cal.MakeDate(2025, 10, 1)
+	inv.Lines[0].Item.Identities[0].Code = "22021010"
+	inv.Lines[0].Taxes = append(inv.Lines[0].Taxes, &tax.Combo{Category: in.TaxCategoryCess})
+	require.NoError(t, inv.Calculate())
+	assert.Nil(t, inv.Lines[0].Taxes.Get(in.TaxCategoryCess).Percent)
+
+	inv = testInvoiceGST(t)
+	inv.Lines[0].Item.Identities[0].Code = "24039990"
+	inv.Lines[0].Taxes = append(inv.Lines[0].Taxes, &tax.Combo{Category: in.TaxCategoryCess})
+	require.NoError(t, inv.Calculate())
+	assert.Equal(t, "204%", inv.Lines[0].Taxes.Get(in.TaxCategoryCess).Percent.String())
+	assert.Equal(t, "222.00", inv.Totals.Tax.String())
+}
+
+func TestInvoiceGSTValidation(t *testing.T) {
+	t.Run("intra-state discount with IGST", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Discounts = []*bill.Discount{
+			{
+				Reason: "Promotion",
+				Amount: num.MakeAmount(1000, 2),
+				Taxes: tax.Set{
+					{Category: in.TaxCategoryIGST, Percent: num.NewPercentage(18, 2)},
+				},
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "discounts: (0: intra-state supplies must use CGST with SGST or UTGST.)")
+	})
+	t.Run("charge mixing IGST with CGST", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Charges = []*bill.Charge{
+			{
+				Reason: "Shipping",
+				Amount: num.MakeAmount(1000, 2),
+				Taxes: tax.Set{
+					{Category: in.TaxCategoryCGST, Percent: num.NewPercentage(9, 2)},
+					{Category: in.TaxCategoryIGST, Percent: num.NewPercentage(18, 2)},
+				},
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "charges: (0: IGST cannot be combined with CGST, SGST, or UTGST.)")
+	})
+	t.Run("inter-state charge with CGST and SGST", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Customer.TaxID.Code = "29AAGCB7383J1Z4"
+		inv.Charges = []*bill.Charge{
+			{
+				Reason: "Shipping",
+				Amount: num.MakeAmount(1000, 2),
+				Taxes: tax.Set{
+					{Category: in.TaxCategoryCGST, Percent: num.NewPercentage(9, 2)},
+					{Category: in.TaxCategorySGST, Percent: num.NewPercentage(9, 2)},
+				},
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "charges: (0: inter-state supplies must use IGST.)")
+	})
+	t.Run("union territory line with SGST", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Supplier.TaxID.Code = "04AAACH1234K1Z0"
+		inv.Customer.TaxID.Code = "04AAACH1234K1Z0"
+		inv.Lines[0].Taxes = tax.Set{
+			{Category: in.TaxCategoryCGST, Percent: num.NewPercentage(9, 2)},
+			{Category: in.TaxCategorySGST, Percent: num.NewPercentage(9, 2)},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.ErrorContains(t, inv.Validate(), "lines: (0: union territory supplies must use UTGST instead of SGST.)")
+	})
+	t.Run("split GST discount", func(t *testing.T) {
+		inv := testInvoiceGST(t)
+		inv.Discounts = []*bill.Discount{
+			{
+				Reason: "Promotion",
+				Amount: num.MakeAmount(1000, 2),
+				Taxes: tax.Set{
+					{Category: tax.CategoryGST, Percent: num.NewPercentage(18, 2)},
+				},
+			},
+		}
+		require.NoError(t, inv.Calculate())
+		assert.NoError(t, inv.Validate())
+	})
+}
@@ -0,0 +1,57 @@
+package in
+
+import (
+	"strings"
+
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/num"
+)
+
+// cessRate defines the ad valorem compensation cess percentage that applies
+// to goods whose HSN code starts with the given prefix.
+type cessRate struct {
+	HSN     string
+	Percent num.Percentage
+	// Until, when set, is the first date on which the rate no longer applies.
+	Until *cal.Date
+}
+
+// compensationCessEnd is the date from which compensation cess was removed
+// from all goods except tobacco and related products.
+var compensationCessEnd = cal.NewDate(2025, 9, 22)
+
+// compensationCessRates contains the most common ad valorem compensation
+// cess rates by HSN code. Goods with mixed ad valorem and specific rates,
+// such as cigarettes or coal, are not included and must have their cess
+// percent provided explicitly.
+var compensationCessRates = []*cessRate{
+	{HSN: "21069020", Percent: num.MakePercentage(60, 2)},                           // Pan masala
+	{HSN: "24039910", Percent: num.MakePercentage(160, 2)},                          // Chewing tobacco
+	{HSN: "24039930", Percent: num.MakePercentage(160, 2)},                          // Jarda scented tobacco
+	{HSN: "24039990", Percent: num.MakePercentage(204, 2)},                          // Pan masala containing tobacco (gutkha)
+	{HSN: "220210", Percent: num.MakePercentage(12, 2), Until: compensationCessEnd}, // Aerated waters
+	{HSN: "880240", Percent: num.MakePercentage(3, 2), Until: compensationCessEnd},  // Aircraft for personal use
+	{HSN: "8903", Percent: num.MakePercentage(3, 2), Until: compensationCessEnd},    // Yachts and pleasure vessels
+}
+
+// compensationCessPercent provides the percentage of compensation cess to
+// apply to the HSN code on the given date, using the longest matching prefix.
+func compensationCessPercent(hsn string, date cal.Date) *num.Percentage {
+	var match *cessRate
+	for _, r := range compensationCessRates {
+		if !strings.HasPrefix(hsn, r.HSN) {
+			continue
+		}
+		if r.Until != nil && !date.IsZero() && !date.Before(r.Until.Date) {
+			continue
+		}
+		if match == nil || len(r.HSN) > len(match.HSN) {
+			match = r
+		}
+	}
+	if match == nil {
+		return nil
+	}
+	p := match.Percent
+	return &p
+}
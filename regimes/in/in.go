@@ -50,6 +50,8 @@ func Validate(doc interface{}) error {
 		return validateOrgIdentity(obj)
 	case *org.Item:
 		return validateOrgItem(obj)
+	case *bill.Invoice:
+		return validateInvoice(obj)
 	}
 	return nil
 }
@@ -61,5 +63,7 @@ func Normalize(doc interface{}) {
 		normalizeTaxIdentity(obj)
 	case *org.Identity:
 		normalizeOrgIdentity(obj)
+	case *bill.Invoice:
+		normalizeInvoice(obj)
 	}
 }
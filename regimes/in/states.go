@@ -0,0 +1,117 @@
+package in
+
+import (
+	"strings"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+)
+
+// stateDef describes a state or union territory as used by the GST system to
+// determine the place of supply.
+type stateDef struct {
+	// Two digit code used by the GST system, and as the GSTIN prefix.
+	Code cbc.Code
+	// Alphabetical codes (ISO 3166-2:IN, including previous versions) that
+	// may be used in the address state field.
+	Alpha []cbc.Code
+	// Name of the state or territory, matched against address regions.
+	Name string
+	// UT is true for union territories without their own legislature, where
+	// UTGST applies instead of SGST.
+	UT bool
+}
+
+var states = []*stateDef{
+	{Code: "01", Alpha: []cbc.Code{"JK"}, Name: "Jammu and Kashmir"},
+	{Code: "02", Alpha: []cbc.Code{"HP"}, Name: "Himachal Pradesh"},
+	{Code: "03", Alpha: []cbc.Code{"PB"}, Name: "Punjab"},
+	{Code: "04", Alpha: []cbc.Code{"CH"}, Name: "Chandigarh", UT: true},
+	{Code: "05", Alpha: []cbc.Code{"UK", "UT"}, Name: "Uttarakhand"},
+	{Code: "06", Alpha: []cbc.Code{"HR"}, Name: "Haryana"},
+	{Code: "07", Alpha: []cbc.Code{"DL"}, Name: "Delhi"},
+	{Code: "08", Alpha: []cbc.Code{"RJ"}, Name: "Rajasthan"},
+	{Code: "09", Alpha: []cbc.Code{"UP"}, Name: "Uttar Pradesh"},
+	{Code: "10", Alpha: []cbc.Code{"BR"}, Name: "Bihar"},
+	{Code: "11", Alpha: []cbc.Code{"SK"}, Name: "Sikkim"},
+	{Code: "12", Alpha: []cbc.Code{"AR"}, Name: "Arunachal Pradesh"},
+	{Code: "13", Alpha: []cbc.Code{"NL"}, Name: "Nagaland"},
+	{Code: "14", Alpha: []cbc.Code{"MN"}, Name: "Manipur"},
+	{Code: "15", Alpha: []cbc.Code{"MZ"}, Name: "Mizoram"},
+	{Code: "16", Alpha: []cbc.Code{"TR"}, Name: "Tripura"},
+	{Code: "17", Alpha: []cbc.Code{"ML"}, Name: "Meghalaya"},
+	{Code: "18", Alpha: []cbc.Code{"AS"}, Name: "Assam"},
+	{Code: "19", Alpha: []cbc.Code{"WB"}, Name: "West Bengal"},
+	{Code: "20", Alpha: []cbc.Code{"JH"}, Name: "Jharkhand"},
+	{Code: "21", Alpha: []cbc.Code{"OD", "OR"}, Name: "Odisha"},
+	{Code: "22", Alpha: []cbc.Code{"CG", "CT"}, Name: "Chhattisgarh"},
+	{Code: "23", Alpha: []cbc.Code{"MP"}, Name: "Madhya Pradesh"},
+	{Code: "24", Alpha: []cbc.Code{"GJ"}, Name: "Gujarat"},
+	{Code: "25", Alpha: []cbc.Code{"DD"}, Name: "Daman and Diu", UT: true},
+	{Code: "26", Alpha: []cbc.Code{"DH", "DN"}, Name: "Dadra and Nagar Haveli and Daman and Diu", UT: true},
+	{Code: "27", Alpha: []cbc.Code{"MH"}, Name: "Maharashtra"},
+	{Code: "29", Alpha: []cbc.Code{"KA"}, Name: "Karnataka"},
+	{Code: "30", Alpha: []cbc.Code{"GA"}, Name: "Goa"},
+	{Code: "31", Alpha: []cbc.Code{"LD"}, Name: "Lakshadweep", UT: true},
+	{Code: "32", Alpha: []cbc.Code{"KL"}, Name: "Kerala"},
+	{Code: "33", Alpha: []cbc.Code{"TN"}, Name: "Tamil Nadu"},
+	{Code: "34", Alpha: []cbc.Code{"PY"}, Name: "Puducherry"},
+	{Code: "35", Alpha: []cbc.Code{"AN"}, Name: "Andaman and Nicobar Islands", UT: true},
+	{Code: "36", Alpha: []cbc.Code{"TS", "TG"}, Name: "Telangana"},
+	{Code: "37", Alpha: []cbc.Code{"AP"}, Name: "Andhra Pradesh"},
+	{Code: "38", Alpha: []cbc.Code{"LA"}, Name: "Ladakh", UT: true},
+}
+
+// stateForCode finds the state definition from either the numerical GST
+// code or one of the alphabetical codes.
+func stateForCode(code cbc.Code) *stateDef {
+	if code == cbc.CodeEmpty {
+		return nil
+	}
+	for _, s := range states {
+		if s.Code == code || code.In(s.Alpha...) {
+			return s
+		}
+	}
+	return nil
+}
+
+// stateForName finds the state definition by its English name.
+func stateForName(name string) *stateDef {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	for _, s := range states {
+		if strings.EqualFold(s.Name, name) {
+			return s
+		}
+	}
+	return nil
+}
+
+// stateForTaxIdentity uses the first two digits of the GSTIN to determine
+// the state of registration.
+func stateForTaxIdentity(tID *tax.Identity) *stateDef {
+	if tID == nil || tID.Country != "IN" {
+		return nil
+	}
+	code := tax.IdentityCodeBadCharsRegexp.ReplaceAllString(strings.ToUpper(tID.Code.String()), "")
+	if len(code) < 2 {
+		return nil
+	}
+	return stateForCode(cbc.Code(code[:2]))
+}
+
+// stateForAddress determines the state from the address state code, or
+// if not available, the region name.
+func stateForAddress(addr *org.Address) *stateDef {
+	if addr == nil {
+		return nil
+	}
+	if s := stateForCode(cbc.NormalizeAlphanumericalCode(addr.State)); s != nil {
+		return s
+	}
+	return stateForName(addr.Region)
+}
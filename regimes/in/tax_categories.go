@@ -4,6 +4,7 @@ package in
 import (
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pkg/here"
 	"github.com/invopop/gobl/tax"
 )
 
@@ -13,9 +14,33 @@ const (
 	TaxCategorySGST  cbc.Code = "SGST"
 	TaxCategoryIGST  cbc.Code = "IGST"
 	TaxCategoryUTGST cbc.Code = "UTGST"
+	TaxCategoryCess  cbc.Code = "CESS"
 )
 
 var taxCategories = []*tax.CategoryDef{
+	// Goods and Services Tax (GST), a convenience category that will be
+	// replaced according to the place of supply.
+	{
+		Code: tax.CategoryGST,
+		Name: i18n.String{
+			i18n.EN: "GST",
+			i18n.HI: "जीएसटी",
+		},
+		Title: i18n.String{
+			i18n.EN: "Goods and Services Tax",
+			i18n.HI: "माल और सेवा कर",
+		},
+		Description: &i18n.String{
+			i18n.EN: here.Doc(`
+				Convenience category used to provide the combined GST rate. During normalization,
+				intra-state supplies will be split into equal CGST and SGST (or UTGST) parts, while
+				inter-state supplies and exports will be converted to IGST. The place of supply is
+				determined from the supplier and customer GSTIN state codes, or the delivery and
+				customer addresses.
+			`),
+		},
+	},
+
 	// Central Goods and Services Tax (CGST)
 	{
 		Code: TaxCategoryCGST,
@@ -103,4 +128,32 @@ var taxCategories = []*tax.CategoryDef{
 			},
 		},
 	},
+
+	// Compensation Cess
+	{
+		Code: TaxCategoryCess,
+		Name: i18n.String{
+			i18n.EN: "Cess",
+			i18n.HI: "उपकर",
+		},
+		Title: i18n.String{
+			i18n.EN: "Compensation Cess",
+			i18n.HI: "क्षतिपूर्ति उपकर",
+		},
+		Description: &i18n.String{
+			i18n.EN: here.Doc(`
+				Additional levy applied on top of GST to luxury and demerit goods such as tobacco
+				and pan masala. When no percent is provided, the ad valorem rate will be determined
+				from the item's HSN code, if known.
+			`),
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "GST (Compensation to States) Act, 2017",
+				},
+				URL: "https://cbic-gst.gov.in/gst-compensation-cess.html",
+			},
+		},
+	},
 }
@@ -0,0 +1,104 @@
+package lt
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.LT: "PVM",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.LT: "Pridėtinės vertės mokestis",
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+					i18n.LT: "Nulinis tarifas",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.LT: "Standartinis tarifas",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2009, 9, 1),
+						Percent: num.MakePercentage(210, 3),
+					},
+					{
+						Since:   cal.NewDate(2009, 1, 1),
+						Percent: num.MakePercentage(190, 3),
+					},
+					{
+						Since:   cal.NewDate(2002, 1, 1),
+						Percent: num.MakePercentage(180, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.LT: "Lengvatinis tarifas",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to passenger transport, accommodation, books, and residential heating, among others.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2009, 1, 1),
+						Percent: num.MakePercentage(90, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateSuperReduced,
+				Name: i18n.String{
+					i18n.EN: "Super-Reduced Rate",
+					i18n.LT: "Antrasis lengvatinis tarifas",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to reimbursable medicines and medical aids, and technical aids for disabled persons.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2009, 1, 1),
+						Percent: num.MakePercentage(50, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.LT: "Neapmokestinama",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Supplies exempt from VAT such as healthcare, education, postal, and financial services.",
+				},
+			},
+		},
+	},
+}
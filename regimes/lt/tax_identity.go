@@ -0,0 +1,71 @@
+package lt
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Lithuanian VAT numbers (PVM mokėtojo kodas) contain 9 digits for legal
+// entities or 12 digits for temporary taxpayers, in both cases with a "1"
+// in the second to last position.
+var (
+	taxCodeRegexps = []*regexp.Regexp{
+		regexp.MustCompile(`^\d{7}1\d$`),
+		regexp.MustCompile(`^\d{10}1\d$`),
+	}
+)
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	match := false
+	for _, re := range taxCodeRegexps {
+		if re.MatchString(val) {
+			match = true
+			break
+		}
+	}
+	if !match {
+		return errors.New("invalid format")
+	}
+
+	return commercialCheck(val)
+}
+
+// commercialCheck uses weights 1 to 9 repeatedly to calculate the modulus 11
+// check digit. If the remainder is 10, the weights are shifted by two and the
+// calculation repeated, with a final remainder of 10 implying a zero.
+func commercialCheck(val string) error {
+	n := len(val) - 1
+	check := weightedSum(val[:n], 0) % 11
+	if check == 10 {
+		check = weightedSum(val[:n], 2) % 11
+	}
+	if check%10 != int(val[n]-'0') {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+func weightedSum(val string, shift int) int {
+	sum := 0
+	for i := range val {
+		sum += int(val[i]-'0') * ((i+shift)%9 + 1)
+	}
+	return sum
+}
@@ -0,0 +1,77 @@
+package lt_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/lt"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "LT119511515", expected: "119511515"},
+		{code: "lt 1195 11515", expected: "119511515"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "LT", Code: ts.code}
+		lt.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good 9 digits", code: "119511515"},
+		{name: "good 9 digits 2", code: "213179412"},
+		{name: "good 12 digits", code: "100001919017"},
+		{name: "good 12 digits 2", code: "290061371314"},
+		{name: "empty", code: ""},
+		{
+			name: "too short",
+			code: "11951151",
+			err:  "invalid format",
+		},
+		{
+			name: "missing marker",
+			code: "119511525",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "1000019190177",
+			err:  "invalid format",
+		},
+		{
+			name: "bad checksum",
+			code: "119511516",
+			err:  "checksum mismatch",
+		},
+		{
+			name: "bad 12 digit checksum",
+			code: "100001919018",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "LT", Code: tt.code}
+			err := lt.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package lu
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.FR: "TVA",
+			i18n.DE: "MwSt",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.FR: "Taxe sur la valeur ajoutée",
+			i18n.DE: "Mehrwertsteuer",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Guichet.lu - VAT rates",
+					i18n.FR: "Guichet.lu - Taux de TVA",
+				},
+				URL: "https://guichet.public.lu/en/entreprises/fiscalite/tva/regime-imposition/taux-tva.html",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+					i18n.FR: "Taux zéro",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.FR: "Taux normal",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2024, 1, 1),
+						Percent: num.MakePercentage(170, 3),
+					},
+					{
+						// Temporary reduction during 2023 to counter inflation.
+						Since:   cal.NewDate(2023, 1, 1),
+						Percent: num.MakePercentage(160, 3),
+					},
+					{
+						Since:   cal.NewDate(2015, 1, 1),
+						Percent: num.MakePercentage(170, 3),
+					},
+					{
+						Since:   cal.NewDate(1992, 1, 1),
+						Percent: num.MakePercentage(150, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateIntermediate,
+				Name: i18n.String{
+					i18n.EN: "Intermediate Rate",
+					i18n.FR: "Taux intermédiaire",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to certain wines, solid mineral fuels, and advertising printed matter, among others.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2024, 1, 1),
+						Percent: num.MakePercentage(140, 3),
+					},
+					{
+						Since:   cal.NewDate(2023, 1, 1),
+						Percent: num.MakePercentage(130, 3),
+					},
+					{
+						Since:   cal.NewDate(2015, 1, 1),
+						Percent: num.MakePercentage(140, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.FR: "Taux réduit",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to gas, electricity, and hairdressing services, among others.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2024, 1, 1),
+						Percent: num.MakePercentage(80, 3),
+					},
+					{
+						Since:   cal.NewDate(2023, 1, 1),
+						Percent: num.MakePercentage(70, 3),
+					},
+					{
+						Since:   cal.NewDate(2015, 1, 1),
+						Percent: num.MakePercentage(80, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateSuperReduced,
+				Name: i18n.String{
+					i18n.EN: "Super-Reduced Rate",
+					i18n.FR: "Taux super-réduit",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to food, books, pharmaceutical products, and passenger transport, among others.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(30, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.FR: "Exonéré",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Exempt operations under article 44 of the Luxembourg VAT law, such as medical care and financial services.",
+				},
+			},
+		},
+	},
+}
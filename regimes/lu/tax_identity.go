@@ -0,0 +1,47 @@
+package lu
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Luxembourg VAT numbers (numéro d'identification TVA) contain 8 digits, the
+// last two of which are the remainder of the first six divided by 89.
+var (
+	taxCodeRegexp = regexp.MustCompile(`^\d{8}$`)
+)
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	if !taxCodeRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+
+	return commercialCheck(val)
+}
+
+func commercialCheck(val string) error {
+	base, _ := strconv.Atoi(val[:6])
+	check, _ := strconv.Atoi(val[6:])
+	if base%89 != check {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
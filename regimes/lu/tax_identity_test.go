@@ -0,0 +1,70 @@
+package lu_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/lu"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "LU15027442", expected: "15027442"},
+		{code: "lu 1502 7442", expected: "15027442"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "LU", Code: ts.code}
+		lu.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good 1", code: "15027442"},
+		{name: "good 2", code: "10000356"},
+		{name: "empty", code: ""},
+		{
+			name: "too short",
+			code: "1502744",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "150274421",
+			err:  "invalid format",
+		},
+		{
+			name: "not numeric",
+			code: "1502744A",
+			err:  "invalid format",
+		},
+		{
+			name: "bad checksum",
+			code: "15027443",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "LU", Code: tt.code}
+			err := lu.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
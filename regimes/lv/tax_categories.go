@@ -0,0 +1,108 @@
+package lv
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.LV: "PVN",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.LV: "Pievienotās vērtības nodoklis",
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+					i18n.LV: "Nulles likme",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.LV: "Standartlikme",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2012, 7, 1),
+						Percent: num.MakePercentage(210, 3),
+					},
+					{
+						Since:   cal.NewDate(2011, 1, 1),
+						Percent: num.MakePercentage(220, 3),
+					},
+					{
+						Since:   cal.NewDate(2009, 1, 1),
+						Percent: num.MakePercentage(210, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.LV: "Samazinātā likme",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to medicines, baby food, books, public transport, and accommodation, among others.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2011, 1, 1),
+						Percent: num.MakePercentage(120, 3),
+					},
+					{
+						Since:   cal.NewDate(2009, 1, 1),
+						Percent: num.MakePercentage(100, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateSuperReduced,
+				Name: i18n.String{
+					i18n.EN: "Super-Reduced Rate",
+					i18n.LV: "Otrā samazinātā likme",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to fresh fruit, berries, and vegetables typical of Latvia.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2018, 1, 1),
+						Percent: num.MakePercentage(50, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.LV: "Atbrīvots",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Exempt supplies such as medical, social, educational, and financial services.",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,73 @@
+package lv
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Latvian VAT numbers (PVN reģistrācijas numurs) contain 11 digits. Legal
+// entities have numbers starting with a digit greater than 3, while natural
+// persons use their personal code which starts with a date of birth.
+var (
+	legalEntityMultipliers = []int{9, 1, 4, 8, 3, 10, 2, 5, 7, 6, 1}
+	personalMultipliers    = []int{10, 5, 8, 4, 2, 1, 6, 3, 7, 9}
+	taxCodeRegexp          = regexp.MustCompile(`^\d{11}$`)
+)
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	if !taxCodeRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+
+	if val[0] > '3' {
+		return legalEntityCheck(val)
+	}
+	if strings.HasPrefix(val, "32") {
+		// Personal codes issued since 2017 do not contain the
+		// date of birth nor a check digit.
+		return nil
+	}
+	return personalCheck(val)
+}
+
+// legalEntityCheck ensures the weighted sum of all the digits
+// has a remainder of 3 when divided by 11.
+func legalEntityCheck(val string) error {
+	sum := 0
+	for i, m := range legalEntityMultipliers {
+		sum += int(val[i]-'0') * m
+	}
+	if sum%11 != 3 {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+func personalCheck(val string) error {
+	sum := 1
+	for i, m := range personalMultipliers {
+		sum += int(val[i]-'0') * m
+	}
+	if sum%11%10 != int(val[10]-'0') {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
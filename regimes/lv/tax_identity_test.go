@@ -0,0 +1,72 @@
+package lv_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/lv"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "LV40003521600", expected: "40003521600"},
+		{code: "lv 4000 352 1600", expected: "40003521600"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "LV", Code: ts.code}
+		lv.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good legal entity", code: "40003521600"},
+		{name: "good legal entity 2", code: "40003009497"},
+		{name: "good personal code", code: "16117519997"},
+		{name: "good new personal code", code: "32579461005"},
+		{name: "empty", code: ""},
+		{
+			name: "too short",
+			code: "4000352160",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "400035216001",
+			err:  "invalid format",
+		},
+		{
+			name: "bad legal entity checksum",
+			code: "40003521601",
+			err:  "checksum mismatch",
+		},
+		{
+			name: "bad personal code checksum",
+			code: "16117519998",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "LV", Code: tt.code}
+			err := lv.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package my
+
+import (
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pkg/here"
+)
+
+// Extension keys used to provide the additional codes required by the
+// MyInvois e-invoicing system.
+const (
+	// ExtKeyTaxType is used to identify the type of tax applied, and is set
+	// automatically from the tax category and rate.
+	ExtKeyTaxType cbc.Key = "my-tax-type"
+	// ExtKeyClassification is used in items to identify the category of
+	// products or services being billed.
+	ExtKeyClassification cbc.Key = "my-classification"
+	// ExtKeyMSIC is used in parties to provide the Malaysia Standard
+	// Industrial Classification code of the principal business activity.
+	ExtKeyMSIC cbc.Key = "my-msic"
+)
+
+var extensionKeys = []*cbc.Definition{
+	{
+		Key: ExtKeyTaxType,
+		Name: i18n.String{
+			i18n.EN: "Tax Type",
+			i18n.MS: "Jenis Cukai",
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: "01",
+				Name: i18n.String{
+					i18n.EN: "Sales Tax",
+				},
+			},
+			{
+				Code: "02",
+				Name: i18n.String{
+					i18n.EN: "Service Tax",
+				},
+			},
+			{
+				Code: "03",
+				Name: i18n.String{
+					i18n.EN: "Tourism Tax",
+				},
+			},
+			{
+				Code: "04",
+				Name: i18n.String{
+					i18n.EN: "High-Value Goods Tax",
+				},
+			},
+			{
+				Code: "05",
+				Name: i18n.String{
+					i18n.EN: "Sales Tax on Low Value Goods",
+				},
+			},
+			{
+				Code: "06",
+				Name: i18n.String{
+					i18n.EN: "Not Applicable",
+				},
+			},
+			{
+				Code: "E",
+				Name: i18n.String{
+					i18n.EN: "Tax exemption (where applicable)",
+				},
+			},
+		},
+	},
+	{
+		Key: ExtKeyClassification,
+		Name: i18n.String{
+			i18n.EN: "Classification Code",
+			i18n.MS: "Kod Klasifikasi",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Code used by MyInvois to categorise the products or services being
+				billed. Code "004" must be used for consolidated e-invoices issued
+				for transactions with buyers who do not require an e-invoice.
+			`),
+		},
+		Values: []*cbc.Definition{
+			{
+				Code: "001",
+				Name: i18n.String{
+					i18n.EN: "Breastfeeding equipment",
+				},
+			},
+			{
+				Code: "002",
+				Name: i18n.String{
+					i18n.EN: "Child care centres and kindergartens fees",
+				},
+			},
+			{
+				Code: "003",
+				Name: i18n.String{
+					i18n.EN: "Computer, smartphone or tablet",
+				},
+			},
+			{
+				Code: "004",
+				Name: i18n.String{
+					i18n.EN: "Consolidated e-Invoice",
+				},
+			},
+			{
+				Code: "005",
+				Name: i18n.String{
+					i18n.EN: "Construction materials",
+				},
+			},
+			{
+				Code: "006",
+				Name: i18n.String{
+					i18n.EN: "Disbursement",
+				},
+			},
+			{
+				Code: "007",
+				Name: i18n.String{
+					i18n.EN: "Donation",
+				},
+			},
+			{
+				Code: "008",
+				Name: i18n.String{
+					i18n.EN: "e-Commerce - e-Invoice to buyer / purchaser",
+				},
+			},
+			{
+				Code: "009",
+				Name: i18n.String{
+					i18n.EN: "e-Commerce - Self-billed e-Invoice to seller, logistics, etc.",
+				},
+			},
+			{
+				Code: "010",
+				Name: i18n.String{
+					i18n.EN: "Education fees",
+				},
+			},
+			{
+				Code: "011",
+				Name: i18n.String{
+					i18n.EN: "Goods on consignment (Consignor)",
+				},
+			},
+			{
+				Code: "012",
+				Name: i18n.String{
+					i18n.EN: "Goods on consignment (Consignee)",
+				},
+			},
+			{
+				Code: "013",
+				Name: i18n.String{
+					i18n.EN: "Gym membership",
+				},
+			},
+			{
+				Code: "014",
+				Name: i18n.String{
+					i18n.EN: "Insurance - Education and medical benefits",
+				},
+			},
+			{
+				Code: "015",
+				Name: i18n.String{
+					i18n.EN: "Insurance - Takaful or life insurance",
+				},
+			},
+			{
+				Code: "016",
+				Name: i18n.String{
+					i18n.EN: "Interest and financing expenses",
+				},
+			},
+			{
+				Code: "017",
+				Name: i18n.String{
+					i18n.EN: "Internet subscription",
+				},
+			},
+			{
+				Code: "018",
+				Name: i18n.String{
+					i18n.EN: "Land and building",
+				},
+			},
+			{
+				Code: "019",
+				Name: i18n.String{
+					i18n.EN: "Medical examination for learning disabilities and early intervention or rehabilitation treatments of learning disabilities",
+				},
+			},
+			{
+				Code: "020",
+				Name: i18n.String{
+					i18n.EN: "Medical examination or vaccination expenses",
+				},
+			},
+			{
+				Code: "021",
+				Name: i18n.String{
+					i18n.EN: "Medical expenses for serious diseases",
+				},
+			},
+			{
+				Code: "022",
+				Name: i18n.String{
+					i18n.EN: "Others",
+				},
+			},
+			{
+				Code: "023",
+				Name: i18n.String{
+					i18n.EN: "Petroleum operations",
+				},
+			},
+			{
+				Code: "024",
+				Name: i18n.String{
+					i18n.EN: "Private retirement scheme or deferred annuity scheme",
+				},
+			},
+			{
+				Code: "025",
+				Name: i18n.String{
+					i18n.EN: "Motor vehicle",
+				},
+			},
+			{
+				Code: "026",
+				Name: i18n.String{
+					i18n.EN: "Subscription of books / journals / magazines / newspapers / other similar publications",
+				},
+			},
+			{
+				Code: "027",
+				Name: i18n.String{
+					i18n.EN: "Reimbursement",
+				},
+			},
+			{
+				Code: "028",
+				Name: i18n.String{
+					i18n.EN: "Rental of motor vehicle",
+				},
+			},
+			{
+				Code: "029",
+				Name: i18n.String{
+					i18n.EN: "EV charging facilities",
+				},
+			},
+			{
+				Code: "030",
+				Name: i18n.String{
+					i18n.EN: "Repair and maintenance",
+				},
+			},
+			{
+				Code: "031",
+				Name: i18n.String{
+					i18n.EN: "Research and development",
+				},
+			},
+			{
+				Code: "032",
+				Name: i18n.String{
+					i18n.EN: "Foreign income",
+				},
+			},
+			{
+				Code: "033",
+				Name: i18n.String{
+					i18n.EN: "Self-billed - Betting and gaming",
+				},
+			},
+			{
+				Code: "034",
+				Name: i18n.String{
+					i18n.EN: "Self-billed - Importation of goods",
+				},
+			},
+			{
+				Code: "035",
+				Name: i18n.String{
+					i18n.EN: "Self-billed - Importation of services",
+				},
+			},
+			{
+				Code: "036",
+				Name: i18n.String{
+					i18n.EN: "Self-billed - Others",
+				},
+			},
+			{
+				Code: "037",
+				Name: i18n.String{
+					i18n.EN: "Self-billed - Monetary payment to agents, dealers or distributors",
+				},
+			},
+			{
+				Code: "038",
+				Name: i18n.String{
+					i18n.EN: "Sports equipment, rental / entry fees for sports facilities, registration in sports competitions or sports training fees",
+				},
+			},
+			{
+				Code: "039",
+				Name: i18n.String{
+					i18n.EN: "Supporting equipment for disabled person",
+				},
+			},
+			{
+				Code: "040",
+				Name: i18n.String{
+					i18n.EN: "Voluntary contribution to approved provident fund",
+				},
+			},
+			{
+				Code: "041",
+				Name: i18n.String{
+					i18n.EN: "Dental examination or treatment",
+				},
+			},
+			{
+				Code: "042",
+				Name: i18n.String{
+					i18n.EN: "Fertility treatment",
+				},
+			},
+			{
+				Code: "043",
+				Name: i18n.String{
+					i18n.EN: "Treatment and home care nursing, daycare centres and residential care centres",
+				},
+			},
+			{
+				Code: "044",
+				Name: i18n.String{
+					i18n.EN: "Vouchers, gift cards, loyalty points, etc.",
+				},
+			},
+			{
+				Code: "045",
+				Name: i18n.String{
+					i18n.EN: "Self-billed - Non-monetary payment to agents, dealers or distributors",
+				},
+			},
+		},
+	},
+	{
+		Key: ExtKeyMSIC,
+		Name: i18n.String{
+			i18n.EN: "MSIC Code",
+			i18n.MS: "Kod MSIC",
+		},
+		Desc: i18n.String{
+			i18n.EN: here.Doc(`
+				Five digit Malaysia Standard Industrial Classification code that
+				identifies the principal business activity of the supplier.
+			`),
+		},
+		Pattern: `^\d{5}$`,
+	},
+}
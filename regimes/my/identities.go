@@ -0,0 +1,92 @@
+package my
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+const (
+	// IdentityKeyBRN represents the Business Registration Number issued by
+	// the Companies Commission of Malaysia (SSM). MyInvois expects it
+	// alongside the TIN for all businesses registered in Malaysia.
+	IdentityKeyBRN cbc.Key = "my-brn"
+
+	// IdentityKeySST represents the Sales and Service Tax registration
+	// number issued by the Royal Malaysian Customs Department (JKDM).
+	IdentityKeySST cbc.Key = "my-sst"
+)
+
+var (
+	// BRNs issued since October 2019 have 12 digits: the year of
+	// registration, a 2 digit entity type, and a 6 digit sequence. Older
+	// numbers have up to 7 digits followed by a check letter.
+	brnRegexp = regexp.MustCompile(`^(\d{12}|\d{1,7}[A-Z])$`)
+	sstRegexp = regexp.MustCompile(`^[A-Z]\d{2}-\d{4}-\d{8}$`)
+)
+
+var identityDefinitions = []*cbc.Definition{
+	{
+		Key: IdentityKeyBRN,
+		Name: i18n.String{
+			i18n.EN: "Business Registration Number",
+			i18n.MS: "Nombor Pendaftaran Perniagaan",
+		},
+	},
+	{
+		Key: IdentityKeySST,
+		Name: i18n.String{
+			i18n.EN: "SST Registration Number",
+			i18n.MS: "Nombor Pendaftaran SST",
+		},
+	},
+}
+
+// normalizeIdentity removes separators from the BRN and formats the SST
+// registration number as it is typically presented: A00-0000-00000000.
+func normalizeIdentity(id *org.Identity) {
+	if id == nil {
+		return
+	}
+	switch id.Key {
+	case IdentityKeyBRN:
+		id.Code = cbc.Code(tax.IdentityCodeBadCharsRegexp.ReplaceAllString(strings.ToUpper(id.Code.String()), ""))
+	case IdentityKeySST:
+		code := tax.IdentityCodeBadCharsRegexp.ReplaceAllString(strings.ToUpper(id.Code.String()), "")
+		if len(code) == 15 {
+			code = fmt.Sprintf("%s-%s-%s", code[:3], code[3:7], code[7:])
+		}
+		id.Code = cbc.Code(code)
+	}
+}
+
+func validateIdentity(id *org.Identity) error {
+	if id == nil {
+		return nil
+	}
+	switch id.Key {
+	case IdentityKeyBRN:
+		return validation.ValidateStruct(id,
+			validation.Field(&id.Code,
+				validation.Required,
+				validation.Match(brnRegexp),
+				validation.Skip,
+			),
+		)
+	case IdentityKeySST:
+		return validation.ValidateStruct(id,
+			validation.Field(&id.Code,
+				validation.Required,
+				validation.Match(sstRegexp),
+				validation.Skip,
+			),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package my_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/my"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityNormalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      cbc.Key
+		input    string
+		expected string
+	}{
+		{name: "brn", key: my.IdentityKeyBRN, input: "2019-0123 4567", expected: "201901234567"},
+		{name: "old brn", key: my.IdentityKeyBRN, input: "123456-a", expected: "123456A"},
+		{name: "sst", key: my.IdentityKeySST, input: "W10 1808 31000123", expected: "W10-1808-31000123"},
+		{name: "sst formatted", key: my.IdentityKeySST, input: "W10-1808-31000123", expected: "W10-1808-31000123"},
+		{name: "sst short", key: my.IdentityKeySST, input: "W10-1808", expected: "W101808"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tax.RegimeDefFor("MY")
+			id := &org.Identity{
+				Key:  tt.key,
+				Code: cbc.Code(tt.input),
+			}
+			r.NormalizeObject(id)
+			assert.Equal(t, tt.expected, id.Code.String())
+		})
+	}
+}
+
+func TestIdentityValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		key  cbc.Key
+		code cbc.Code
+		err  string
+	}{
+		{name: "brn", key: my.IdentityKeyBRN, code: "201901234567"},
+		{name: "old brn", key: my.IdentityKeyBRN, code: "123456A"},
+		{name: "sst", key: my.IdentityKeySST, code: "W10-1808-31000123"},
+		{name: "other key", key: "other", code: "ABC"},
+		{
+			name: "brn too short",
+			key:  my.IdentityKeyBRN,
+			code: "20190123456",
+			err:  "code: must be in a valid format",
+		},
+		{
+			name: "brn empty",
+			key:  my.IdentityKeyBRN,
+			code: "",
+			err:  "code: cannot be blank",
+		},
+		{
+			name: "sst bad format",
+			key:  my.IdentityKeySST,
+			code: "W101808",
+			err:  "code: must be in a valid format",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := &org.Identity{Key: tt.key, Code: tt.code}
+			err := my.Validate(id)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
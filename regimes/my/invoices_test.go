@@ -0,0 +1,107 @@
+package my_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/my"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validInvoice() *bill.Invoice {
+	return &bill.Invoice{
+		Series:    "TEST",
+		Code:      "0002",
+		IssueDate: cal.MakeDate(2025, 1, 15),
+		Supplier: &org.Party{
+			Name: "Test Supplier",
+			TaxID: &tax.Identity{
+				Country: "MY",
+				Code:    "C25845632020",
+			},
+			Ext: tax.Extensions{
+				my.ExtKeyMSIC: "62010",
+			},
+		},
+		Customer: &org.Party{
+			Name: "Test Customer",
+			TaxID: &tax.Identity{
+				Country: "MY",
+				Code:    "C10000000010",
+			},
+		},
+		Lines: []*bill.Line{
+			{
+				Quantity: num.MakeAmount(1, 0),
+				Item: &org.Item{
+					Name:  "bogus",
+					Price: num.MakeAmount(10000, 2),
+					Unit:  org.UnitPackage,
+					Ext: tax.Extensions{
+						my.ExtKeyClassification: "022",
+					},
+				},
+				Taxes: tax.Set{
+					{
+						Category: my.TaxCategoryServiceTax,
+						Rate:     "standard",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInvoiceValidation(t *testing.T) {
+	inv := validInvoice()
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+	assert.Equal(t, "8.0%", inv.Lines[0].Taxes[0].Percent.String())
+	assert.Equal(t, cbc.Code("02"), inv.Lines[0].Taxes[0].Ext[my.ExtKeyTaxType])
+
+	inv = validInvoice()
+	inv.Supplier.TaxID.Code = ""
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "supplier: (tax_id: (code: cannot be blank.).)")
+}
+
+func TestInvoiceServiceTaxHistory(t *testing.T) {
+	inv := validInvoice()
+	inv.IssueDate = cal.MakeDate(2023, 6, 1)
+	require.NoError(t, inv.Calculate())
+	assert.Equal(t, "6.0%", inv.Lines[0].Taxes[0].Percent.String())
+}
+
+func TestInvoiceSalesTax(t *testing.T) {
+	inv := validInvoice()
+	inv.Lines[0].Taxes[0].Category = tax.CategoryST
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+	assert.Equal(t, "10.0%", inv.Lines[0].Taxes[0].Percent.String())
+	assert.Equal(t, cbc.Code("01"), inv.Lines[0].Taxes[0].Ext[my.ExtKeyTaxType])
+
+	inv = validInvoice()
+	inv.Lines[0].Taxes[0].Category = tax.CategoryST
+	inv.Lines[0].Taxes[0].Rate = tax.RateExempt
+	require.NoError(t, inv.Calculate())
+	assert.NoError(t, inv.Validate())
+	assert.Equal(t, cbc.Code("E"), inv.Lines[0].Taxes[0].Ext[my.ExtKeyTaxType])
+}
+
+func TestInvoiceExtensions(t *testing.T) {
+	inv := validInvoice()
+	inv.Lines[0].Item.Ext[my.ExtKeyClassification] = "999"
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "my-classification: value '999' invalid")
+
+	inv = validInvoice()
+	inv.Supplier.Ext[my.ExtKeyMSIC] = "6201"
+	require.NoError(t, inv.Calculate())
+	assert.ErrorContains(t, inv.Validate(), "my-msic")
+}
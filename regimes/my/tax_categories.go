@@ -0,0 +1,169 @@
+package my
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+// Tax categories specific for Malaysia.
+const (
+	TaxCategoryServiceTax cbc.Code = "SVT" // Cukai Perkhidmatan
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// Sales Tax
+	//
+	{
+		Code: tax.CategoryST,
+		Name: i18n.String{
+			i18n.EN: "Sales Tax",
+			i18n.MS: "Cukai Jualan",
+		},
+		Title: i18n.String{
+			i18n.EN: "Sales Tax",
+			i18n.MS: "Cukai Jualan",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Royal Malaysian Customs Department - MySST",
+				},
+				URL: "https://mysst.customs.gov.my",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.MS: "Kadar Standard",
+				},
+				Ext: tax.Extensions{
+					ExtKeyTaxType: "01",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2018, 9, 1),
+						Percent: num.MakePercentage(100, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.MS: "Kadar Dikurangkan",
+				},
+				Ext: tax.Extensions{
+					ExtKeyTaxType: "01",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to selected goods such as certain foodstuffs, construction materials, and technology items.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2018, 9, 1),
+						Percent: num.MakePercentage(50, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.MS: "Dikecualikan",
+				},
+				Exempt: true,
+				Ext: tax.Extensions{
+					ExtKeyTaxType: "E",
+				},
+				Description: i18n.String{
+					i18n.EN: "Goods listed in the Sales Tax (Goods Exempted From Tax) Order, such as basic foodstuffs and medicines.",
+				},
+			},
+		},
+	},
+	//
+	// Service Tax
+	//
+	{
+		Code: TaxCategoryServiceTax,
+		Name: i18n.String{
+			i18n.EN: "Service Tax",
+			i18n.MS: "Cukai Perkhidmatan",
+		},
+		Title: i18n.String{
+			i18n.EN: "Service Tax",
+			i18n.MS: "Cukai Perkhidmatan",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Royal Malaysian Customs Department - MySST",
+				},
+				URL: "https://mysst.customs.gov.my",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.MS: "Kadar Standard",
+				},
+				Ext: tax.Extensions{
+					ExtKeyTaxType: "02",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2024, 3, 1),
+						Percent: num.MakePercentage(80, 3),
+					},
+					{
+						Since:   cal.NewDate(2018, 9, 1),
+						Percent: num.MakePercentage(60, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.MS: "Kadar Dikurangkan",
+				},
+				Ext: tax.Extensions{
+					ExtKeyTaxType: "02",
+				},
+				Description: i18n.String{
+					i18n.EN: "Food and beverage, telecommunications, parking and logistics services remain at the previous rate.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2024, 3, 1),
+						Percent: num.MakePercentage(60, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.MS: "Dikecualikan",
+				},
+				Exempt: true,
+				Ext: tax.Extensions{
+					ExtKeyTaxType: "E",
+				},
+				Description: i18n.String{
+					i18n.EN: "Services provided outside the scope of the Service Tax Regulations or specifically exempted.",
+				},
+			},
+		},
+	},
+}
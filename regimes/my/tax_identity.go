@@ -0,0 +1,49 @@
+package my
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Malaysian Tax Identification Numbers (TIN) are issued by the Inland
+// Revenue Board (LHDNM) and are required by the MyInvois e-invoicing
+// system for both suppliers and buyers. Each TIN starts with a prefix that
+// identifies the type of taxpayer, followed by up to 11 digits:
+//
+//   - IG: individuals,
+//   - C: companies,
+//   - CS: cooperative societies,
+//   - D: partnerships,
+//   - E: employers,
+//   - F, FA: associations,
+//   - PT: limited liability partnerships,
+//   - TA, TC, TN, TR: trust bodies, unit trusts, business trusts and REITs,
+//   - TP: deceased persons' estates,
+//   - J: Hindu joint families,
+//   - LE: Labuan entities.
+//
+// There is no publicly documented check digit, so only the format is
+// validated.
+var taxCodeRegexp = regexp.MustCompile(`^(IG|C|CS|D|E|F|FA|PT|TA|TC|TN|TR|TP|J|LE)\d{8,11}$`)
+
+// validateTaxIdentity checks to ensure the TIN looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	if !taxCodeRegexp.MatchString(code.String()) {
+		return errors.New("invalid format")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package my_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/my"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "C25845632020", expected: "C25845632020"},
+		{code: "c 2584563202-0", expected: "C25845632020"},
+		{code: "MYIG115002000", expected: "IG115002000"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "MY", Code: ts.code}
+		my.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "company", code: "C25845632020"},
+		{name: "individual", code: "IG115002000"},
+		{name: "partnership", code: "D2584563209"},
+		{name: "llp", code: "PT1234567890"},
+		{name: "labuan", code: "LE12345678"},
+		{name: "empty", code: ""},
+		{
+			name: "unknown prefix",
+			code: "X25845632020",
+			err:  "invalid format",
+		},
+		{
+			name: "missing prefix",
+			code: "25845632020",
+			err:  "invalid format",
+		},
+		{
+			name: "too short",
+			code: "C1234567",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "C123456789012",
+			err:  "invalid format",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "MY", Code: tt.code}
+			err := my.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
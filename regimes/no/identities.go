@@ -0,0 +1,64 @@
+package no
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/validation"
+)
+
+const (
+	// IdentityKeyOrgNumber represents the Norwegian organisation number
+	// (organisasjonsnummer) assigned by the Brønnøysund Register Centre.
+	IdentityKeyOrgNumber cbc.Key = "no-org-number"
+)
+
+var orgNumberRegexp = regexp.MustCompile(`^\d{9}$`)
+
+var identityDefinitions = []*cbc.Definition{
+	{
+		Key: IdentityKeyOrgNumber,
+		Name: i18n.String{
+			i18n.EN: "Organisation Number",
+			i18n.NB: "Organisasjonsnummer",
+		},
+	},
+}
+
+// normalizeOrgNumber removes any spaces or separators from the
+// organisation number, which is often presented as "NNN NNN NNN".
+func normalizeOrgNumber(id *org.Identity) {
+	if id == nil || id.Key != IdentityKeyOrgNumber {
+		return
+	}
+	id.Code = cbc.NormalizeNumericalCode(id.Code)
+}
+
+func validateOrgNumber(id *org.Identity) error {
+	if id == nil || id.Key != IdentityKeyOrgNumber {
+		return nil
+	}
+	return validation.ValidateStruct(id,
+		validation.Field(&id.Code,
+			validation.Required,
+			validation.Match(orgNumberRegexp),
+			validation.By(validateOrgNumberCode),
+			validation.Skip,
+		),
+	)
+}
+
+func validateOrgNumberCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+	if computeMod11CheckDigit(val[:8]) != val[8:] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
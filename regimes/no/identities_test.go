@@ -0,0 +1,61 @@
+package no_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/no"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrgNumberNormalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "already normalized", input: "974760673", expected: "974760673"},
+		{name: "with spaces", input: "974 760 673", expected: "974760673"},
+		{name: "with dots", input: "974.760.673", expected: "974760673"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tax.RegimeDefFor("NO")
+			id := &org.Identity{
+				Key:  no.IdentityKeyOrgNumber,
+				Code: cbc.Code(tt.input),
+			}
+			r.NormalizeObject(id)
+			assert.Equal(t, tt.expected, id.Code.String())
+		})
+	}
+}
+
+func TestOrgNumberValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "valid 1", code: "974760673"},
+		{name: "valid 2", code: "923609016"},
+		{name: "missing", code: "", err: "code: cannot be blank."},
+		{name: "too short", code: "97476067", err: "code: must be in a valid format."},
+		{name: "bad checksum", code: "974760674", err: "code: checksum mismatch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := &org.Identity{Key: no.IdentityKeyOrgNumber, Code: tt.code}
+			err := no.Validate(id)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
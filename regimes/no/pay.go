@@ -0,0 +1,76 @@
+package no
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/gobl/regimes/common"
+	"github.com/invopop/validation"
+)
+
+// Regime specific payment means extension keys.
+const (
+	// MeansKeyKID identifies bank transfers that must include a KID
+	// (kundeidentifikasjonsnummer) customer identification number so
+	// that the payment can be matched automatically by the recipient.
+	MeansKeyKID cbc.Key = "kid"
+)
+
+// KID numbers contain between 2 and 25 digits, with the last digit
+// acting as either a modulus 10 (Luhn) or modulus 11 check digit.
+var kidRegexp = regexp.MustCompile(`^\d{2,25}$`)
+
+var paymentMeansKeyDefinitions = []*cbc.Definition{
+	{
+		Key: pay.MeansKeyCreditTransfer.With(MeansKeyKID),
+		Name: i18n.String{
+			i18n.EN: "Credit Transfer with KID",
+			i18n.NB: "Betaling med KID",
+		},
+		Desc: i18n.String{
+			i18n.EN: "Bank transfer using a KID customer identification number in the remittance information.",
+		},
+	},
+}
+
+func normalizePayInstructions(instr *pay.Instructions) {
+	if instr == nil || !instr.Key.Has(MeansKeyKID) {
+		return
+	}
+	instr.Ref = cbc.NormalizeNumericalCode(instr.Ref)
+}
+
+func validatePayInstructions(instr *pay.Instructions) error {
+	return validation.ValidateStruct(instr,
+		validation.Field(&instr.Ref,
+			validation.When(
+				instr.Key.Has(MeansKeyKID),
+				validation.Required,
+				validation.By(validateKID),
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateKID(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+	if !kidRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+	n := len(val) - 1
+	if common.ComputeLuhnCheckDigit(val[:n]) == val[n:] {
+		return nil
+	}
+	if computeMod11CheckDigit(val[:n]) == val[n:] {
+		return nil
+	}
+	return errors.New("checksum mismatch")
+}
@@ -0,0 +1,57 @@
+package no_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/gobl/regimes/no"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayInstructionsNormalization(t *testing.T) {
+	instr := &pay.Instructions{
+		Key: "credit-transfer+kid",
+		Ref: "1234 5674",
+	}
+	no.Normalize(instr)
+	assert.Equal(t, cbc.Code("12345674"), instr.Ref)
+
+	instr = &pay.Instructions{
+		Key: "credit-transfer",
+		Ref: "INV-1234",
+	}
+	no.Normalize(instr)
+	assert.Equal(t, cbc.Code("INV-1234"), instr.Ref)
+}
+
+func TestPayInstructionsValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		key  cbc.Key
+		ref  cbc.Code
+		err  string
+	}{
+		{name: "valid MOD10", key: "credit-transfer+kid", ref: "1234567890128"},
+		{name: "valid MOD11", key: "credit-transfer+kid", ref: "123456785"},
+		{name: "valid MOD11 zero", key: "credit-transfer+kid", ref: "1234560"},
+		{name: "no OCR required", key: "credit-transfer", ref: "INV-1234"},
+		{name: "missing KID", key: "credit-transfer+kid", err: "ref: cannot be blank."},
+		{name: "too short", key: "credit-transfer+kid", ref: "1", err: "ref: invalid format."},
+		{name: "not numeric", key: "credit-transfer+kid", ref: "A2345674", err: "ref: invalid format."},
+		{name: "bad checksum", key: "credit-transfer+kid", ref: "123456786", err: "ref: checksum mismatch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instr := &pay.Instructions{Key: tt.key, Ref: tt.ref}
+			err := no.Validate(instr)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package no
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.NB: "MVA",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.NB: "Merverdiavgift",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Skatteetaten - VAT rates",
+					i18n.NB: "Skatteetaten - Satser for merverdiavgift",
+				},
+				URL: "https://www.skatteetaten.no/en/rates/value-added-tax/",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+					i18n.NB: "Nullsats",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.NB: "Alminnelig sats",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2005, 1, 1),
+						Percent: num.MakePercentage(250, 3),
+					},
+					{
+						Since:   cal.NewDate(2001, 1, 1),
+						Percent: num.MakePercentage(240, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.NB: "Redusert sats",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to food and beverages, excluding alcohol and tobacco.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2012, 1, 1),
+						Percent: num.MakePercentage(150, 3),
+					},
+					{
+						Since:   cal.NewDate(2007, 1, 1),
+						Percent: num.MakePercentage(140, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateSuperReduced,
+				Name: i18n.String{
+					i18n.EN: "Super-Reduced Rate",
+					i18n.NB: "Lav sats",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to passenger transport, hotel accommodation, and admission to cinemas, museums, amusement parks and sporting events.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2021, 10, 1),
+						Percent: num.MakePercentage(120, 3),
+					},
+					{
+						// Temporary reduction during the COVID-19 pandemic.
+						Since:   cal.NewDate(2020, 4, 1),
+						Percent: num.MakePercentage(60, 3),
+					},
+					{
+						Since:   cal.NewDate(2018, 1, 1),
+						Percent: num.MakePercentage(120, 3),
+					},
+					{
+						Since:   cal.NewDate(2016, 1, 1),
+						Percent: num.MakePercentage(100, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.NB: "Unntatt",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Supplies outside the scope of the VAT Act, such as health services, education, and financial services.",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,72 @@
+package no
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Norwegian VAT numbers are the 9 digit organisation number, usually
+// presented with the "NO" prefix and "MVA" suffix, e.g. "NO974760673MVA".
+var (
+	taxCodeRegexp   = regexp.MustCompile(`^\d{9}$`)
+	taxCodeSuffixes = regexp.MustCompile(`MVA$`)
+)
+
+// normalizeTaxIdentity will remove any whitespace or separation characters from
+// the tax code and also the "MVA" suffix used to indicate VAT registration.
+func normalizeTaxIdentity(tID *tax.Identity) {
+	if tID == nil {
+		return
+	}
+	tax.NormalizeIdentity(tID)
+	tID.Code = cbc.Code(taxCodeSuffixes.ReplaceAllString(tID.Code.String(), ""))
+}
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	if !taxCodeRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+
+	if computeMod11CheckDigit(val[:8]) != val[8:] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+// computeMod11CheckDigit calculates the modulus 11 check digit for the
+// provided number using weights 2 to 7 repeatedly from right to left, as
+// used by both organisation numbers and KID payment references. A "-" is
+// returned when no valid check digit exists for the number.
+func computeMod11CheckDigit(number string) string {
+	sum := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		pos := len(number) - 1 - i
+		sum += int(number[i]-'0') * (2 + pos%6)
+	}
+	switch c := 11 - (sum % 11); c {
+	case 11:
+		return "0"
+	case 10:
+		return "-"
+	default:
+		return strconv.Itoa(c)
+	}
+}
@@ -0,0 +1,72 @@
+package no_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/no"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "NO974760673MVA", expected: "974760673"},
+		{code: "974 760 673 MVA", expected: "974760673"},
+		{code: "no 974760673", expected: "974760673"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "NO", Code: ts.code}
+		no.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good 1", code: "974760673"},
+		{name: "good 2", code: "923609016"},
+		{name: "good 3", code: "988077917"},
+		{name: "empty", code: ""},
+		{
+			name: "too short",
+			code: "97476067",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "9747606731",
+			err:  "invalid format",
+		},
+		{
+			name: "not normalized",
+			code: "974760673MVA",
+			err:  "invalid format",
+		},
+		{
+			name: "bad checksum",
+			code: "123456789",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "NO", Code: tt.code}
+			err := no.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
@@ -13,15 +13,26 @@ import (
 	_ "github.com/invopop/gobl/regimes/ch"
 	_ "github.com/invopop/gobl/regimes/co"
 	_ "github.com/invopop/gobl/regimes/de"
+	_ "github.com/invopop/gobl/regimes/dk"
+	_ "github.com/invopop/gobl/regimes/ee"
 	_ "github.com/invopop/gobl/regimes/es"
+	_ "github.com/invopop/gobl/regimes/fi"
 	_ "github.com/invopop/gobl/regimes/fr"
 	_ "github.com/invopop/gobl/regimes/gb"
 	_ "github.com/invopop/gobl/regimes/gr"
+	_ "github.com/invopop/gobl/regimes/ie"
 	_ "github.com/invopop/gobl/regimes/in"
 	_ "github.com/invopop/gobl/regimes/it"
+	_ "github.com/invopop/gobl/regimes/lt"
+	_ "github.com/invopop/gobl/regimes/lu"
+	_ "github.com/invopop/gobl/regimes/lv"
 	_ "github.com/invopop/gobl/regimes/mx"
+	_ "github.com/invopop/gobl/regimes/my"
 	_ "github.com/invopop/gobl/regimes/nl"
+	_ "github.com/invopop/gobl/regimes/no"
 	_ "github.com/invopop/gobl/regimes/pl"
 	_ "github.com/invopop/gobl/regimes/pt"
+	_ "github.com/invopop/gobl/regimes/se"
+	_ "github.com/invopop/gobl/regimes/sg"
 	_ "github.com/invopop/gobl/regimes/us"
 )
@@ -0,0 +1,66 @@
+package se
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/validation"
+)
+
+const (
+	// IdentityKeyOrgNumber represents the Swedish organisation number
+	// (organisationsnummer) assigned to legal entities by the Swedish
+	// Companies Registration Office (Bolagsverket) or the tax agency.
+	IdentityKeyOrgNumber cbc.Key = "se-org-number"
+)
+
+var orgNumberRegexp = regexp.MustCompile(`^\d{6}-\d{4}$`)
+
+var identityDefinitions = []*cbc.Definition{
+	{
+		Key: IdentityKeyOrgNumber,
+		Name: i18n.String{
+			i18n.EN: "Organisation Number",
+			i18n.SV: "Organisationsnummer",
+		},
+	},
+}
+
+// normalizeOrgNumber will try to format the organisation number
+// as it is typically presented: NNNNNN-NNNN.
+func normalizeOrgNumber(id *org.Identity) {
+	if id == nil || id.Key != IdentityKeyOrgNumber {
+		return
+	}
+	code := cbc.NormalizeNumericalCode(id.Code).String()
+	if len(code) == 10 {
+		code = fmt.Sprintf("%s-%s", code[:6], code[6:])
+	}
+	id.Code = cbc.Code(code)
+}
+
+func validateOrgNumber(id *org.Identity) error {
+	if id == nil || id.Key != IdentityKeyOrgNumber {
+		return nil
+	}
+	return validation.ValidateStruct(id,
+		validation.Field(&id.Code,
+			validation.Required,
+			validation.Match(orgNumberRegexp),
+			validation.By(validateOrgNumberCode),
+			validation.Skip,
+		),
+	)
+}
+
+func validateOrgNumberCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+	return checkOrgNumber(val[:6] + val[7:])
+}
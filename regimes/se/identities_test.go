@@ -0,0 +1,63 @@
+package se_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/org"
+	"github.com/invopop/gobl/regimes/se"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrgNumberNormalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "already formatted", input: "556012-5790", expected: "556012-5790"},
+		{name: "without separators", input: "5560125790", expected: "556012-5790"},
+		{name: "with spaces", input: "556 012 5790", expected: "556012-5790"},
+		{name: "too short", input: "55601257", expected: "55601257"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tax.RegimeDefFor("SE")
+			id := &org.Identity{
+				Key:  se.IdentityKeyOrgNumber,
+				Code: cbc.Code(tt.input),
+			}
+			r.NormalizeObject(id)
+			assert.Equal(t, tt.expected, id.Code.String())
+		})
+	}
+}
+
+func TestOrgNumberValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "valid 1", code: "556012-5790"},
+		{name: "valid 2", code: "556036-0793"},
+		{name: "missing", code: "", err: "code: cannot be blank."},
+		{name: "no separator", code: "5560125790", err: "code: must be in a valid format."},
+		{name: "too short", code: "556012-579", err: "code: must be in a valid format."},
+		{name: "bad checksum", code: "556012-5791", err: "code: checksum mismatch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := &org.Identity{Key: se.IdentityKeyOrgNumber, Code: tt.code}
+			err := se.Validate(id)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package se
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/gobl/regimes/common"
+	"github.com/invopop/validation"
+)
+
+// Regime specific payment means extension keys.
+const (
+	// MeansKeyOCR identifies payments made via Bankgiro or Plusgiro that
+	// must include an OCR reference number so that the payment can be
+	// matched automatically by the recipient.
+	MeansKeyOCR cbc.Key = "ocr"
+)
+
+// OCR references contain between 2 and 25 digits, with the last
+// digit acting as a Luhn check digit.
+var ocrRefRegexp = regexp.MustCompile(`^\d{2,25}$`)
+
+var paymentMeansKeyDefinitions = []*cbc.Definition{
+	{
+		Key: pay.MeansKeyCreditTransfer.With(MeansKeyOCR),
+		Name: i18n.String{
+			i18n.EN: "Credit Transfer with OCR Reference",
+			i18n.SV: "Betalning med OCR-nummer",
+		},
+		Desc: i18n.String{
+			i18n.EN: "Bankgiro or Plusgiro payment using an OCR reference number in the remittance information.",
+		},
+	},
+}
+
+func normalizePayInstructions(instr *pay.Instructions) {
+	if instr == nil || !instr.Key.Has(MeansKeyOCR) {
+		return
+	}
+	instr.Ref = cbc.NormalizeNumericalCode(instr.Ref)
+}
+
+func validatePayInstructions(instr *pay.Instructions) error {
+	return validation.ValidateStruct(instr,
+		validation.Field(&instr.Ref,
+			validation.When(
+				instr.Key.Has(MeansKeyOCR),
+				validation.Required,
+				validation.By(validateOCRRef),
+			),
+			validation.Skip,
+		),
+	)
+}
+
+func validateOCRRef(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+	if !ocrRefRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+	n := len(val) - 1
+	if common.ComputeLuhnCheckDigit(val[:n]) != val[n:] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package se_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/pay"
+	"github.com/invopop/gobl/regimes/se"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayInstructionsNormalization(t *testing.T) {
+	instr := &pay.Instructions{
+		Key: "credit-transfer+ocr",
+		Ref: "1234 5674",
+	}
+	se.Normalize(instr)
+	assert.Equal(t, cbc.Code("12345674"), instr.Ref)
+
+	instr = &pay.Instructions{
+		Key: "credit-transfer",
+		Ref: "INV-1234",
+	}
+	se.Normalize(instr)
+	assert.Equal(t, cbc.Code("INV-1234"), instr.Ref)
+}
+
+func TestPayInstructionsValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		key  cbc.Key
+		ref  cbc.Code
+		err  string
+	}{
+		{name: "valid OCR 1", key: "credit-transfer+ocr", ref: "12345674"},
+		{name: "valid OCR 2", key: "credit-transfer+ocr", ref: "1234567890128"},
+		{name: "no OCR required", key: "credit-transfer", ref: "INV-1234"},
+		{name: "missing OCR", key: "credit-transfer+ocr", err: "ref: cannot be blank."},
+		{name: "too short", key: "credit-transfer+ocr", ref: "1", err: "ref: invalid format."},
+		{name: "not numeric", key: "credit-transfer+ocr", ref: "A2345674", err: "ref: invalid format."},
+		{name: "bad checksum", key: "credit-transfer+ocr", ref: "12345675", err: "ref: checksum mismatch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instr := &pay.Instructions{Key: tt.key, Ref: tt.ref}
+			err := se.Validate(instr)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
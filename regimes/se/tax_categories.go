@@ -0,0 +1,105 @@
+package se
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// VAT
+	//
+	{
+		Code: tax.CategoryVAT,
+		Name: i18n.String{
+			i18n.EN: "VAT",
+			i18n.SV: "Moms",
+		},
+		Title: i18n.String{
+			i18n.EN: "Value Added Tax",
+			i18n.SV: "Mervärdesskatt",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "Skatteverket - VAT",
+					i18n.SV: "Skatteverket - Moms",
+				},
+				URL: "https://www.skatteverket.se/foretag/moms",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+					i18n.SV: "Nollskattesats",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+					i18n.SV: "Normalskattesats",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(1990, 7, 1),
+						Percent: num.MakePercentage(250, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateReduced,
+				Name: i18n.String{
+					i18n.EN: "Reduced Rate",
+					i18n.SV: "Reducerad skattesats",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies mainly to food, restaurant and catering services, and hotel accommodation.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(1996, 1, 1),
+						Percent: num.MakePercentage(120, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateSuperReduced,
+				Name: i18n.String{
+					i18n.EN: "Super-Reduced Rate",
+					i18n.SV: "Lägre reducerad skattesats",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies mainly to books, newspapers, passenger transport, and cultural and sporting events.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(1996, 1, 1),
+						Percent: num.MakePercentage(60, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+					i18n.SV: "Undantagen",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Supplies exempt from VAT, such as healthcare, education, and banking and insurance services.",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,47 @@
+package se
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/common"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Swedish VAT numbers (momsregistreringsnummer) are composed of the
+// 10 digit organisation or personal identity number followed by "01".
+var (
+	taxCodeRegexp = regexp.MustCompile(`^\d{10}01$`)
+)
+
+// validateTaxIdentity checks to ensure the VAT code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	if !taxCodeRegexp.MatchString(val) {
+		return errors.New("invalid format")
+	}
+
+	return checkOrgNumber(val[:10])
+}
+
+// checkOrgNumber validates the Luhn check digit contained in the last
+// position of the 10 digit organisation or personal identity number.
+func checkOrgNumber(val string) error {
+	if common.ComputeLuhnCheckDigit(val[:9]) != val[9:] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
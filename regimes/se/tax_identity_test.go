@@ -0,0 +1,72 @@
+package se_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/se"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "SE556012579001", expected: "556012579001"},
+		{code: "556012-5790 01", expected: "556012579001"},
+		{code: "se 5560 1257 9001", expected: "556012579001"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "SE", Code: ts.code}
+		se.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "good 1", code: "556012579001"},
+		{name: "good 2", code: "556036079301"},
+		{name: "good 3", code: "202100548901"},
+		{name: "empty", code: ""},
+		{
+			name: "bad suffix",
+			code: "556012579002",
+			err:  "invalid format",
+		},
+		{
+			name: "too short",
+			code: "55601257901",
+			err:  "invalid format",
+		},
+		{
+			name: "too long",
+			code: "5560125790011",
+			err:  "invalid format",
+		},
+		{
+			name: "bad checksum",
+			code: "556012579101",
+			err:  "checksum mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "SE", Code: tt.code}
+			err := se.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.err)
+				}
+			}
+		})
+	}
+}
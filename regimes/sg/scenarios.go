@@ -0,0 +1,31 @@
+package sg
+
+import (
+	"github.com/invopop/gobl/bill"
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+)
+
+var invoiceScenarios = &tax.ScenarioSet{
+	Schema: bill.ShortSchemaInvoice,
+	List: []*tax.Scenario{
+		// Reverse Charges
+		{
+			Tags: []cbc.Key{tax.TagReverseCharge},
+			Note: &cbc.Note{
+				Key:  cbc.NoteKeyLegal,
+				Src:  tax.TagReverseCharge,
+				Text: "Reverse charge: Customer to account for GST to IRAS.",
+			},
+		},
+		// Simplified Tax Invoices
+		{
+			Tags: []cbc.Key{tax.TagSimplified},
+			Note: &cbc.Note{
+				Key:  cbc.NoteKeyLegal,
+				Src:  tax.TagSimplified,
+				Text: "Price payable includes GST.",
+			},
+		},
+	},
+}
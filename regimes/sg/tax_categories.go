@@ -0,0 +1,90 @@
+package sg
+
+import (
+	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/tax"
+)
+
+var taxCategories = []*tax.CategoryDef{
+	//
+	// GST
+	//
+	{
+		Code: tax.CategoryGST,
+		Name: i18n.String{
+			i18n.EN: "GST",
+		},
+		Title: i18n.String{
+			i18n.EN: "Goods and Services Tax",
+		},
+		Sources: []*tax.Source{
+			{
+				Title: i18n.String{
+					i18n.EN: "IRAS - Current GST Rates",
+				},
+				URL: "https://www.iras.gov.sg/taxes/goods-services-tax-(gst)/basics-of-gst/current-gst-rates",
+			},
+		},
+		Retained: false,
+		Rates: []*tax.RateDef{
+			{
+				Key: tax.RateZero,
+				Name: i18n.String{
+					i18n.EN: "Zero Rate",
+				},
+				Description: i18n.String{
+					i18n.EN: "Applies to the export of goods and international services.",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Percent: num.MakePercentage(0, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateStandard,
+				Name: i18n.String{
+					i18n.EN: "Standard Rate",
+				},
+				Values: []*tax.RateValueDef{
+					{
+						Since:   cal.NewDate(2024, 1, 1),
+						Percent: num.MakePercentage(90, 3),
+					},
+					{
+						Since:   cal.NewDate(2023, 1, 1),
+						Percent: num.MakePercentage(80, 3),
+					},
+					{
+						Since:   cal.NewDate(2007, 7, 1),
+						Percent: num.MakePercentage(70, 3),
+					},
+					{
+						Since:   cal.NewDate(2004, 1, 1),
+						Percent: num.MakePercentage(50, 3),
+					},
+					{
+						Since:   cal.NewDate(2003, 1, 1),
+						Percent: num.MakePercentage(40, 3),
+					},
+					{
+						Since:   cal.NewDate(1994, 4, 1),
+						Percent: num.MakePercentage(30, 3),
+					},
+				},
+			},
+			{
+				Key: tax.RateExempt,
+				Name: i18n.String{
+					i18n.EN: "Exempt",
+				},
+				Exempt: true,
+				Description: i18n.String{
+					i18n.EN: "Exempt supplies include the sale and lease of residential properties, financial services, and investment precious metals.",
+				},
+			},
+		},
+	},
+}
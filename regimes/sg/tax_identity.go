@@ -0,0 +1,124 @@
+package sg
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/tax"
+	"github.com/invopop/validation"
+)
+
+// Singapore businesses registered for GST use their Unique Entity Number
+// (UEN) as the GST registration number. The UEN may have one of three
+// formats depending on the issuing agency:
+//
+//   - businesses registered with ACRA: 8 digits and a check letter, e.g. "53222223K",
+//   - local companies registered with ACRA: the year of registration, 5 digits
+//     and a check letter, e.g. "199201624D",
+//   - other entities: "T", "S" or "R" for the century, 2 digits for the year,
+//     2 letters for the entity type, 4 digits and a check letter, e.g. "T08GA0028A".
+//
+// Entities without a UEN, such as overseas suppliers, are issued with a GST
+// registration number starting with "M", e.g. "M90312345A", that does not
+// include a publicly documented check character.
+var (
+	taxCodeBusinessRegexp     = regexp.MustCompile(`^\d{8}[A-Z]$`)
+	taxCodeLocalCompanyRegexp = regexp.MustCompile(`^(18|19|20)\d{7}[A-Z]$`)
+	taxCodeOtherRegexp        = regexp.MustCompile(`^[RST]\d{2}[A-Z]{2}\d{4}[A-Z]$`)
+	taxCodeGSTRegexp          = regexp.MustCompile(`^M[0-9X]\d{7}[A-Z]$`)
+
+	businessMultipliers     = []int{10, 4, 9, 3, 8, 2, 7, 1}
+	localCompanyMultipliers = []int{10, 8, 6, 4, 9, 7, 5, 3, 1}
+	otherMultipliers        = []int{4, 3, 5, 3, 10, 2, 2, 5, 7}
+
+	otherEntityTypes = []string{
+		"CC", "CD", "CH", "CL", "CM", "CP", "CS", "CX", "DP", "FB", "FC",
+		"FM", "FN", "GA", "GB", "GS", "HS", "LL", "LP", "MB", "MC", "MD",
+		"MH", "MM", "MQ", "NB", "NR", "PA", "PB", "PF", "RF", "RP", "SM",
+		"SS", "TC", "TU", "VH", "XL",
+	}
+)
+
+const (
+	businessCheckLetters     = "XMKECAWLJDB"
+	localCompanyCheckLetters = "ZKCMDNERGWH"
+	otherAlphabet            = "ABCDEFGHJKLMNPQRSTUVWX0123456789"
+)
+
+// validateTaxIdentity checks to ensure the UEN or GST code looks okay.
+func validateTaxIdentity(tID *tax.Identity) error {
+	return validation.ValidateStruct(tID,
+		validation.Field(&tID.Code, validation.By(validateTaxCode)),
+	)
+}
+
+func validateTaxCode(value any) error {
+	code, ok := value.(cbc.Code)
+	if !ok || code == "" {
+		return nil
+	}
+	val := code.String()
+
+	switch {
+	case taxCodeBusinessRegexp.MatchString(val):
+		return businessCheck(val)
+	case taxCodeLocalCompanyRegexp.MatchString(val):
+		return localCompanyCheck(val)
+	case taxCodeOtherRegexp.MatchString(val):
+		return otherCheck(val)
+	case taxCodeGSTRegexp.MatchString(val):
+		return nil
+	}
+	return errors.New("invalid format")
+}
+
+func businessCheck(val string) error {
+	sum := 0
+	for i, m := range businessMultipliers {
+		sum += int(val[i]-'0') * m
+	}
+	if businessCheckLetters[sum%11] != val[8] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+func localCompanyCheck(val string) error {
+	if val[:4] > time.Now().Format("2006") {
+		return errors.New("invalid registration year")
+	}
+	sum := 0
+	for i, m := range localCompanyMultipliers {
+		sum += int(val[i]-'0') * m
+	}
+	if localCompanyCheckLetters[sum%11] != val[9] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+func otherCheck(val string) error {
+	if !isOtherEntityType(val[3:5]) {
+		return errors.New("invalid entity type")
+	}
+	sum := 0
+	for i, m := range otherMultipliers {
+		sum += strings.IndexByte(otherAlphabet, val[i]) * m
+	}
+	if otherAlphabet[(sum-5+11*len(otherAlphabet))%11] != val[9] {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+func isOtherEntityType(et string) bool {
+	for _, v := range otherEntityTypes {
+		if v == et {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package sg_test
+
+import (
+	"testing"
+
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/regimes/sg"
+	"github.com/invopop/gobl/tax"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTaxIdentity(t *testing.T) {
+	tests := []struct {
+		code     cbc.Code
+		expected cbc.Code
+	}{
+		{code: "199201624D", expected: "199201624D"},
+		{code: "SG199201624D", expected: "199201624D"},
+		{code: "t08-ga-0028a", expected: "T08GA0028A"},
+		{code: " 53222223 K", expected: "53222223K"},
+	}
+	for _, ts := range tests {
+		tID := &tax.Identity{Country: "SG", Code: ts.code}
+		sg.Normalize(tID)
+		assert.Equal(t, ts.expected, tID.Code)
+	}
+}
+
+func TestValidateTaxIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		code cbc.Code
+		err  string
+	}{
+		{name: "local company 1", code: "196800306E"},
+		{name: "local company 2", code: "199201624D"},
+		{name: "local company 3", code: "200002150H"},
+		{name: "business 1", code: "00192200M"},
+		{name: "business 2", code: "53222223K"},
+		{name: "other 1", code: "S16FC0121D"},
+		{name: "other 2", code: "T08GA0028A"},
+		{name: "gst registration", code: "M90312345A"},
+		{name: "empty", code: ""},
+		{
+			name: "too short",
+			code: "1992016D",
+			err:  "invalid format",
+		},
+		{
+			name: "bad characters",
+			code: "1992O1624D",
+			err:  "invalid format",
+		},
+		{
+			name: "local company bad checksum",
+			code: "199201624E",
+			err:  "checksum mismatch",
+		},
+		{
+			name: "local company future year",
+			code: "209901624D",
+			err:  "invalid registration year",
+		},
+		{
+			name: "business bad checksum",
+			code: "53222223X",
+			err:  "checksum mismatch",
+		},
+		{
+			name: "other bad checksum",
+			code: "T08GA0028B",
+			err:  "checksum mismatch",
+		},
+		{
+			name: "other bad entity type",
+			code: "T08ZZ0028A",
+			err:  "invalid entity type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tID := &tax.Identity{Country: "SG", Code: tt.code}
+			err := sg.Validate(tID)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
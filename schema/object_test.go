@@ -5,6 +5,7 @@ import (
 
 	"github.com/invopop/gobl/bill"
 	"github.com/invopop/gobl/cal"
+	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/note"
 	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/org"
@@ -75,7 +76,7 @@ func exampleInvoice() *bill.Invoice {
 		Series: "TEST",
 		Code:   "000123",
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			Name: "Test Supplier",
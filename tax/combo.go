@@ -13,7 +13,9 @@ import (
 
 // Combo represents the tax combination of a category code and rate key. The percent
 // and retained attributes will be determined automatically from the Rate key if set
-// during calculation.
+// during calculation. Taxes such as excise duties or eco-fees that are charged as a
+// fixed amount, instead of a percentage of the line's total, may use the fixed
+// amount and per properties.
 type Combo struct {
 	// Tax category code from those available inside a region.
 	Category cbc.Code `json:"cat" jsonschema:"title=Category"`
@@ -27,11 +29,18 @@ type Combo struct {
 	Percent *num.Percentage `json:"percent,omitempty" jsonschema:"title=Percent" jsonschema_extras:"calculated=true"`
 	// Some countries require an additional surcharge (calculated if rate present).
 	Surcharge *num.Percentage `json:"surcharge,omitempty" jsonschema:"title=Surcharge" jsonschema_extras:"calculated=true"`
+	// Fixed amount of tax to apply instead of a percentage (calculated if rate present).
+	Fixed *num.Amount `json:"fixed,omitempty" jsonschema:"title=Fixed Amount" jsonschema_extras:"calculated=true"`
+	// Per determines if the fixed amount is applied to each unit of the line's
+	// quantity, the default, or once per line (calculated if rate present).
+	Per cbc.Key `json:"per,omitempty" jsonschema:"title=Per" jsonschema_extras:"calculated=true"`
 	// Local codes that apply for a given rate or percentage that need to be identified and validated.
 	Ext Extensions `json:"ext,omitempty" jsonschema:"title=Extensions"`
 
 	// Copied from the category definition, implies this tax combo is retained
 	retained bool `json:"-"`
+	// Copied from the category definition, other categories included in the base
+	baseIncludes []cbc.Code `json:"-"`
 }
 
 // ValidateWithContext ensures the Combo has the correct details.
@@ -53,11 +62,24 @@ func (c *Combo) ValidateWithContext(ctx context.Context) error {
 		validation.Field(&c.Rate,
 			r.InCategoryRates(c.Category),
 		),
-		validation.Field(&c.Percent),
+		validation.Field(&c.Percent,
+			validation.When(
+				c.Fixed != nil,
+				validation.Nil.Error("must be blank with fixed amount"),
+			),
+		),
 		validation.Field(&c.Surcharge, validation.When(
 			c.Percent == nil,
 			validation.Nil.Error("required with percent"),
 		)),
+		validation.Field(&c.Fixed),
+		validation.Field(&c.Per,
+			validation.When(
+				c.Fixed == nil,
+				validation.Empty.Error("must be blank without fixed amount"),
+			),
+			validation.In(PerUnit, PerLine),
+		),
 		validation.Field(&c.Ext),
 	)
 }
@@ -94,6 +116,7 @@ func (c *Combo) calculateForRegime(r *RegimeDef, tags []cbc.Key, date cal.Date)
 		return ErrInvalidCategory.WithMessage("'%s' not defined in regime", c.Category.String())
 	}
 	c.retained = category.Retained
+	c.baseIncludes = category.BaseIncludes
 
 	if err := c.prepareRate(category, tags, date); err != nil {
 		return err
@@ -134,6 +157,8 @@ func (c *Combo) prepareRate(category *CategoryDef, tags []cbc.Key, date cal.Date
 	if rate.Exempt {
 		c.Percent = nil
 		c.Surcharge = nil
+		c.Fixed = nil
+		c.Per = cbc.KeyEmpty
 		return nil
 	}
 
@@ -147,6 +172,17 @@ func (c *Combo) prepareRate(category *CategoryDef, tags []cbc.Key, date cal.Date
 		return ErrInvalidDate.WithMessage("rate value unavailable for '%s' in '%s' on '%s'", c.Rate.String(), c.Category.String(), date.String())
 	}
 
+	if value.Fixed != nil {
+		f := *value.Fixed // copy
+		c.Fixed = &f
+		c.Per = value.Per
+		c.Percent = nil
+		c.Surcharge = nil
+		return nil
+	}
+	c.Fixed = nil
+	c.Per = cbc.KeyEmpty
+
 	p := value.Percent // copy
 	c.Percent = &p
 
@@ -160,6 +196,25 @@ func (c *Combo) prepareRate(category *CategoryDef, tags []cbc.Key, date cal.Date
 	return nil
 }
 
+// FixedAmount provides the fixed amount of tax to apply to a line with
+// the given quantity, or nil if the combo does not define a fixed amount.
+// Amounts per line take the sign of the quantity, so that a negative
+// quantity will always reduce the tax due.
+func (c *Combo) FixedAmount(quantity num.Amount) *num.Amount {
+	if c == nil || c.Fixed == nil {
+		return nil
+	}
+	a := *c.Fixed
+	if c.Per == PerLine {
+		if quantity.IsNegative() {
+			a = a.Invert()
+		}
+	} else {
+		a = a.Multiply(quantity)
+	}
+	return &a
+}
+
 // UnmarshalJSON is a temporary migration helper that will move the
 // first of the "tags" array used in earlier versions of GOBL into
 // the rate field.
@@ -35,3 +35,13 @@ const (
 	// European Economic Area, used with exports
 	TagEEA cbc.Key = "eea"
 )
+
+// Fixed amount bases used to determine how a fixed tax amount is applied
+// to each taxable line.
+const (
+	// PerUnit multiplies the fixed amount by the line's quantity, and is
+	// assumed when empty.
+	PerUnit cbc.Key = "unit"
+	// PerLine applies the fixed amount once to each line.
+	PerLine cbc.Key = "line"
+)
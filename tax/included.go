@@ -0,0 +1,53 @@
+package tax
+
+import (
+	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/num"
+)
+
+// IncludedTaxes describes the combined amount of the taxes from one or more
+// categories that are included in the total of a taxable line, which will
+// always be `Factor × N + Fixed` for the line's net total N.
+type IncludedTaxes struct {
+	// Factor applied to the net total, from percentages.
+	Factor num.Amount
+	// Fixed amount that does not depend on the net total.
+	Fixed num.Amount
+}
+
+// IncludedTaxesFor determines the taxes from the categories that are included
+// in the line's total using the same approach as the totals calculator, so
+// that compound taxes and fixed amounts are taken into account. The line's
+// taxes are expected to have been calculated already. Nil is returned if
+// there are no included amounts to remove.
+func IncludedTaxesFor(line TaxableLine, cats []cbc.Code) *IncludedTaxes {
+	tl := mapTaxLines([]TaxableLine{line})[0]
+	cs := make([]*Combo, 0, len(cats))
+	for _, code := range cats {
+		if c := tl.taxes.Get(code); c != nil && !c.retained {
+			cs = append(cs, c)
+		}
+	}
+	return tl.included(cs)
+}
+
+// Remove provides the net total from the total including the taxes.
+func (it *IncludedTaxes) Remove(total num.Amount) num.Amount {
+	return total.Subtract(it.Fixed).Divide(it.divisor())
+}
+
+// Divisor provides the amount that a total including the taxes, and each of
+// the amounts it is composed of, such as prices, discounts, and charges,
+// should be divided by in order to obtain the net total.
+func (it *IncludedTaxes) Divisor(total num.Amount) num.Amount {
+	d := it.divisor()
+	net := total.Subtract(it.Fixed)
+	if it.Fixed.IsZero() || total.IsZero() || net.IsZero() {
+		return d
+	}
+	return d.Multiply(total).Divide(net)
+}
+
+func (it *IncludedTaxes) divisor() num.Amount {
+	return num.MakeAmount(1, 0).RescaleUp(it.Factor.Exp()).Add(it.Factor)
+}
@@ -53,6 +53,15 @@ type RegimeDef struct {
 	// `sum-then-round`.
 	CalculatorRoundingRule CalculatorRoundingRule `json:"calculator_rounding_rule,omitempty" jsonschema:"title=Calculator Rounding Rule"`
 
+	// Rounding mode used when reducing tax amounts and totals to the currency's
+	// precision, default is `half-up`.
+	CalculatorRoundingMode num.RoundingMode `json:"calculator_rounding_mode,omitempty" jsonschema:"title=Calculator Rounding Mode"`
+
+	// CashRounding defines the rule for rounding the payable amount of documents
+	// in the regime's currency according to the payment means, overriding the
+	// currency's own rule if any.
+	CashRounding *currency.RoundingRule `json:"cash_rounding,omitempty" jsonschema:"title=Cash Rounding"`
+
 	// Tags that can be applied at the document level to identify additional
 	// considerations.
 	Tags []*TagSet `json:"tags,omitempty" jsonschema:"title=Tags"`
@@ -110,6 +119,11 @@ type CategoryDef struct {
 	// income.
 	Retained bool `json:"retained,omitempty" jsonschema:"title=Retained"`
 
+	// BaseIncludes lists the codes of other categories whose tax amounts on
+	// each line form part of this category's taxable base, for compound
+	// taxes calculated on top of another tax.
+	BaseIncludes []cbc.Code `json:"base_includes,omitempty" jsonschema:"title=Base Includes"`
+
 	// Specific tax definitions inside this category. Order is important.
 	Rates []*RateDef `json:"rates,omitempty" jsonschema:"title=Rates"`
 
@@ -188,6 +202,11 @@ type RateValueDef struct {
 	Percent num.Percentage `json:"percent" jsonschema:"title=Percent"`
 	// An additional surcharge to apply.
 	Surcharge *num.Percentage `json:"surcharge,omitempty" jsonschema:"title=Surcharge"`
+	// Fixed amount of tax to apply instead of the percentage.
+	Fixed *num.Amount `json:"fixed,omitempty" jsonschema:"title=Fixed Amount"`
+	// Per determines if the fixed amount applies to each unit, the default,
+	// or once per line.
+	Per cbc.Key `json:"per,omitempty" jsonschema:"title=Per"`
 	// When true, this value should no longer be used.
 	Disabled bool `json:"disabled,omitempty" jsonschema:"title=Disabled"`
 }
@@ -275,6 +294,8 @@ func (r *RegimeDef) ValidateWithContext(ctx context.Context) error {
 		validation.Field(&r.Country),
 		validation.Field(&r.Zone),
 		validation.Field(&r.Currency),
+		validation.Field(&r.CalculatorRoundingMode),
+		validation.Field(&r.CashRounding),
 		validation.Field(&r.Tags),
 		validation.Field(&r.Identities),
 		validation.Field(&r.Extensions),
@@ -364,6 +385,13 @@ func (c *CategoryDef) ValidateWithContext(ctx context.Context) error {
 		validation.Field(&c.Name, validation.Required),
 		validation.Field(&c.Title, validation.Required),
 		validation.Field(&c.Description),
+		validation.Field(&c.BaseIncludes,
+			validation.Each(
+				r.InCategories(),
+				validation.NotIn(c.Code).Error("cannot include itself"),
+			),
+			validation.By(r.checkBaseIncludesCycle(c.Code)),
+		),
 		validation.Field(&c.Sources),
 		validation.Field(&c.Rates),
 		validation.Field(&c.Extensions,
@@ -374,6 +402,31 @@ func (c *CategoryDef) ValidateWithContext(ctx context.Context) error {
 	return err
 }
 
+// checkBaseIncludesCycle ensures that none of the categories included in the
+// base, directly or indirectly, include the original category in their own
+// base, which would make the calculation impossible.
+func (r *RegimeDef) checkBaseIncludesCycle(code cbc.Code) validation.RuleFunc {
+	return func(value any) error {
+		codes, _ := value.([]cbc.Code)
+		seen := make(map[cbc.Code]bool)
+		for len(codes) > 0 {
+			cd := codes[0]
+			codes = codes[1:]
+			if cd == code {
+				return fmt.Errorf("circular reference to '%s'", code)
+			}
+			if seen[cd] {
+				continue
+			}
+			seen[cd] = true
+			if cat := r.CategoryDef(cd); cat != nil {
+				codes = append(codes, cat.BaseIncludes...)
+			}
+		}
+		return nil
+	}
+}
+
 // Validate ensures the Source's contents are correct.
 func (s *Source) Validate() error {
 	return validation.ValidateStruct(s,
@@ -401,10 +454,38 @@ func (r *RateDef) ValidateWithContext(ctx context.Context) error {
 // Validate ensures the tax rate contains all the required fields.
 func (rv *RateValueDef) Validate() error {
 	return validation.ValidateStruct(rv,
-		validation.Field(&rv.Percent, validation.Required),
+		validation.Field(&rv.Percent,
+			validation.When(
+				rv.Fixed == nil,
+				validation.Required,
+			).Else(
+				validation.By(checkRateValuePercentBlank),
+			),
+		),
+		validation.Field(&rv.Surcharge,
+			validation.When(
+				rv.Fixed != nil,
+				validation.Nil.Error("must be blank with fixed amount"),
+			),
+		),
+		validation.Field(&rv.Per,
+			validation.When(
+				rv.Fixed == nil,
+				validation.Empty.Error("must be blank without fixed amount"),
+			),
+			validation.In(PerUnit, PerLine),
+		),
 	)
 }
 
+func checkRateValuePercentBlank(value any) error {
+	p, ok := value.(num.Percentage)
+	if ok && !p.IsZero() {
+		return errors.New("must be blank with fixed amount")
+	}
+	return nil
+}
+
 func checkRateValuesOrder(list interface{}) error {
 	values, ok := list.([]*RateValueDef)
 	if !ok {
@@ -429,6 +510,28 @@ func checkRateValuesOrder(list interface{}) error {
 	return nil
 }
 
+// RoundingMode provides the rounding mode to use when reducing the precision
+// of tax amounts and totals, or an empty mode for the default.
+func (r *RegimeDef) RoundingMode() num.RoundingMode {
+	if r == nil {
+		return ""
+	}
+	return r.CalculatorRoundingMode
+}
+
+// CashRoundingFor provides the rule to use for rounding payable amounts in the
+// given currency, using the regime's rule if the currency matches, or the
+// currency's own rule. Nil is returned if amounts should not be rounded.
+func (r *RegimeDef) CashRoundingFor(cur currency.Code) *currency.RoundingRule {
+	if r != nil && r.CashRounding != nil && r.Currency == cur {
+		return r.CashRounding
+	}
+	if d := cur.Def(); d != nil {
+		return d.CashRounding
+	}
+	return nil
+}
+
 // CategoryDef provides the requested category definition by its code.
 func (r *RegimeDef) CategoryDef(code cbc.Code) *CategoryDef {
 	if r == nil {
@@ -1,10 +1,13 @@
 package tax_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/invopop/gobl/cbc"
+	"github.com/invopop/gobl/i18n"
+	"github.com/invopop/gobl/num"
 	"github.com/invopop/gobl/tax"
 	"github.com/invopop/validation"
 	"github.com/stretchr/testify/assert"
@@ -30,3 +33,52 @@ func TestRegimeInCategoryRates(t *testing.T) {
 	err := validation.Validate(rate, r.InCategoryRates(tax.CategoryVAT))
 	assert.ErrorContains(t, err, "must be blank when regime is undefine")
 }
+
+func TestRateValueDefValidate(t *testing.T) {
+	rv := &tax.RateValueDef{
+		Percent: num.MakePercentage(160, 3),
+	}
+	assert.NoError(t, rv.Validate())
+
+	f := num.MakeAmount(16451, 4)
+	rv = &tax.RateValueDef{
+		Fixed: &f,
+		Per:   tax.PerUnit,
+	}
+	assert.NoError(t, rv.Validate())
+
+	rv.Percent = num.MakePercentage(80, 3)
+	assert.ErrorContains(t, rv.Validate(), "percent: must be blank with fixed amount")
+
+	rv = &tax.RateValueDef{Per: tax.PerLine}
+	assert.ErrorContains(t, rv.Validate(), "per: must be blank without fixed amount")
+}
+
+func TestCategoryDefBaseIncludes(t *testing.T) {
+	cat := func(code cbc.Code, includes ...cbc.Code) *tax.CategoryDef {
+		return &tax.CategoryDef{
+			Code:         code,
+			Name:         i18n.NewString(code.String()),
+			Title:        i18n.NewString(code.String()),
+			BaseIncludes: includes,
+		}
+	}
+	r := &tax.RegimeDef{
+		Categories: []*tax.CategoryDef{
+			cat("A", "B"),
+			cat("B", "C"),
+			cat("C"),
+			cat("D", "D"),
+			cat("E", "F"),
+			cat("F", "A", "E"),
+		},
+	}
+	ctx := r.WithContext(context.Background())
+	assert.NoError(t, r.Categories[0].ValidateWithContext(ctx))
+	assert.NoError(t, r.Categories[2].ValidateWithContext(ctx))
+	assert.ErrorContains(t, r.Categories[3].ValidateWithContext(ctx), "base_includes: (0: cannot include itself.)")
+	assert.ErrorContains(t, r.Categories[4].ValidateWithContext(ctx), "base_includes: circular reference to 'E'")
+
+	r.Categories[2].BaseIncludes = []cbc.Code{"X"}
+	assert.ErrorContains(t, r.Categories[2].ValidateWithContext(ctx), "base_includes: (0: must be a valid value.)")
+}
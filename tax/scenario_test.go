@@ -182,7 +182,7 @@ func scenariosInvoiceExample() *bill.Invoice {
 		Code:      "00123",
 		IssueDate: cal.MakeDate(2022, 6, 13),
 		Tax: &bill.Tax{
-			PricesInclude: tax.CategoryVAT,
+			PricesInclude: []cbc.Code{tax.CategoryVAT},
 		},
 		Supplier: &org.Party{
 			Name: "Test Supplier",
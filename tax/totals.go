@@ -8,11 +8,13 @@ import (
 
 // CategoryTotal groups together all rates inside a given category.
 type CategoryTotal struct {
-	Code      cbc.Code     `json:"code" jsonschema:"title=Code"`
-	Retained  bool         `json:"retained,omitempty" jsonschema:"title=Retained"`
-	Rates     []*RateTotal `json:"rates" jsonschema:"title=Rates"`
-	Amount    num.Amount   `json:"amount" jsonschema:"title=Amount"`
-	Surcharge *num.Amount  `json:"surcharge,omitempty" jsonschema:"title=Surcharge"`
+	Code     cbc.Code     `json:"code" jsonschema:"title=Code"`
+	Retained bool         `json:"retained,omitempty" jsonschema:"title=Retained"`
+	Rates    []*RateTotal `json:"rates" jsonschema:"title=Rates"`
+	Amount   num.Amount   `json:"amount" jsonschema:"title=Amount"`
+	// Amount of the category's tax that was already included in prices.
+	Included  *num.Amount `json:"included,omitempty" jsonschema:"title=Included"`
+	Surcharge *num.Amount `json:"surcharge,omitempty" jsonschema:"title=Surcharge"`
 
 	amount num.Amount // internal amount with greater accuracy
 }
@@ -35,6 +37,12 @@ type RateTotal struct {
 	Percent *num.Percentage `json:"percent,omitempty" jsonschema:"title=Percent"`
 	// Surcharge applied to the rate.
 	Surcharge *RateTotalSurcharge `json:"surcharge,omitempty" jsonschema:"title=Surcharge"`
+	// Fixed amount of tax applied per unit or line instead of a percentage.
+	Fixed *num.Amount `json:"fixed,omitempty" jsonschema:"title=Fixed Amount"`
+	// Per defines how the fixed amount is applied, either per unit or line.
+	Per cbc.Key `json:"per,omitempty" jsonschema:"title=Per"`
+	// Quantity of units or lines the fixed amount was applied to.
+	Quantity *num.Amount `json:"quantity,omitempty" jsonschema:"title=Quantity"`
 	// Total amount of rate, excluding surcharges
 	Amount num.Amount `json:"amount" jsonschema:"title=Amount"`
 }
@@ -78,6 +86,24 @@ func (t *Total) PreciseSum() num.Amount {
 	return t.Sum
 }
 
+// PreciseIncluded provides the sum of the amounts of all the categories
+// included in prices, with the original precision from the calculator, or
+// nil if no taxes were included.
+func (t *Total) PreciseIncluded() *num.Amount {
+	var ti *num.Amount
+	for _, ct := range t.Categories {
+		if ct.Included == nil {
+			continue
+		}
+		a := ct.PreciseAmount()
+		if ti != nil {
+			a = ti.Add(a)
+		}
+		ti = &a
+	}
+	return ti
+}
+
 // newCategoryTotal prepares a category total calculation.
 func newCategoryTotal(c *Combo, zero num.Amount) *CategoryTotal {
 	ct := new(CategoryTotal)
@@ -99,6 +125,13 @@ func newRateTotal(c *Combo, zero num.Amount) *RateTotal {
 		pc := *c.Percent
 		rt.Percent = &pc
 	}
+	if c.Fixed != nil {
+		f := *c.Fixed
+		rt.Fixed = &f
+		rt.Per = fixedPer(c.Per)
+		q := num.AmountZero
+		rt.Quantity = &q
+	}
 	rt.Base = zero
 	rt.Amount = zero
 	if c.Surcharge != nil {
@@ -110,6 +143,15 @@ func newRateTotal(c *Combo, zero num.Amount) *RateTotal {
 	return rt
 }
 
+// fixedPer normalizes the basis of a fixed amount, so that combos without one
+// are grouped with those applied per unit.
+func fixedPer(per cbc.Key) cbc.Key {
+	if per == cbc.KeyEmpty {
+		return PerUnit
+	}
+	return per
+}
+
 // Category provides the category total for the matching code.
 func (t *Total) Category(code cbc.Code) *CategoryTotal {
 	for _, ct := range t.Categories {
@@ -129,6 +171,12 @@ func (rt *RateTotal) matches(c *Combo) bool {
 	if rt.Country != c.Country {
 		return false
 	}
+	if rt.Fixed != nil || c.Fixed != nil {
+		if rt.Fixed == nil || c.Fixed == nil {
+			return false
+		}
+		return rt.Per == fixedPer(c.Per) && rt.Fixed.Equals(*c.Fixed)
+	}
 	if rt.Percent == nil || c.Percent == nil {
 		return rt.Percent == nil && c.Percent == nil
 	}
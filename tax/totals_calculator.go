@@ -5,6 +5,7 @@ import (
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/l10n"
 	"github.com/invopop/gobl/num"
+	"github.com/invopop/validation"
 )
 
 // CalculatorRoundingRule defines the available methods for calculating the
@@ -23,8 +24,29 @@ const (
 	// the amounts presented, but can lead to rounding errors in the case of
 	// pre-payments and when line item prices include tax.
 	CalculatorRoundThenSum CalculatorRoundingRule = "round-then-sum"
+	// CalculatorRoundPerLine will calculate and round the tax amounts of each
+	// line individually before summing them to determine the totals. This is
+	// the approach required by formats that report the taxes of each line,
+	// such as the Mexican CFDI.
+	CalculatorRoundPerLine CalculatorRoundingRule = "round-per-line"
 )
 
+// CalculatorRoundingRules contains the list of all supported rounding rules.
+var CalculatorRoundingRules = []CalculatorRoundingRule{
+	CalculatorSumThenRound,
+	CalculatorRoundThenSum,
+	CalculatorRoundPerLine,
+}
+
+// Validate ensures the rounding rule is one of those supported, or empty.
+func (r CalculatorRoundingRule) Validate() error {
+	rules := make([]any, len(CalculatorRoundingRules))
+	for i, v := range CalculatorRoundingRules {
+		rules[i] = string(v)
+	}
+	return validation.Validate(string(r), validation.In(rules...))
+}
+
 // TotalCalculator defines the base structure with the available
 // data for calculating tax totals.
 type TotalCalculator struct {
@@ -33,7 +55,9 @@ type TotalCalculator struct {
 	Zero     num.Amount
 	Date     cal.Date
 	Lines    []TaxableLine
-	Includes cbc.Code // Tax included in price
+	Includes []cbc.Code // Taxes included in price
+	// Rounding rule to use instead of the regime's default
+	Rounding CalculatorRoundingRule
 }
 
 // TaxableLine defines what we expect from a line in order to subsequently calculate
@@ -43,6 +67,19 @@ type TaxableLine interface {
 	GetTotal() num.Amount
 }
 
+// TaxableQuantity may be implemented by taxable lines in order to provide
+// the number of units that fixed tax amounts should be applied to. Lines
+// that do not implement this interface are assumed to contain a single unit.
+type TaxableQuantity interface {
+	GetQuantity() num.Amount
+}
+
+// TaxableLineAmounts may be implemented by taxable lines in order to receive
+// the tax amount calculated for each of their combos when rounding per line.
+type TaxableLineAmounts interface {
+	AddTaxAmount(c *Combo, amount num.Amount)
+}
+
 // Calculate the totals
 func (tc *TotalCalculator) Calculate(t *Total) error {
 	// reset
@@ -51,6 +88,11 @@ func (tc *TotalCalculator) Calculate(t *Total) error {
 
 	// get simplified list of lines
 	taxLines := mapTaxLines(tc.Lines)
+	if tc.roundPerLine() {
+		for _, tl := range taxLines {
+			tl.rescale = tc.rescale
+		}
+	}
 	if err := tc.prepareLines(taxLines); err != nil {
 		return err
 	}
@@ -82,21 +124,26 @@ func (tc *TotalCalculator) prepareLines(taxLines []*taxLine) error {
 }
 
 func (tc *TotalCalculator) removeIncludedTaxes(taxLines []*taxLine) error {
-	// If prices include a tax, perform a pre-loop to update all the line prices with
-	// the price minus the defined tax.
-	if tc.Includes.IsEmpty() {
+	// If prices include taxes, perform a pre-loop to update all the line prices with
+	// the price minus the defined taxes.
+	codes := tc.Includes
+	if len(codes) == 0 {
 		return nil
 	}
 	for _, tl := range taxLines {
-		if c := tl.taxes.Get(tc.Includes); c != nil {
-			if c.retained {
-				return ErrInvalidPricesInclude.WithMessage("cannot include retained category '%s'", tc.Includes.String())
+		cs := make([]*Combo, 0, len(codes))
+		for _, code := range codes {
+			if c := tl.taxes.Get(code); c != nil {
+				if c.retained {
+					return ErrInvalidPricesInclude.WithMessage("cannot include retained category '%s'", code.String())
+				}
+				cs = append(cs, c)
 			}
-			if c.Percent == nil {
-				// no taxes, skip
-				continue
-			}
-			tl.total = tl.total.Remove(*c.Percent)
+		}
+		if it := tl.included(cs); it != nil {
+			// multiple taxes are removed together so that the order in
+			// which they are defined does not affect the result.
+			tl.total = it.Remove(tl.total)
 		}
 	}
 	return nil
@@ -106,9 +153,25 @@ func (tc *TotalCalculator) calculateBaseRateTotals(taxLines []*taxLine, t *Total
 	// Go through each line and add the total to the base of each tax
 	for _, tl := range taxLines {
 		for _, c := range tl.taxes {
+			base := tl.base(c, nil)
 			rt := t.rateTotalFor(c, tc.Zero)
-			rt.Base = tc.matchPrecision(rt.Base, tl.total)
-			rt.Base = rt.Base.Add(tl.total)
+			if tl.rescale != nil {
+				base = tl.rescale(base)
+				tl.addAmounts(c, base, rt)
+			}
+			rt.Base = tc.matchPrecision(rt.Base, base)
+			rt.Base = rt.Base.Add(base)
+			if rt.Fixed != nil {
+				q := tl.quantity
+				if rt.Per == PerLine {
+					q = num.MakeAmount(1, 0)
+					if tl.quantity.IsNegative() {
+						q = q.Invert()
+					}
+				}
+				q = rt.Quantity.MatchPrecision(q).Add(q)
+				rt.Quantity = &q
+			}
 		}
 	}
 }
@@ -118,6 +181,10 @@ func (tc *TotalCalculator) calculateFinalSum(t *Total) {
 	t.Sum = tc.Zero
 	for _, ct := range t.Categories {
 		tc.calculateBaseCategoryTotal(ct)
+		if ct.Code.In(tc.Includes...) {
+			a := ct.Amount
+			ct.Included = &a
+		}
 
 		t.Sum = tc.matchPrecision(t.Sum, ct.Amount)
 		if ct.Retained {
@@ -138,76 +205,241 @@ func (tc *TotalCalculator) calculateBaseCategoryTotal(ct *CategoryTotal) {
 	zero := tc.Zero
 	ct.Amount = zero
 	for _, rt := range ct.Rates {
+		if tc.roundPerLine() {
+			// amounts already calculated from each line
+			tc.addRateTotal(ct, rt)
+			continue
+		}
+		if rt.Fixed != nil {
+			rt.Amount = rt.Fixed.Multiply(*rt.Quantity)
+			tc.addRateTotal(ct, rt)
+			continue
+		}
 		if rt.Percent == nil {
 			rt.Amount = zero
 			continue // exempt, nothing else to do
 		}
-		base := rt.Base
 		rt.Amount = rt.Percent.Of(rt.Base)
-		ct.Amount = tc.matchPrecision(ct.Amount, rt.Amount)
-		ct.Amount = ct.Amount.Add(rt.Amount)
 		if rt.Surcharge != nil {
-			rt.Surcharge.Amount = rt.Surcharge.Percent.Of(base)
-			if ct.Surcharge == nil {
-				ct.Surcharge = &zero
-			}
-			a := rt.Surcharge.Amount
-			x := *ct.Surcharge
-			x = tc.matchPrecision(x, a)
-			x = x.Add(a)
-			ct.Surcharge = &x
+			rt.Surcharge.Amount = rt.Surcharge.Percent.Of(rt.Base)
 		}
+		tc.addRateTotal(ct, rt)
 	}
 }
 
+// addRateTotal adds the rate total's amount and surcharge to the category.
+func (tc *TotalCalculator) addRateTotal(ct *CategoryTotal, rt *RateTotal) {
+	ct.Amount = tc.matchPrecision(ct.Amount, rt.Amount)
+	ct.Amount = ct.Amount.Add(rt.Amount)
+	if rt.Surcharge != nil {
+		if ct.Surcharge == nil {
+			zero := tc.Zero
+			ct.Surcharge = &zero
+		}
+		a := rt.Surcharge.Amount
+		x := *ct.Surcharge
+		x = tc.matchPrecision(x, a)
+		x = x.Add(a)
+		ct.Surcharge = &x
+	}
+}
+
+// roundingRule provides the calculator's rounding rule, or the regime's
+// default if none was set.
+func (tc *TotalCalculator) roundingRule() CalculatorRoundingRule {
+	if tc.Rounding != "" {
+		return tc.Rounding
+	}
+	if r := RegimeDefFor(tc.Country.Code()); r != nil {
+		return r.CalculatorRoundingRule
+	}
+	return ""
+}
+
+// roundPerLine returns true if taxes must be rounded for each line.
+func (tc *TotalCalculator) roundPerLine() bool {
+	return tc.roundingRule() == CalculatorRoundPerLine
+}
+
 // matchPrecision is used to match the precision of two amounts according to the
 // current rounding rule.
 func (tc *TotalCalculator) matchPrecision(a, b num.Amount) num.Amount {
-	r := RegimeDefFor(tc.Country.Code())
-	if r != nil {
-		switch r.CalculatorRoundingRule {
-		case CalculatorRoundThenSum:
-			return a.Rescale(tc.Zero.Exp())
-		}
+	switch tc.roundingRule() {
+	case CalculatorRoundThenSum:
+		return tc.rescale(a)
 	}
 	return a.MatchPrecision(b)
 }
 
+// rescale reduces the amount to the currency's precision using the regime's
+// rounding mode.
+func (tc *TotalCalculator) rescale(a num.Amount) num.Amount {
+	return a.RescaleWith(tc.Zero.Exp(), RegimeDefFor(tc.Country.Code()).RoundingMode())
+}
+
 // round will go through all the values generated and round them to the currency's
 // preferred precision. The final precise sum will be available in the t.sum variable
 // still.
 func (tc *TotalCalculator) round(t *Total) {
-	zero := tc.Zero
 	for _, ct := range t.Categories {
 		for _, rt := range ct.Rates {
-			rt.Amount = rt.Amount.Rescale(zero.Exp())
-			rt.Base = rt.Base.Rescale(zero.Exp())
+			rt.Amount = tc.rescale(rt.Amount)
+			rt.Base = tc.rescale(rt.Base)
 			if rt.Surcharge != nil {
-				rt.Surcharge.Amount = rt.Surcharge.Amount.Rescale(zero.Exp())
+				rt.Surcharge.Amount = tc.rescale(rt.Surcharge.Amount)
 			}
 		}
 		ct.amount = ct.Amount
-		ct.Amount = ct.Amount.Rescale(zero.Exp())
+		ct.Amount = tc.rescale(ct.Amount)
+		if ct.Included != nil {
+			*ct.Included = tc.rescale(*ct.Included)
+		}
 		if ct.Surcharge != nil {
-			*ct.Surcharge = ct.Surcharge.Rescale(zero.Exp())
+			*ct.Surcharge = tc.rescale(*ct.Surcharge)
 		}
 	}
 	t.sum = t.Sum
-	t.Sum = t.Sum.Rescale(zero.Exp())
+	t.Sum = tc.rescale(t.Sum)
 }
 
 // taxLine is used to replace
 type taxLine struct {
-	total num.Amount
-	taxes Set
+	total    num.Amount
+	quantity num.Amount
+	taxes    Set
+	// amounts receives the tax amounts when rounding per line, if supported
+	amounts TaxableLineAmounts
+	// rescale is set when tax amounts must be rounded per line
+	rescale func(num.Amount) num.Amount
+}
+
+// base provides the taxable base for the combo on this line, which will be the
+// line's total plus the amounts of any other categories included in the base.
+// Included categories are always calculated first, in the order defined by the
+// category, so that compound taxes are deterministic.
+func (tl *taxLine) base(c *Combo, seen []cbc.Code) num.Amount {
+	base := tl.total
+	for _, code := range c.baseIncludes {
+		if code.In(seen...) {
+			continue // circular reference, ignore
+		}
+		if ic := tl.taxes.Get(code); ic != nil {
+			base = base.Add(tl.amount(ic, append(seen, c.Category)))
+		}
+	}
+	return base
+}
+
+// amount provides the tax amount of the combo on this line, excluding surcharges.
+func (tl *taxLine) amount(c *Combo, seen []cbc.Code) num.Amount {
+	var a num.Amount
+	switch {
+	case c.Fixed != nil:
+		a = c.FixedAmount(tl.quantity).MatchPrecision(tl.total)
+	case c.Percent != nil:
+		base := tl.base(c, seen)
+		if tl.rescale != nil {
+			base = tl.rescale(base)
+		}
+		a = c.Percent.Of(base)
+	default:
+		return num.MakeAmount(0, tl.total.Exp())
+	}
+	if tl.rescale != nil {
+		a = tl.rescale(a)
+	}
+	return a
+}
+
+// addAmounts calculates the combo's tax amounts for this line with the
+// already rounded base, reports the amount to the original line, and adds
+// the amounts to the rate total.
+func (tl *taxLine) addAmounts(c *Combo, base num.Amount, rt *RateTotal) {
+	var a num.Amount
+	switch {
+	case c.Fixed != nil:
+		a = tl.rescale(*c.FixedAmount(tl.quantity))
+	case c.Percent != nil:
+		a = tl.rescale(c.Percent.Of(base))
+	default:
+		return // exempt
+	}
+	if tl.amounts != nil {
+		tl.amounts.AddTaxAmount(c, a)
+	}
+	rt.Amount = rt.Amount.Add(a)
+	if c.Surcharge != nil && rt.Surcharge != nil {
+		sa := tl.rescale(c.Surcharge.Of(base))
+		rt.Surcharge.Amount = rt.Surcharge.Amount.Add(sa)
+	}
+}
+
+// included provides the combined amounts of the combos, which must be
+// included in the line's total, or nil if there is nothing to remove. As
+// every tax amount is linear with respect to the line's net total N, the
+// sum of included amounts is expressed as a·N + b.
+func (tl *taxLine) included(cs []*Combo) *IncludedTaxes {
+	if len(cs) == 0 {
+		return nil
+	}
+	one := num.MakeAmount(1, 0).RescaleUp(tl.total.Exp() + 4)
+	it := &IncludedTaxes{
+		Factor: num.MakeAmount(0, one.Exp()),
+		Fixed:  num.MakeAmount(0, one.Exp()),
+	}
+	for _, c := range cs {
+		a, b := tl.linear(c, one, nil)
+		it.Factor = it.Factor.Add(a)
+		it.Fixed = it.Fixed.Add(b)
+	}
+	if it.Factor.IsZero() && it.Fixed.IsZero() {
+		return nil
+	}
+	return it
+}
+
+// linear provides the factor and constant that determine the combo's amount
+// on this line from the line's net total.
+func (tl *taxLine) linear(c *Combo, one num.Amount, seen []cbc.Code) (num.Amount, num.Amount) {
+	zero := num.MakeAmount(0, one.Exp())
+	if fa := c.FixedAmount(tl.quantity); fa != nil {
+		return zero, zero.Add(*fa)
+	}
+	if c.Percent == nil {
+		return zero, zero
+	}
+	a, b := one, zero
+	for _, code := range c.baseIncludes {
+		if code.In(seen...) {
+			continue
+		}
+		if ic := tl.taxes.Get(code); ic != nil {
+			ia, ib := tl.linear(ic, one, append(seen, c.Category))
+			a = a.Add(ia)
+			b = b.Add(ib)
+		}
+	}
+	return c.Percent.Of(a), c.Percent.Of(b)
 }
 
 func mapTaxLines(lines []TaxableLine) []*taxLine {
 	tls := make([]*taxLine, len(lines))
 	for i, v := range lines {
 		tls[i] = &taxLine{
-			total: v.GetTotal(),
-			taxes: v.GetTaxes(),
+			total:    v.GetTotal(),
+			quantity: num.MakeAmount(1, 0),
+			taxes:    v.GetTaxes(),
+		}
+		if tq, ok := v.(TaxableQuantity); ok {
+			tls[i].quantity = tq.GetQuantity().Abs()
+		}
+		if ta, ok := v.(TaxableLineAmounts); ok {
+			tls[i].amounts = ta
+		}
+		// fixed amounts follow the sign of the line's total so that negative
+		// lines, discounts, and inverted documents reduce the tax due.
+		if tls[i].total.IsNegative() {
+			tls[i].quantity = tls[i].quantity.Invert()
 		}
 	}
 	return tls
@@ -1,6 +1,7 @@
 package tax_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/invopop/gobl/cbc"
 	"github.com/invopop/gobl/l10n"
 	"github.com/invopop/gobl/num"
+	"github.com/invopop/gobl/regimes/ca"
 	"github.com/invopop/gobl/regimes/es"
 	"github.com/invopop/gobl/regimes/it"
 	"github.com/invopop/gobl/regimes/pt"
@@ -735,7 +737,8 @@ func TestTotalBySumCalculate(t *testing.T) {
 								Amount:  num.MakeAmount(1364, 2),
 							},
 						},
-						Amount: num.MakeAmount(3099, 2),
+						Amount:   num.MakeAmount(3099, 2),
+						Included: num.NewAmount(3099, 2),
 					},
 				},
 				Sum: num.MakeAmount(3099, 2),
@@ -781,7 +784,8 @@ func TestTotalBySumCalculate(t *testing.T) {
 								Amount:  num.MakeAmount(1364, 2),
 							},
 						},
-						Amount: num.MakeAmount(3099, 2),
+						Amount:   num.MakeAmount(3099, 2),
+						Included: num.NewAmount(3099, 2),
 					},
 				},
 				Sum: num.MakeAmount(3099, 2),
@@ -899,7 +903,8 @@ func TestTotalBySumCalculate(t *testing.T) {
 								Amount:  num.MakeAmount(1364, 2),
 							},
 						},
-						Amount: num.MakeAmount(3099, 2),
+						Amount:   num.MakeAmount(3099, 2),
+						Included: num.NewAmount(3099, 2),
 					},
 					{
 						Code:     es.TaxCategoryIRPF,
@@ -1016,7 +1021,8 @@ func TestTotalBySumCalculate(t *testing.T) {
 								Amount: num.MakeAmount(0, 2),
 							},
 						},
-						Amount: num.MakeAmount(0, 2),
+						Amount:   num.MakeAmount(0, 2),
+						Included: num.NewAmount(0, 2),
 					},
 				},
 				Sum: num.MakeAmount(0, 2),
@@ -1062,7 +1068,8 @@ func TestTotalBySumCalculate(t *testing.T) {
 								Amount: num.MakeAmount(0, 2),
 							},
 						},
-						Amount: num.MakeAmount(0, 2),
+						Amount:   num.MakeAmount(0, 2),
+						Included: num.NewAmount(0, 2),
 					},
 				},
 				Sum: num.MakeAmount(0, 2),
@@ -1113,7 +1120,8 @@ func TestTotalBySumCalculate(t *testing.T) {
 								Amount: num.MakeAmount(0, 2),
 							},
 						},
-						Amount: num.MakeAmount(1736, 2),
+						Amount:   num.MakeAmount(1736, 2),
+						Included: num.NewAmount(1736, 2),
 					},
 				},
 				Sum: num.MakeAmount(1736, 2),
@@ -1258,13 +1266,17 @@ func TestTotalBySumCalculate(t *testing.T) {
 			if test.country != "" {
 				country = test.country
 			}
+			var includes []cbc.Code
+			if test.taxIncluded != "" {
+				includes = []cbc.Code{test.taxIncluded}
+			}
 			tc := &tax.TotalCalculator{
 				Country:  country,
 				Tags:     test.tags,
 				Zero:     zero,
 				Date:     d,
 				Lines:    test.lines,
-				Includes: test.taxIncluded,
+				Includes: includes,
 			}
 			tot := new(tax.Total)
 			err := tc.Calculate(tot)
@@ -1288,3 +1300,440 @@ func TestTotalBySumCalculate(t *testing.T) {
 	}
 
 }
+
+type quantityLine struct {
+	taxes    tax.Set
+	amount   num.Amount
+	quantity num.Amount
+}
+
+func (ql *quantityLine) GetTaxes() tax.Set {
+	return ql.taxes
+}
+
+func (ql *quantityLine) GetTotal() num.Amount {
+	return ql.amount
+}
+
+func (ql *quantityLine) GetQuantity() num.Amount {
+	return ql.quantity
+}
+
+func TestTotalCalculatorFixedAmounts(t *testing.T) {
+	zero := num.MakeAmount(0, 2)
+	calculate := func(t *testing.T, includes []cbc.Code, lines ...tax.TaxableLine) *tax.Total {
+		t.Helper()
+		tc := &tax.TotalCalculator{
+			Country:  l10n.MX.Tax(),
+			Zero:     zero,
+			Date:     cal.MakeDate(2024, 6, 1),
+			Lines:    lines,
+			Includes: includes,
+		}
+		tot := new(tax.Total)
+		require.NoError(t, tc.Calculate(tot))
+		return tot
+	}
+	ieps := func(amount num.Amount, per cbc.Key) *tax.Combo {
+		return &tax.Combo{
+			Category: "IEPS",
+			Fixed:    &amount,
+			Per:      per,
+		}
+	}
+
+	t.Run("per unit", func(t *testing.T) {
+		tot := calculate(t, nil,
+			&quantityLine{
+				taxes: tax.Set{
+					{Category: tax.CategoryVAT, Rate: tax.RateStandard},
+					ieps(num.MakeAmount(16451, 4), ""),
+				},
+				amount:   num.MakeAmount(20000, 2),
+				quantity: num.MakeAmount(10, 0),
+			},
+			&quantityLine{
+				taxes: tax.Set{
+					ieps(num.MakeAmount(16451, 4), ""),
+				},
+				amount:   num.MakeAmount(5000, 2),
+				quantity: num.MakeAmount(25, 1),
+			},
+		)
+		ct := tot.Category("IEPS")
+		require.NotNil(t, ct)
+		require.Len(t, ct.Rates, 1)
+		rt := ct.Rates[0]
+		assert.Nil(t, rt.Percent)
+		assert.Equal(t, "1.6451", rt.Fixed.String())
+		assert.Equal(t, "12.5", rt.Quantity.String())
+		assert.Equal(t, "250.00", rt.Base.String())
+		assert.Equal(t, "20.56", rt.Amount.String())
+		assert.Equal(t, "20.56", ct.Amount.String())
+		assert.Equal(t, "52.56", tot.Sum.String())
+	})
+
+	t.Run("empty per grouped with unit", func(t *testing.T) {
+		tot := calculate(t, nil,
+			&quantityLine{
+				taxes:    tax.Set{ieps(num.MakeAmount(100, 2), "")},
+				amount:   num.MakeAmount(1000, 2),
+				quantity: num.MakeAmount(2, 0),
+			},
+			&quantityLine{
+				taxes:    tax.Set{ieps(num.MakeAmount(100, 2), tax.PerUnit)},
+				amount:   num.MakeAmount(1500, 2),
+				quantity: num.MakeAmount(3, 0),
+			},
+		)
+		ct := tot.Category("IEPS")
+		require.NotNil(t, ct)
+		require.Len(t, ct.Rates, 1)
+		assert.Equal(t, tax.PerUnit, ct.Rates[0].Per)
+		assert.Equal(t, "5", ct.Rates[0].Quantity.String())
+		assert.Equal(t, "5.00", ct.Rates[0].Amount.String())
+	})
+
+	t.Run("per line", func(t *testing.T) {
+		tot := calculate(t, nil,
+			&quantityLine{
+				taxes:    tax.Set{ieps(num.MakeAmount(200, 2), tax.PerLine)},
+				amount:   num.MakeAmount(30000, 2),
+				quantity: num.MakeAmount(3, 0),
+			},
+			&taxableLine{
+				taxes:  tax.Set{ieps(num.MakeAmount(200, 2), tax.PerLine)},
+				amount: num.MakeAmount(1000, 2),
+			},
+			&taxableLine{
+				taxes:  tax.Set{ieps(num.MakeAmount(50, 2), "")},
+				amount: num.MakeAmount(1000, 2),
+			},
+		)
+		ct := tot.Category("IEPS")
+		require.NotNil(t, ct)
+		require.Len(t, ct.Rates, 2)
+		assert.Equal(t, tax.PerLine, ct.Rates[0].Per)
+		assert.Equal(t, "2", ct.Rates[0].Quantity.String())
+		assert.Equal(t, "4.00", ct.Rates[0].Amount.String())
+		assert.Equal(t, "1", ct.Rates[1].Quantity.String())
+		assert.Equal(t, "0.50", ct.Rates[1].Amount.String())
+		assert.Equal(t, "4.50", tot.Sum.String())
+	})
+
+	t.Run("prices include fixed amount", func(t *testing.T) {
+		tot := calculate(t, []cbc.Code{"IEPS"},
+			&quantityLine{
+				taxes:    tax.Set{ieps(num.MakeAmount(100, 2), "")},
+				amount:   num.MakeAmount(10000, 2),
+				quantity: num.MakeAmount(2, 0),
+			},
+		)
+		ct := tot.Category("IEPS")
+		require.NotNil(t, ct)
+		assert.Equal(t, "98.00", ct.Rates[0].Base.String())
+		assert.Equal(t, "2.00", ct.Amount.String())
+	})
+}
+
+func TestComboFixedValidation(t *testing.T) {
+	ctx := tax.RegimeDefFor("MX").WithContext(context.Background())
+	f := num.MakeAmount(100, 2)
+	c := &tax.Combo{
+		Category: "IEPS",
+		Fixed:    &f,
+		Per:      tax.PerUnit,
+	}
+	assert.NoError(t, c.ValidateWithContext(ctx))
+
+	c.Percent = num.NewPercentage(8, 2)
+	assert.ErrorContains(t, c.ValidateWithContext(ctx), "percent: must be blank with fixed amount")
+
+	c.Percent = nil
+	c.Per = "box"
+	assert.ErrorContains(t, c.ValidateWithContext(ctx), "per: must be a valid value")
+
+	c.Fixed = nil
+	c.Per = tax.PerLine
+	assert.ErrorContains(t, c.ValidateWithContext(ctx), "per: must be blank without fixed amount")
+}
+
+func TestComboFixedAmount(t *testing.T) {
+	c := &tax.Combo{
+		Category: "IEPS",
+		Fixed:    num.NewAmount(150, 2),
+		Per:      tax.PerUnit,
+	}
+	assert.Equal(t, "4.50", c.FixedAmount(num.MakeAmount(3, 0)).String())
+	assert.Equal(t, "-4.50", c.FixedAmount(num.MakeAmount(-3, 0)).String())
+	c.Per = tax.PerLine
+	assert.Equal(t, "1.50", c.FixedAmount(num.MakeAmount(3, 0)).String())
+	assert.Equal(t, "-1.50", c.FixedAmount(num.MakeAmount(-3, 0)).String())
+	c.Fixed = nil
+	assert.Nil(t, c.FixedAmount(num.MakeAmount(3, 0)))
+}
+
+func TestTotalCalculatorCompound(t *testing.T) {
+	zero := num.MakeAmount(0, 2)
+	calculate := func(t *testing.T, includes []cbc.Code, amount num.Amount) *tax.Total {
+		t.Helper()
+		tc := &tax.TotalCalculator{
+			Country: l10n.ES.Tax(),
+			Zero:    zero,
+			Date:    cal.MakeDate(2024, 6, 1),
+			Lines: []tax.TaxableLine{
+				&taxableLine{
+					taxes: tax.Set{
+						{Category: es.TaxCategoryIGIC, Rate: tax.RateStandard},
+						{Category: es.TaxCategoryAIEM, Percent: num.NewPercentage(50, 3)},
+					},
+					amount: amount,
+				},
+				&taxableLine{
+					taxes: tax.Set{
+						{Category: es.TaxCategoryIGIC, Rate: tax.RateStandard},
+					},
+					amount: num.MakeAmount(10000, 2),
+				},
+			},
+			Includes: includes,
+		}
+		tot := new(tax.Total)
+		require.NoError(t, tc.Calculate(tot))
+		return tot
+	}
+
+	t.Run("base includes other category", func(t *testing.T) {
+		tot := calculate(t, nil, num.MakeAmount(10000, 2))
+		igic := tot.Category(es.TaxCategoryIGIC)
+		require.NotNil(t, igic)
+		assert.Equal(t, "205.00", igic.Rates[0].Base.String())
+		assert.Equal(t, "14.35", igic.Amount.String())
+		aiem := tot.Category(es.TaxCategoryAIEM)
+		require.NotNil(t, aiem)
+		assert.Equal(t, "100.00", aiem.Rates[0].Base.String())
+		assert.Equal(t, "5.00", aiem.Amount.String())
+		assert.Equal(t, "19.35", tot.Sum.String())
+	})
+
+	t.Run("prices include compound category", func(t *testing.T) {
+		tot := calculate(t, []cbc.Code{es.TaxCategoryIGIC}, num.MakeAmount(10735, 2))
+		igic := tot.Category(es.TaxCategoryIGIC)
+		assert.Equal(t, "198.46", igic.Rates[0].Base.String())
+		assert.Equal(t, "13.89", igic.Amount.String())
+		aiem := tot.Category(es.TaxCategoryAIEM)
+		assert.Equal(t, "100.00", aiem.Rates[0].Base.String())
+		assert.Equal(t, "5.00", aiem.Amount.String())
+	})
+
+	t.Run("prices include category in base", func(t *testing.T) {
+		tot := calculate(t, []cbc.Code{es.TaxCategoryAIEM}, num.MakeAmount(10500, 2))
+		igic := tot.Category(es.TaxCategoryIGIC)
+		assert.Equal(t, "205.00", igic.Rates[0].Base.String())
+		aiem := tot.Category(es.TaxCategoryAIEM)
+		assert.Equal(t, "100.00", aiem.Rates[0].Base.String())
+		assert.Equal(t, "5.00", aiem.Amount.String())
+	})
+}
+
+func TestTotalCalculatorMultipleIncludes(t *testing.T) {
+	zero := num.MakeAmount(0, 2)
+	calculate := func(t *testing.T, includes ...cbc.Code) *tax.Total {
+		t.Helper()
+		tc := &tax.TotalCalculator{
+			Country: l10n.CA.Tax(),
+			Zero:    zero,
+			Date:    cal.MakeDate(2024, 6, 1),
+			Lines: []tax.TaxableLine{
+				&taxableLine{
+					taxes: tax.Set{
+						{Category: tax.CategoryGST, Rate: tax.RateStandard},
+						{Category: ca.TaxCategoryPST, Percent: num.NewPercentage(7, 2)},
+					},
+					amount: num.MakeAmount(11200, 2),
+				},
+				&taxableLine{
+					taxes: tax.Set{
+						{Category: tax.CategoryGST, Rate: tax.RateStandard},
+						{Category: ca.TaxCategoryPST, Percent: num.NewPercentage(7, 2)},
+					},
+					amount: num.MakeAmount(1999, 2),
+				},
+			},
+			Includes: includes,
+		}
+		tot := new(tax.Total)
+		require.NoError(t, tc.Calculate(tot))
+		return tot
+	}
+
+	t.Run("both categories included", func(t *testing.T) {
+		tot := calculate(t, tax.CategoryGST, ca.TaxCategoryPST)
+		gst := tot.Category(tax.CategoryGST)
+		require.NotNil(t, gst)
+		require.NotNil(t, gst.Included)
+		assert.Equal(t, "5.89", gst.Included.String())
+		assert.Equal(t, "117.85", gst.Rates[0].Base.String())
+		assert.Equal(t, "5.89", gst.Amount.String())
+		pst := tot.Category(ca.TaxCategoryPST)
+		require.NotNil(t, pst)
+		require.NotNil(t, pst.Included)
+		assert.Equal(t, "8.25", pst.Included.String())
+		assert.Equal(t, "117.85", pst.Rates[0].Base.String())
+		assert.Equal(t, "8.25", pst.Amount.String())
+		assert.Equal(t, "14.14", tot.Sum.String())
+	})
+
+	t.Run("order does not matter", func(t *testing.T) {
+		t1 := calculate(t, tax.CategoryGST, ca.TaxCategoryPST)
+		t2 := calculate(t, ca.TaxCategoryPST, tax.CategoryGST)
+		assert.Equal(t, t1, t2)
+	})
+
+	t.Run("single category included", func(t *testing.T) {
+		tot := calculate(t, tax.CategoryGST)
+		gst := tot.Category(tax.CategoryGST)
+		require.NotNil(t, gst.Included)
+		assert.Equal(t, "6.29", gst.Included.String())
+		assert.Equal(t, "125.70", gst.Rates[0].Base.String())
+		pst := tot.Category(ca.TaxCategoryPST)
+		assert.Nil(t, pst.Included)
+	})
+
+	t.Run("retained category", func(t *testing.T) {
+		tc := &tax.TotalCalculator{
+			Country: l10n.ES.Tax(),
+			Zero:    zero,
+			Date:    cal.MakeDate(2024, 6, 1),
+			Lines: []tax.TaxableLine{
+				&taxableLine{
+					taxes: tax.Set{
+						{Category: tax.CategoryVAT, Rate: tax.RateStandard},
+						{Category: es.TaxCategoryIRPF, Rate: es.TaxRatePro},
+					},
+					amount: num.MakeAmount(10000, 2),
+				},
+			},
+			Includes: []cbc.Code{tax.CategoryVAT, es.TaxCategoryIRPF},
+		}
+		err := tc.Calculate(new(tax.Total))
+		assert.ErrorContains(t, err, "cannot include retained category 'IRPF'")
+	})
+}
+
+func TestTotalCalculatorRoundingMode(t *testing.T) {
+	r := tax.RegimeDefFor(l10n.ES)
+	defer func(mode num.RoundingMode) {
+		r.CalculatorRoundingMode = mode
+	}(r.CalculatorRoundingMode)
+
+	calculate := func(t *testing.T, mode num.RoundingMode) *tax.Total {
+		t.Helper()
+		r.CalculatorRoundingMode = mode
+		tc := &tax.TotalCalculator{
+			Country: l10n.ES.Tax(),
+			Zero:    num.MakeAmount(0, 2),
+			Date:    cal.MakeDate(2024, 6, 1),
+			Lines: []tax.TaxableLine{
+				&taxableLine{
+					taxes:  tax.Set{{Category: tax.CategoryVAT, Rate: tax.RateStandard}},
+					amount: num.MakeAmount(250, 2),
+				},
+			},
+		}
+		tot := new(tax.Total)
+		require.NoError(t, tc.Calculate(tot))
+		return tot
+	}
+
+	tests := []struct {
+		mode num.RoundingMode
+		sum  string
+	}{
+		{"", "0.53"},
+		{num.RoundingModeHalfUp, "0.53"},
+		{num.RoundingModeHalfEven, "0.52"},
+		{num.RoundingModeUp, "0.53"},
+		{num.RoundingModeDown, "0.52"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			tot := calculate(t, tt.mode)
+			assert.Equal(t, tt.sum, tot.Sum.String())
+			assert.Equal(t, tt.sum, tot.Categories[0].Amount.String())
+			assert.Equal(t, tt.sum, tot.Categories[0].Rates[0].Amount.String())
+		})
+	}
+}
+
+type taxableLineWithAmounts struct {
+	taxableLine
+	amounts map[cbc.Code]num.Amount
+}
+
+func (tl *taxableLineWithAmounts) AddTaxAmount(c *tax.Combo, a num.Amount) {
+	if tl.amounts == nil {
+		tl.amounts = make(map[cbc.Code]num.Amount)
+	}
+	tl.amounts[c.Category] = a
+}
+
+func TestTotalCalculatorRoundPerLine(t *testing.T) {
+	lines := func() []*taxableLineWithAmounts {
+		var tls []*taxableLineWithAmounts
+		for i := 0; i < 3; i++ {
+			tls = append(tls, &taxableLineWithAmounts{
+				taxableLine: taxableLine{
+					taxes: tax.Set{
+						{Category: tax.CategoryVAT, Rate: tax.RateStandard},
+						{Category: "ISR", Percent: num.NewPercentage(125, 4)},
+					},
+					amount: num.MakeAmount(103, 2),
+				},
+			})
+		}
+		return tls
+	}
+	calculator := func(tls []*taxableLineWithAmounts, rr tax.CalculatorRoundingRule) *tax.TotalCalculator {
+		tc := &tax.TotalCalculator{
+			Country:  l10n.MX.Tax(),
+			Zero:     num.MakeAmount(0, 2),
+			Date:     cal.MakeDate(2024, 6, 1),
+			Rounding: rr,
+		}
+		for _, tl := range tls {
+			tc.Lines = append(tc.Lines, tl)
+		}
+		return tc
+	}
+
+	t.Run("per line", func(t *testing.T) {
+		tls := lines()
+		tc := calculator(tls, tax.CalculatorRoundPerLine)
+		tot := new(tax.Total)
+		require.NoError(t, tc.Calculate(tot))
+		for _, tl := range tls {
+			assert.Equal(t, "0.16", tl.amounts[tax.CategoryVAT].String())
+			assert.Equal(t, "0.01", tl.amounts["ISR"].String())
+		}
+		vat := tot.Category(tax.CategoryVAT)
+		assert.Equal(t, "3.09", vat.Rates[0].Base.String())
+		assert.Equal(t, "0.48", vat.Rates[0].Amount.String())
+		assert.Equal(t, "0.48", vat.Amount.String())
+		assert.Equal(t, "0.03", tot.Category("ISR").Amount.String())
+		assert.Equal(t, "0.45", tot.Sum.String())
+	})
+
+	t.Run("regime default", func(t *testing.T) {
+		tls := lines()
+		tc := calculator(tls, "")
+		tot := new(tax.Total)
+		require.NoError(t, tc.Calculate(tot))
+		for _, tl := range tls {
+			assert.Nil(t, tl.amounts)
+		}
+		assert.Equal(t, "0.49", tot.Category(tax.CategoryVAT).Amount.String())
+		assert.Equal(t, "0.04", tot.Category("ISR").Amount.String())
+		assert.Equal(t, "0.46", tot.Sum.String())
+	})
+}